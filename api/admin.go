@@ -0,0 +1,234 @@
+// Package api
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/cfg"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const (
+	headerAuthorization = "Authorization"
+	bearerPrefix        = "Bearer "
+
+	contextOperator    = "operator"
+	contextAuditBefore = "auditBefore"
+
+	auditTimeout = 5 * time.Second
+)
+
+var (
+	ErrInvalidAdminToken = errors.New("invalid admin token")
+	ErrExpiredAdminToken = errors.New("admin token is expired")
+)
+
+var adminTokenEncoding = base64.RawURLEncoding
+
+// SignAdminToken return `claims.signature`, both are base64url without padding and signature is HMAC-SHA256 of claims
+func SignAdminToken(secret string, operator *types.Operator) (string, error) {
+	if secret == "" {
+		return "", errors.New("admin token secret is empty")
+	}
+	claims, err := json.Marshal(operator)
+	if err != nil {
+		return "", err
+	}
+	payload := adminTokenEncoding.EncodeToString(claims)
+	return payload + "." + adminTokenEncoding.EncodeToString(adminTokenSignature(secret, payload)), nil
+}
+
+func ParseAdminToken(secret, token string, now time.Time) (*types.Operator, error) {
+	parts := strings.Split(token, ".")
+	if secret == "" || len(parts) != 2 {
+		return nil, ErrInvalidAdminToken
+	}
+	signature, err := adminTokenEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, adminTokenSignature(secret, parts[0])) {
+		return nil, ErrInvalidAdminToken
+	}
+	claims, err := adminTokenEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidAdminToken
+	}
+	var operator *types.Operator
+	// tokens without jti could never be revoked, so they are not accepted
+	if err := json.Unmarshal(claims, &operator); err != nil || operator == nil || operator.Name == "" || operator.ID == "" {
+		return nil, ErrInvalidAdminToken
+	}
+	if now.Unix() >= operator.ExpiresAt {
+		return nil, ErrExpiredAdminToken
+	}
+	return operator, nil
+}
+
+func adminTokenSignature(secret, payload string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// RequestOperator return operator of admin routes, nil for other routes
+func RequestOperator(c echo.Context) *types.Operator {
+	operator, _ := c.Get(contextOperator).(*types.Operator)
+	return operator
+}
+
+func WithOperator(c echo.Context, operator *types.Operator) {
+	c.Set(contextOperator, operator)
+}
+
+// AuditBefore keep the record which is about to be updated, so audit log has the diff between it and payload
+func AuditBefore(c echo.Context, record interface{}) {
+	c.Set(contextAuditBefore, record)
+}
+
+type adminAuth struct {
+	srv    EchoServer
+	secret string
+}
+
+func newAdminAuth(srv EchoServer, cfg cfg.ExplorerConfig, logger *zap.Logger) *adminAuth {
+	if cfg.AdminTokenSecret == "" {
+		logger.Warn("ADMIN_TOKEN_SECRET is empty, admin routes are disabled")
+	}
+	return &adminAuth{srv: srv, secret: cfg.AdminTokenSecret}
+}
+
+// require let operators with any of roles through, and record their mutating calls to audit log
+func (a *adminAuth) require(roles []string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			token := c.Request().Header.Get(headerAuthorization)
+			if !strings.HasPrefix(token, bearerPrefix) {
+				return Unauthorized.Build(c)
+			}
+			operator, err := ParseAdminToken(a.secret, strings.TrimPrefix(token, bearerPrefix), time.Now())
+			if err != nil {
+				return Unauthorized.Build(c)
+			}
+			ctx, cancel := context.WithTimeout(c.Request().Context(), auditTimeout)
+			revoked, err := a.srv.AdminTokenRevoked(ctx, operator.ID)
+			cancel()
+			if err != nil {
+				return InternalServer.Build(c)
+			}
+			if revoked {
+				return Unauthorized.Build(c)
+			}
+			if !operator.HasRole(roles...) {
+				return Forbidden.Build(c)
+			}
+			c.Set(contextOperator, operator)
+			if c.Request().Method == echo.GET {
+				return next(c)
+			}
+
+			var payload interface{}
+			if c.Request().Body != nil {
+				if data, err := readBody(c.Request()); err == nil && len(data) > 0 {
+					_ = json.Unmarshal(data, &payload)
+				}
+			}
+			err = next(c)
+			status := c.Response().Status
+			if err != nil {
+				if he, ok := err.(*echo.HTTPError); ok {
+					status = he.Code
+				} else {
+					status = http.StatusInternalServerError
+				}
+			}
+			log := &types.AuditLog{
+				Operator: operator.Name,
+				Roles:    operator.Roles,
+				Method:   c.Request().Method,
+				Route:    c.Path(),
+				Path:     c.Request().URL.Path,
+				Payload:  payload,
+				Diff:     payloadDiff(c.Get(contextAuditBefore), payload),
+				Status:   status,
+				IP:       ClientIP(c),
+				Time:     time.Now(),
+			}
+			ctx, cancel = context.WithTimeout(context.Background(), auditTimeout)
+			a.srv.RecordAudit(ctx, log)
+			cancel()
+			return err
+		}
+	}
+}
+
+// payloadDiff compare top level fields of payload with the same fields of record, fields which are not changed are skipped
+func payloadDiff(record, payload interface{}) []*types.AuditChange {
+	fields, ok := payload.(map[string]interface{})
+	if record == nil || !ok {
+		return nil
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil
+	}
+	var before map[string]interface{}
+	if err := json.Unmarshal(data, &before); err != nil {
+		return nil
+	}
+	var changes []*types.AuditChange
+	for field, to := range fields {
+		from := before[field]
+		if reflect.DeepEqual(from, to) {
+			continue
+		}
+		changes = append(changes, &types.AuditChange{Field: field, From: from, To: to})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+func adminAPIs(srv EchoServer) []restDefinition {
+	return []restDefinition{
+		{
+			method:  echo.GET,
+			path:    "/admin/audit",
+			fn:      srv.AuditLogs,
+			roles:   []string{types.AdminRoleOps},
+			summary: "Audit log of mutating admin calls, latest first",
+			params: pagingParams(timeRangeParams(
+				queryParam("operator", paramString, "only calls of operator"),
+				queryParam("route", paramString, "only calls of route path, e.g. /contracts"),
+			)...),
+			response: pagedList("AuditLog"),
+		},
+		{
+			method:  echo.POST,
+			path:    "/admin/tokens/:jti/revoke",
+			fn:      srv.RevokeAdminToken,
+			roles:   []string{types.AdminRoleOps},
+			summary: "Revoke admin token by its jti, it's rejected from now on even if not expired",
+			params:  []param{pathParam("jti", paramString, "jti claim of token, printed by cmd/admintoken")},
+		},
+		{
+			method:   echo.GET,
+			path:     "/admin/me",
+			fn:       srv.AdminOperator,
+			roles:    types.AdminRoles,
+			summary:  "Operator and roles of admin token",
+			response: model("Operator"),
+		},
+	}
+}
@@ -0,0 +1,155 @@
+// Package api
+package api
+
+import (
+	"strings"
+	"time"
+
+	"github.com/labstack/echo"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const contextAPIKey = "apiKey"
+
+// RequestAPIKey return key of caller which is resolved by middleware, nil for anonymous requests
+func RequestAPIKey(c echo.Context) *types.APIKey {
+	key, _ := c.Get(contextAPIKey).(*types.APIKey)
+	return key
+}
+
+func WithAPIKey(c echo.Context, key *types.APIKey) {
+	c.Set(contextAPIKey, key)
+}
+
+// resolveAPIKey reject unknown or revoked keys, enforce daily quota of key tier and record usage per route.
+// Requests are served anonymously if keys cannot be looked up
+func resolveAPIKey(srv EchoServer) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			raw := strings.TrimSpace(c.Request().Header.Get(headerAPIKey))
+			if raw == "" {
+				return next(c)
+			}
+			ctx := c.Request().Context()
+			key, err := srv.ResolveAPIKey(ctx, raw)
+			if err != nil {
+				return next(c)
+			}
+			if key == nil {
+				return Unauthorized.Build(c)
+			}
+			WithAPIKey(c, key)
+			route := c.Request().Method + " " + c.Path()
+
+			tier := types.APIKeyTierByName(key.Tier)
+			if !tier.Unlimited && tier.DailyQuota > 0 {
+				count, err := srv.CountAPIKeyRequest(ctx, key)
+				if err == nil && count > tier.DailyQuota {
+					srv.RecordAPIKeyUsage(key, route, true)
+					c.Response().Header().Set(headerRetryAfter, seconds(untilNextDay(time.Now())))
+					return TooManyRequests.Build(c)
+				}
+			}
+			err = next(c)
+			srv.RecordAPIKeyUsage(key, route, c.Response().Status == TooManyRequests.StatusCode)
+			return err
+		}
+	}
+}
+
+// untilNextDay is when daily quota is reset
+func untilNextDay(now time.Time) time.Duration {
+	now = now.UTC()
+	return now.Truncate(24 * time.Hour).Add(24 * time.Hour).Sub(now)
+}
+
+// key holders see their own key by X-API-Key header, other routes are for ops
+func apiKeyAPIs(srv EchoServer) []restDefinition {
+	id := pathParam("id", paramString, "API key ID")
+	ops := []string{types.AdminRoleOps}
+	return []restDefinition{
+		{
+			method:   echo.POST,
+			path:     "/admin/apikeys",
+			fn:       srv.CreateAPIKey,
+			roles:    ops,
+			summary:  "Create API key, the key is only returned here",
+			request:  model("APIKeyRequest"),
+			response: model("APIKey"),
+		},
+		{
+			method:  echo.GET,
+			path:    "/admin/apikeys",
+			fn:      srv.APIKeys,
+			roles:   ops,
+			summary: "API keys",
+			params: pagingParams(
+				queryParam("owner", paramString, "only keys of owner"),
+				enumParam("tier", "only keys of tier", types.APIKeyTierFree, types.APIKeyTierStandard, types.APIKeyTierPartner, types.APIKeyTierInternal),
+				queryParam("revoked", paramBoolean, "only revoked or active keys"),
+			),
+			response: pagedList("APIKey"),
+		},
+		{
+			method:   echo.GET,
+			path:     "/admin/apikeys/:id",
+			fn:       srv.APIKeyInfo,
+			roles:    ops,
+			summary:  "API key",
+			params:   []param{id},
+			response: model("APIKey"),
+		},
+		{
+			method:   echo.PUT,
+			path:     "/admin/apikeys/:id",
+			fn:       srv.UpdateAPIKey,
+			roles:    ops,
+			summary:  "Update owner or tier of API key",
+			params:   []param{id},
+			request:  model("APIKeyRequest"),
+			response: model("APIKey"),
+		},
+		{
+			method:   echo.POST,
+			path:     "/admin/apikeys/:id/rotate",
+			fn:       srv.RotateAPIKey,
+			roles:    ops,
+			summary:  "Replace API key, the new key is only returned here",
+			params:   []param{id},
+			response: model("APIKey"),
+		},
+		{
+			method:  echo.DELETE,
+			path:    "/admin/apikeys/:id",
+			fn:      srv.RevokeAPIKey,
+			roles:   ops,
+			summary: "Revoke API key",
+			params:  []param{id},
+		},
+		{
+			method:   echo.GET,
+			path:     "/admin/apikeys/:id/usage",
+			fn:       srv.APIKeyUsage,
+			roles:    ops,
+			summary:  "Daily usage of API key",
+			params:   timeRangeParams(id),
+			response: listOf("APIKeyUsage"),
+		},
+		{
+			method:   echo.GET,
+			path:     "/apikeys/tiers",
+			fn:       srv.APIKeyTiers,
+			summary:  "API key tiers",
+			response: listOf("APIKeyTier"),
+		},
+		{
+			method:   echo.GET,
+			path:     "/apikeys/me",
+			fn:       srv.OwnAPIKey,
+			summary:  "API key of caller with its recent usage",
+			params:   timeRangeParams(),
+			response: model("OwnAPIKey"),
+		},
+	}
+}
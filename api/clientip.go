@@ -0,0 +1,92 @@
+// Package api
+package api
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo"
+)
+
+const contextClientIP = "clientIP"
+
+// ClientIP return IP of caller which is resolved by middleware, or the direct peer if middleware is not used
+func ClientIP(c echo.Context) string {
+	if ip, ok := c.Get(contextClientIP).(string); ok {
+		return ip
+	}
+	return remoteIP(c.Request().RemoteAddr)
+}
+
+// clientIPResolver trust X-Forwarded-For and X-Real-IP only when they are set by one of trusted proxies,
+// otherwise any client could pick its own IP by sending them
+type clientIPResolver struct {
+	trusted []*net.IPNet
+}
+
+func newClientIPResolver(trustedProxies []string) (*clientIPResolver, error) {
+	r := &clientIPResolver{}
+	for _, entry := range trustedProxies {
+		network, err := parseIPNet(strings.TrimSpace(entry))
+		if err != nil {
+			return nil, err
+		}
+		r.trusted = append(r.trusted, network)
+	}
+	return r, nil
+}
+
+func (r *clientIPResolver) isTrusted(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range r.trusted {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve walk X-Forwarded-For from the right, the first hop which is not a trusted proxy is the client
+func (r *clientIPResolver) resolve(req *http.Request) string {
+	peer := remoteIP(req.RemoteAddr)
+	if !r.isTrusted(peer) {
+		return peer
+	}
+	if forwarded := req.Header.Get(echo.HeaderXForwardedFor); forwarded != "" {
+		hops := strings.Split(forwarded, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if net.ParseIP(hop) == nil {
+				break
+			}
+			if i == 0 || !r.isTrusted(hop) {
+				return hop
+			}
+		}
+	}
+	if realIP := strings.TrimSpace(req.Header.Get(echo.HeaderXRealIP)); net.ParseIP(realIP) != nil {
+		return realIP
+	}
+	return peer
+}
+
+func (r *clientIPResolver) middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(contextClientIP, r.resolve(c.Request()))
+			return next(c)
+		}
+	}
+}
+
+func remoteIP(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
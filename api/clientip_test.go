@@ -0,0 +1,43 @@
+// Package api
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_clientIPResolver_resolve(t *testing.T) {
+	r, err := newClientIPResolver([]string{"10.0.0.0/8", "127.0.0.1"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	cases := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		realIP     string
+		want       string
+	}{
+		{name: "direct", remoteAddr: "1.2.3.4:5000", want: "1.2.3.4"},
+		{name: "spoofed headers of untrusted peer", remoteAddr: "1.2.3.4:5000", forwarded: "5.6.7.8", realIP: "5.6.7.8", want: "1.2.3.4"},
+		{name: "trusted proxy", remoteAddr: "10.0.0.1:5000", forwarded: "5.6.7.8", want: "5.6.7.8"},
+		{name: "spoofed hop before trusted proxy", remoteAddr: "10.0.0.1:5000", forwarded: "9.9.9.9, 5.6.7.8, 10.0.0.2", want: "5.6.7.8"},
+		{name: "only trusted hops", remoteAddr: "127.0.0.1:5000", forwarded: "10.0.0.3, 10.0.0.2", want: "10.0.0.3"},
+		{name: "real IP of trusted proxy", remoteAddr: "127.0.0.1:5000", realIP: "5.6.7.8", want: "5.6.7.8"},
+		{name: "invalid header", remoteAddr: "127.0.0.1:5000", forwarded: "unknown", want: "127.0.0.1"},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		req.RemoteAddr = c.remoteAddr
+		if c.forwarded != "" {
+			req.Header.Set(echo.HeaderXForwardedFor, c.forwarded)
+		}
+		if c.realIP != "" {
+			req.Header.Set(echo.HeaderXRealIP, c.realIP)
+		}
+		assert.Equal(t, c.want, r.resolve(req), c.name)
+	}
+}
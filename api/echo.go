@@ -19,12 +19,12 @@
 package api
 
 import (
-	"fmt"
-
 	"github.com/labstack/echo"
 	"github.com/labstack/echo/middleware"
+	"go.uber.org/zap"
 
 	"github.com/kardiachain/kardia-explorer-backend/cfg"
+	"github.com/kardiachain/kardia-explorer-backend/types"
 )
 
 type restDefinition struct {
@@ -32,245 +32,427 @@ type restDefinition struct {
 	path        string
 	fn          func(c echo.Context) error
 	middlewares []echo.MiddlewareFunc
+	budget      rateBudget // default budget if empty
+	roles       []string   // admin route if set, operator token must have any of roles
+
+	// OpenAPI spec, params are also validated before calling fn
+	summary  string
+	params   []param
+	request  *body
+	response *body
 }
 
-func bind(gr *echo.Group, srv EchoServer) {
+var txTypeParam = enumParam("type", "only txs of type", types.TxTypes...)
+
+func routes(srv EchoServer) []restDefinition {
 	apis := []restDefinition{
 		{
-			method:      echo.GET,
-			path:        "/ping",
-			fn:          srv.Ping,
-			middlewares: nil,
+			method:   echo.GET,
+			path:     "/ping",
+			fn:       srv.Ping,
+			summary:  "Server version",
+			response: model("PingStat"),
 		},
 		{
-			method: echo.GET,
-			path:   "/dashboard/stats",
-			fn:     srv.Stats,
+			method:   echo.GET,
+			path:     "/dashboard/stats",
+			fn:       srv.Stats,
+			summary:  "Number of txs of latest blocks",
+			response: model("DashboardStats"),
 		},
 		{
-			method: echo.GET,
-			path:   "/dashboard/holders/total",
-			fn:     srv.TotalHolders,
+			method:  echo.GET,
+			path:    "/dashboard/charts",
+			fn:      srv.NetworkChart,
+			summary: "Network activity by day or hour, buckets without blocks are zero",
+			params: timeRangeParams(
+				param{name: "metric", in: paramQuery, typ: paramString, required: true, enum: types.ChartMetrics, desc: "charted metric"},
+				enumParam("interval", "bucket size, day by default", types.NetworkBucketDay, types.NetworkBucketHour),
+			),
+			response: model("Chart"),
 		},
 		{
-			method: echo.GET,
-			path:   "/dashboard/token",
-			fn:     srv.TokenInfo,
+			method:   echo.GET,
+			path:     "/dashboard/holders/total",
+			fn:       srv.TotalHolders,
+			summary:  "Total holders and contracts",
+			response: model("TotalHolders"),
 		},
 		{
-			method: echo.PUT,
-			path:   "/dashboard/token/supplies",
-			fn:     srv.UpdateSupplyAmounts,
+			method:   echo.GET,
+			path:     "/dashboard/token",
+			fn:       srv.TokenInfo,
+			summary:  "KAI market info",
+			response: model("TokenInfo"),
 		},
 		{
-			method: echo.PUT,
-			path:   "/nodes",
-			fn:     srv.UpsertNetworkNodes,
+			method:   echo.GET,
+			path:     "/dashboard/token/history",
+			fn:       srv.PriceHistory,
+			summary:  "Hourly KAI price in USD, default to last 30 days",
+			params:   timeRangeParams(),
+			response: listOf("PricePoint"),
 		},
 		{
-			method: echo.DELETE,
-			path:   "/nodes/:nodeID",
-			fn:     srv.RemoveNetworkNodes,
+			method:  echo.PUT,
+			path:    "/dashboard/token/supplies",
+			fn:      srv.UpdateSupplyAmounts,
+			roles:   []string{types.AdminRoleOps},
+			summary: "Update KAI supplies",
+			request: model("SupplyInfo"),
+		},
+		{
+			method:  echo.PUT,
+			path:    "/nodes",
+			fn:      srv.UpsertNetworkNodes,
+			roles:   []string{types.AdminRoleOps},
+			summary: "Upsert a custom network node",
+			request: model("NetworkNode"),
+		},
+		{
+			method:  echo.DELETE,
+			path:    "/nodes/:nodeID",
+			fn:      srv.RemoveNetworkNodes,
+			roles:   []string{types.AdminRoleOps},
+			summary: "Remove a custom network node",
+			params:  []param{pathParam("nodeID", paramString, "node ID")},
 		},
 		// Blocks
 		{
-			method: echo.GET,
-			// Query params: ?page=0&limit=10
-			path: "/blocks",
-			fn:   srv.Blocks,
+			method:  echo.GET,
+			path:    "/blocks",
+			fn:      srv.Blocks,
+			summary: "Latest blocks",
+			params: cursorParams(timeRangeParams(
+				queryParam("fromHeight", paramInteger, "first block height"),
+				queryParam("toHeight", paramInteger, "last block height"),
+				queryParam("proposer", paramAddress, "only blocks of proposer"),
+			)...),
+			response: pagedList("SimpleBlock"),
 		},
 		{
-			method: echo.GET,
-			path:   "/blocks/:block",
-			fn:     srv.Block,
+			method:   echo.GET,
+			path:     "/blocks/:block",
+			fn:       srv.Block,
+			summary:  "Block by hash or height",
+			params:   []param{pathParam("block", paramString, "block hash or height")},
+			response: model("Block"),
 		},
 		{
-			method: echo.GET,
-			path:   "/blocks/error",
-			fn:     srv.PersistentErrorBlocks,
+			method:   echo.GET,
+			path:     "/blocks/error",
+			fn:       srv.PersistentErrorBlocks,
+			summary:  "Heights of blocks which failed to be imported",
+			response: model("BlockHeights"),
 		},
 		{
-			method: echo.GET,
-			// Params: proposer address
-			// Query params: ?page=0&limit=10
-			path:        "/blocks/proposer/:address",
-			fn:          srv.BlocksByProposer,
-			middlewares: nil,
+			method:   echo.GET,
+			path:     "/blocks/proposer/:address",
+			fn:       srv.BlocksByProposer,
+			summary:  "Blocks proposed by address",
+			params:   pagingParams(pathParam("address", paramAddress, "proposer address")),
+			response: pagedList("SimpleBlock"),
 		},
 		{
-			method: echo.GET,
-			// Params: block's hash
-			// Query params: ?page=0&limit=10
+			method:      echo.GET,
 			path:        "/block/:block/txs",
 			fn:          srv.BlockTxs,
 			middlewares: []echo.MiddlewareFunc{checkPagination()},
+			summary:     "Txs of block by hash or height",
+			params:      pagingParams(pathParam("block", paramString, "block hash or height"), txTypeParam),
+			response:    pagedList("SimpleTransaction"),
 		},
 		{
-			method: echo.GET,
-			path:   "/txs/:txHash",
-			fn:     srv.TxByHash,
+			method:   echo.GET,
+			path:     "/txs/:txHash",
+			fn:       srv.TxByHash,
+			summary:  "Tx by hash",
+			params:   []param{pathParam("txHash", paramHash, "tx hash")},
+			response: model("Transaction"),
 		},
 		{
-			method: echo.GET,
-			// Query params: ?page=0&limit=10
+			method:      echo.GET,
 			path:        "/txs",
 			fn:          srv.Txs,
 			middlewares: []echo.MiddlewareFunc{checkPagination()},
+			summary:     "Latest txs, status, type, method and value filters can only be combined with toAddress",
+			params: cursorParams(timeRangeParams(
+				queryParam("fromBlock", paramInteger, "first block height"),
+				queryParam("toBlock", paramInteger, "last block height"),
+				enumParam("status", "tx status", "success", "failed"),
+				txTypeParam,
+				queryParam("toAddress", paramAddress, "receiver or called contract"),
+				queryParam("method", paramString, "method name or 0x prefixed 4 bytes selector"),
+				queryParam("minValue", paramNumber, "minimum value in KAI"),
+				queryParam("maxValue", paramNumber, "maximum value in KAI"),
+			)...),
+			response: pagedList("SimpleTransaction"),
 		},
 		// Address
 		{
-			method: echo.GET,
-			// Query params: ?page=0&limit=10&sort=1
-			path: "/addresses",
-			fn:   srv.Addresses,
+			method:   echo.GET,
+			path:     "/addresses",
+			fn:       srv.Addresses,
+			summary:  "Rich list, addresses sorted by balance with share of supply and rank changes",
+			params:   pagingParams(enumParam("sort", "sort direction of balance", "1", "-1")),
+			response: pagedList("SimpleAddress"),
+			budget:   budgetExpensive,
 		},
 		{
-			method: echo.GET,
-			path:   "/addresses/:address",
-			fn:     srv.AddressInfo,
+			method:   echo.GET,
+			path:     "/addresses/:address",
+			fn:       srv.AddressInfo,
+			summary:  "Address info",
+			params:   []param{pathParam("address", paramAddress, "address")},
+			response: model("SimpleAddress"),
+			budget:   budgetExpensive,
 		},
 		{
-			method: echo.POST,
-			path:   "/addresses/reload",
-			fn:     srv.ReloadAddressesBalance,
+			method:  echo.POST,
+			path:    "/addresses/reload",
+			fn:      srv.ReloadAddressesBalance,
+			roles:   []string{types.AdminRoleOps},
+			summary: "Reload balances of all addresses",
 		},
 		// Tokens
 		{
-			method:      echo.GET,
-			path:        "/addresses/:address/txs",
-			fn:          srv.AddressTxs,
-			middlewares: nil,
-		},
-		{
-			method: echo.GET,
-			// Query params: ?page=0&limit=10&contractAddress=0x
-			path:        "/addresses/:address/tokens",
-			fn:          srv.AddressHolders,
-			middlewares: nil,
+			method:   echo.GET,
+			path:     "/addresses/:address/txs",
+			fn:       srv.AddressTxs,
+			summary:  "Txs of address",
+			params:   cursorParams(pathParam("address", paramAddress, "address"), txTypeParam),
+			response: pagedList("SimpleTransaction"),
 		},
 		{
-			method:      echo.GET,
-			path:        "/nodes",
-			fn:          srv.Nodes,
-			middlewares: nil,
+			method:  echo.GET,
+			path:    "/addresses/:address/tokens",
+			fn:      srv.AddressHolders,
+			summary: "Token balances of address",
+			params: pagingParams(
+				pathParam("address", paramAddress, "holder address"),
+				queryParam("contractAddress", paramAddress, "only balance of this token"),
+			),
+			response: pagedList("TokenHolder"),
 		},
-		// Proposal
 		{
-			method: echo.GET,
-			// Query params: ?page=0&limit=10
-			path:        "/proposal",
-			fn:          srv.GetProposalsList,
-			middlewares: nil,
+			method:   echo.GET,
+			path:     "/metrics/ratelimit",
+			fn:       srv.RateLimitMetrics,
+			roles:    []string{types.AdminRoleOps},
+			summary:  "Allowed and limited requests by rate limit budget",
+			response: model("RateLimitCounters"),
 		},
 		{
-			method:      echo.GET,
-			path:        "/proposal/:id",
-			fn:          srv.GetProposalDetails,
-			middlewares: nil,
-		},
-		{
-			method:      echo.GET,
-			path:        "/proposal/params",
-			fn:          srv.GetParams,
-			middlewares: nil,
-		},
-		{
-			method:      echo.PUT,
-			path:        "/addresses",
-			fn:          srv.UpdateAddressName,
-			middlewares: nil,
-		},
-		{
-			method:      echo.POST,
-			path:        "/validators/reload",
-			fn:          srv.ReloadValidators,
-			middlewares: nil,
-		},
-		{
-			method:      echo.GET,
-			path:        "/search",
-			fn:          srv.SearchAddressByName,
-			middlewares: nil,
-		},
-		{
-			method:      echo.GET,
-			path:        "/token/holders/:contractAddress",
-			fn:          srv.GetHoldersListByToken,
-			middlewares: nil,
+			method:   echo.GET,
+			path:     "/nodes",
+			fn:       srv.Nodes,
+			summary:  "Network nodes",
+			response: listOf("NodeInfo"),
+			budget:   budgetExpensive,
 		},
+		// Proposal
 		{
-			method: echo.GET,
-			// Query params: ?page=0&limit=10&address=0x&contractAddress=0x&txHash=0x
-			path:        "/token/txs",
-			fn:          srv.GetInternalTxs,
-			middlewares: nil,
+			method:   echo.GET,
+			path:     "/proposal",
+			fn:       srv.GetProposalsList,
+			summary:  "Network param proposals",
+			params:   pagingParams(),
+			response: pagedList("ProposalDetail"),
+			budget:   budgetExpensive,
+		},
+		{
+			method:   echo.GET,
+			path:     "/proposal/:id",
+			fn:       srv.GetProposalDetails,
+			summary:  "Network param proposal by ID",
+			params:   []param{pathParam("id", paramInteger, "proposal ID")},
+			response: model("ProposalDetail"),
+		},
+		{
+			method:   echo.GET,
+			path:     "/proposal/params",
+			fn:       srv.GetParams,
+			summary:  "Current network params",
+			response: model("ProposalParams"),
+		},
+		{
+			method:  echo.PUT,
+			path:    "/addresses",
+			fn:      srv.UpdateAddressName,
+			roles:   []string{types.AdminRoleLabels},
+			summary: "Update name of address",
+			request: model("UpdateAddress"),
+		},
+		{
+			method:  echo.POST,
+			path:    "/validators/reload",
+			fn:      srv.ReloadValidators,
+			roles:   []string{types.AdminRoleOps},
+			summary: "Reload validators from network",
+		},
+		{
+			method:  echo.GET,
+			path:    "/search",
+			fn:      srv.Search,
+			summary: "Search blocks by height or hash, txs by hash, addresses, and tokens, contracts or validators by name",
+			params: []param{
+				queryParam("q", paramString, "block height, block or tx hash, address, or name"),
+				queryParam("name", paramString, "name only, for old clients. Answered with the old list of addresses and contracts, or 400 if nothing matches"),
+			},
+			response: oneOf(listOf("SearchResult"), listOf("SimpleKRCTokenInfo")),
+			budget:   budgetExpensive,
+		},
+		{
+			method:  echo.GET,
+			path:    "/search/autocomplete",
+			fn:      srv.Autocomplete,
+			summary: "Typeahead of names and token symbols, most popular first",
+			params: []param{
+				{name: "q", in: paramQuery, typ: paramString, required: true, desc: "prefix of a name, or of a word in it"},
+				{name: "limit", in: paramQuery, typ: paramInteger, max: 25, desc: "number of entries, 10 by default"},
+			},
+			response: listOf("AutocompleteEntry"),
+		},
+		{
+			method:   echo.GET,
+			path:     "/token/holders/:contractAddress",
+			fn:       srv.GetHoldersListByToken,
+			summary:  "Holders of token",
+			params:   pagingParams(pathParam("contractAddress", paramAddress, "token address")),
+			response: pagedList("TokenHolder"),
+		},
+		{
+			method:  echo.GET,
+			path:    "/token/txs",
+			fn:      srv.GetInternalTxs,
+			summary: "Token transfers",
+			params: cursorParams(
+				queryParam("address", paramAddress, "sender or receiver"),
+				queryParam("contractAddress", paramAddress, "token address"),
+				queryParam("txHash", paramHash, "tx hash"),
+			),
+			response: pagedList("InternalTransaction"),
 		},
 	}
-	bindContractAPIs(gr, srv)
-	bindStakingAPIs(gr, srv)
-	for _, api := range apis {
-		gr.Add(api.method, api.path, api.fn, api.middlewares...)
+	apis = append(apis, contractAPIs(srv)...)
+	apis = append(apis, stakingAPIs(srv)...)
+	apis = append(apis, webhookAPIs(srv)...)
+	apis = append(apis, exportAPIs(srv)...)
+	apis = append(apis, apiKeyAPIs(srv)...)
+	apis = append(apis, adminAPIs(srv)...)
+	return apis
+}
+
+func bind(gr *echo.Group, srv EchoServer, spec *OpenAPI, limiter *rateLimiter, auth *adminAuth) {
+	for _, api := range spec.defs {
+		middlewares := []echo.MiddlewareFunc{resolveAPIKey(srv)}
+		if limiter != nil {
+			middlewares = append(middlewares, limiter.limit(api.budget))
+		}
+		if len(api.roles) > 0 {
+			middlewares = append(middlewares, auth.require(api.roles))
+		}
+		middlewares = append(middlewares, spec.validateRequest(api))
+		middlewares = append(middlewares, api.middlewares...)
+		gr.Add(api.method, api.path, api.fn, middlewares...)
 	}
 }
 
-func bindContractAPIs(gr *echo.Group, srv EchoServer) {
-	apis := []restDefinition{
-		{
-			method:      echo.POST,
-			path:        "/contracts",
-			fn:          srv.InsertContract,
-			middlewares: nil,
-		},
-		{
-			method:      echo.PUT,
-			path:        "/contracts",
-			fn:          srv.UpdateContract,
-			middlewares: nil,
-		},
-		{
-			method: echo.GET,
-			// Query params
-			// [?status=(Verified, Unverified)]
-			path:        "/contracts",
-			fn:          srv.Contracts,
-			middlewares: nil,
-		},
-		{
-			method:      echo.GET,
-			path:        "/contracts/:contractAddress",
-			fn:          srv.Contract,
-			middlewares: nil,
-		},
-		{
-			method:      echo.PUT,
-			path:        "/contracts/abi",
-			fn:          srv.UpdateSMCABIByType,
-			middlewares: nil,
-		},
-		{
-			method: echo.GET,
-			// Query params: ?page=0&limit=10&contractAddress=0x&methodName=0x&txHash=0x
-			path:        "/contracts/events",
-			fn:          srv.ContractEvents,
-			middlewares: nil,
+func contractAPIs(srv EchoServer) []restDefinition {
+	return []restDefinition{
+		{
+			method:  echo.POST,
+			path:    "/contracts",
+			fn:      srv.InsertContract,
+			roles:   []string{types.AdminRoleContracts},
+			summary: "Insert contract",
+			request: model("Contract", "Address"),
+		},
+		{
+			method:   echo.PUT,
+			path:     "/contracts",
+			fn:       srv.UpdateContract,
+			roles:    []string{types.AdminRoleContracts},
+			summary:  "Update contract",
+			request:  model("Contract", "Address"),
+			response: model("Address"),
+		},
+		{
+			method:  echo.GET,
+			path:    "/contracts",
+			fn:      srv.Contracts,
+			summary: "Contracts",
+			params: pagingParams(
+				enumParam("status", "verification status", "Verified", "Unverified"),
+				queryParam("type", paramString, "contract type, e.g. KRC20"),
+			),
+			response: pagedList("SimpleKRCTokenInfo"),
+		},
+		{
+			method:   echo.GET,
+			path:     "/contracts/:contractAddress",
+			fn:       srv.Contract,
+			summary:  "Contract info",
+			params:   []param{pathParam("contractAddress", paramAddress, "contract address")},
+			response: model("KRCTokenInfo"),
+		},
+		{
+			method:  echo.PUT,
+			path:    "/contracts/abi",
+			fn:      srv.UpdateSMCABIByType,
+			roles:   []string{types.AdminRoleContracts},
+			summary: "Update ABI of contract type",
+			request: model("ContractABI"),
+		},
+		{
+			method:  echo.GET,
+			path:    "/contracts/events",
+			fn:      srv.ContractEvents,
+			summary: "Decoded contract events",
+			params: pagingParams(
+				queryParam("contractAddress", paramAddress, "contract address"),
+				queryParam("methodName", paramString, "event name"),
+				queryParam("txHash", paramHash, "tx hash"),
+			),
+			response: pagedList("InternalTransaction"),
 		},
 	}
-	for _, api := range apis {
-		gr.Add(api.method, api.path, api.fn, api.middlewares...)
-	}
 }
 
-func Start(srv EchoServer, cfg cfg.ExplorerConfig) {
+func Start(srv EchoServer, cfg cfg.ExplorerConfig, logger *zap.Logger) {
 	e := echo.New()
 
-	e.Use(middleware.CORS())
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{AllowOrigins: cfg.CORSAllowOrigins}))
 	e.Use(middleware.Logger())
 	e.Use(middleware.Gzip())
 
+	spec, err := NewOpenAPI(srv)
+	if err != nil {
+		logger.Panic("cannot build OpenAPI spec", zap.Error(err))
+	}
+	ipResolver, err := newClientIPResolver(cfg.TrustedProxies)
+	if err != nil {
+		logger.Panic("cannot parse trusted proxies", zap.Error(err))
+	}
+	e.Use(ipResolver.middleware())
+	limiter, err := newRateLimiter(srv, cfg)
+	if err != nil {
+		logger.Panic("cannot create rate limiter", zap.Error(err))
+	}
+	limit := []echo.MiddlewareFunc{resolveAPIKey(srv)}
+	if limiter != nil {
+		limit = append(limit, limiter.limit(budgetDefault))
+	}
+
 	v1Gr := e.Group("/api/v1")
-	bind(v1Gr, srv)
+	bind(v1Gr, srv, spec, limiter, newAdminAuth(srv, cfg, logger))
+	v1Gr.GET("/openapi.json", spec.serve)
+	e.GET("/graphql", srv.GraphQL, limit...)
+	e.POST("/graphql", srv.GraphQL, limit...)
+	e.GET("/api", srv.EtherscanAPI, limit...)
+	e.POST("/rpc", srv.RPC, limit...)
+	e.GET("/ws", srv.Stream, limit...)
 	if err := e.Start(cfg.Port); err != nil {
-		fmt.Println("cannot start echo server", err.Error())
-		panic(err)
+		logger.Panic("cannot start echo server", zap.Error(err))
 	}
 }
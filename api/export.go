@@ -0,0 +1,68 @@
+// Package api
+package api
+
+import (
+	"github.com/labstack/echo"
+)
+
+// export APIs stream the whole result as CSV or NDJSON instead of paging. An export cut short by an error
+// ends with a row or line starting with `#error`, since status 200 is already sent
+var exportParams = timeRangeParams(
+	enumParam("format", "file format, csv by default", "csv", "ndjson"),
+	queryParam("fiat", paramBoolean, "add USD value at time of each row, only KAI and wrapped KAI have prices"),
+)
+
+func exportAPIs(srv EchoServer) []restDefinition {
+	address := pathParam("address", paramAddress, "address")
+	contract := pathParam("contract", paramAddress, "contract address")
+	return []restDefinition{
+		{
+			method:   echo.GET,
+			path:     "/export/addresses/:address/txs",
+			fn:       srv.ExportAddressTxs,
+			summary:  "Export txs of address",
+			params:   append([]param{address}, exportParams...),
+			response: download,
+			budget:   budgetExport,
+		},
+		{
+			method:  echo.GET,
+			path:    "/export/addresses/:address/tokenTransfers",
+			fn:      srv.ExportAddressTokenTransfers,
+			summary: "Export token transfers of address",
+			params: append([]param{
+				address,
+				queryParam("contract", paramAddress, "only transfers of this token"),
+			}, exportParams...),
+			response: download,
+			budget:   budgetExport,
+		},
+		{
+			method:   echo.GET,
+			path:     "/export/addresses/:address/rewards",
+			fn:       srv.ExportAddressRewards,
+			summary:  "Export staking rewards of address",
+			params:   append([]param{address}, exportParams...),
+			response: download,
+			budget:   budgetExport,
+		},
+		{
+			method:   echo.GET,
+			path:     "/export/tokens/:contract/holders",
+			fn:       srv.ExportTokenHolders,
+			summary:  "Export holders of token",
+			params:   append([]param{contract}, exportParams...),
+			response: download,
+			budget:   budgetExport,
+		},
+		{
+			method:   echo.GET,
+			path:     "/export/contracts/:contract/events",
+			fn:       srv.ExportContractEvents,
+			summary:  "Export decoded events of contract",
+			params:   append([]param{contract}, exportParams...),
+			response: download,
+			budget:   budgetExport,
+		},
+	}
+}
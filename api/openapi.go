@@ -0,0 +1,788 @@
+// Package api
+package api
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kardiachain/kardia-explorer-backend/cfg"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const (
+	paramPath  = "path"
+	paramQuery = "query"
+
+	paramString  = "string"
+	paramInteger = "integer"
+	paramNumber  = "number"
+	paramBoolean = "boolean"
+	paramAddress = "address"
+	paramHash    = "hash"
+)
+
+var (
+	addressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	hashPattern    = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+)
+
+// param is a path or query parameter of a route, it's documented in OpenAPI spec and validated before handler
+type param struct {
+	name     string
+	in       string
+	typ      string
+	required bool
+	enum     []string
+	max      int // maximum of integer param, 0 is unbounded
+	desc     string
+}
+
+func pathParam(name, typ, desc string) param {
+	return param{name: name, in: paramPath, typ: typ, required: true, desc: desc}
+}
+
+func queryParam(name, typ, desc string) param {
+	return param{name: name, in: paramQuery, typ: typ, desc: desc}
+}
+
+func enumParam(name, desc string, values ...string) param {
+	return param{name: name, in: paramQuery, typ: paramString, enum: values, desc: desc}
+}
+
+func pagingParams(extra ...param) []param {
+	return append([]param{
+		queryParam("page", paramInteger, "page number, start from 1"),
+		// larger limits are clamped by Pagination.Sanitize, old clients send them
+		queryParam("limit", paramInteger, fmt.Sprintf("page size, at most %d", types.MaximumLimit)),
+	}, extra...)
+}
+
+// cursorParams is paging of routes which also accept `next`/`prev` cursor of previous response
+func cursorParams(extra ...param) []param {
+	return pagingParams(append([]param{queryParam("cursor", paramString, "next or prev cursor of previous page")}, extra...)...)
+}
+
+func timeRangeParams(extra ...param) []param {
+	return append([]param{
+		queryParam("from", paramInteger, "unix timestamp"),
+		queryParam("to", paramInteger, "unix timestamp"),
+	}, extra...)
+}
+
+// body is schema of a request body or of `data` in a success response, models are registered by EchoServer.OpenAPIModels
+type body struct {
+	models []string // merged with allOf if there are many
+	list   bool
+	paged  bool // wrapped in PagingResponse model
+	stream bool // CSV or NDJSON download instead of JSON
+	oneOf  []*body
+}
+
+func model(names ...string) *body {
+	return &body{models: names}
+}
+
+func listOf(name string) *body {
+	return &body{models: []string{name}, list: true}
+}
+
+func paged(name string) *body {
+	return &body{models: []string{name}, paged: true}
+}
+
+func pagedList(name string) *body {
+	return &body{models: []string{name}, list: true, paged: true}
+}
+
+// oneOf is a response which has different shapes depending on params
+func oneOf(bodies ...*body) *body {
+	return &body{oneOf: bodies}
+}
+
+var download = &body{stream: true}
+
+const (
+	pagingModel         = "PagingResponse"
+	adminSecurityScheme = "adminToken"
+)
+
+// schema is the subset of OpenAPI 3.0 schema object we generate
+type schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Maximum              *int               `json:"maximum,omitempty"`
+	Items                *schema            `json:"items,omitempty"`
+	Properties           map[string]*schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+	AllOf                []*schema          `json:"allOf,omitempty"`
+	OneOf                []*schema          `json:"oneOf,omitempty"`
+}
+
+func refSchema(name string) *schema {
+	return &schema{Ref: "#/components/schemas/" + name}
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	bigIntType     = reflect.TypeOf(big.Int{})
+	objectIDType   = reflect.TypeOf(primitive.ObjectID{})
+	textMarshaler  = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshaler  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	anyStructTypes = map[reflect.Type]*schema{
+		timeType:     {Type: "string", Format: "date-time"},
+		bigIntType:   {Type: "integer"},
+		objectIDType: {Type: "string"},
+	}
+)
+
+// schemaOf reflect JSON schema of Go type the same way encoding/json marshals it
+func schemaOf(t reflect.Type, seen map[reflect.Type]bool) *schema {
+	if s, ok := anyStructTypes[t]; ok {
+		copied := *s
+		return &copied
+	}
+	if t.Kind() == reflect.Ptr {
+		s := schemaOf(t.Elem(), seen)
+		if s.Ref == "" {
+			s.Nullable = true
+		}
+		return s
+	}
+	if t.Implements(textMarshaler) || reflect.PtrTo(t).Implements(textMarshaler) {
+		return &schema{Type: "string"}
+	}
+	if t.Implements(jsonMarshaler) || reflect.PtrTo(t).Implements(jsonMarshaler) {
+		return &schema{}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &schema{Type: "string"}
+	case reflect.Bool:
+		return &schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &schema{Type: "number"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &schema{Type: "string", Format: "byte", Nullable: true}
+		}
+		return &schema{Type: "array", Items: schemaOf(t.Elem(), seen), Nullable: true}
+	case reflect.Array:
+		return &schema{Type: "array", Items: schemaOf(t.Elem(), seen)}
+	case reflect.Map:
+		return &schema{Type: "object", AdditionalProperties: schemaOf(t.Elem(), seen), Nullable: true}
+	case reflect.Struct:
+		if seen[t] {
+			// recursive type
+			return &schema{}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+		s := &schema{Type: "object", Properties: make(map[string]*schema), AdditionalProperties: false}
+		addStructFields(s, t, seen, true)
+		sort.Strings(s.Required)
+		return s
+	}
+	return &schema{}
+}
+
+// addStructFields add JSON fields of struct, fields of embedded structs are promoted unless outer struct has the same name
+func addStructFields(s *schema, t reflect.Type, seen map[reflect.Type]bool, required bool) {
+	type embedded struct {
+		t        reflect.Type
+		required bool
+	}
+	var promoted []embedded
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts := tag, ""
+		if idx := strings.Index(tag, ","); idx >= 0 {
+			name, opts = tag[:idx], tag[idx:]
+		}
+		ft := f.Type
+		if f.Anonymous && name == "" {
+			isPtr := ft.Kind() == reflect.Ptr
+			if isPtr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				promoted = append(promoted, embedded{t: ft, required: required && !isPtr})
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if _, ok := s.Properties[name]; ok {
+			continue
+		}
+		fs := schemaOf(f.Type, seen)
+		if strings.Contains(opts, ",string") {
+			fs = &schema{Type: "string"}
+		}
+		s.Properties[name] = fs
+		if required && !strings.Contains(opts, "omitempty") {
+			s.Required = append(s.Required, name)
+		}
+	}
+	for _, e := range promoted {
+		if seen[e.t] {
+			continue
+		}
+		addStructFields(s, e.t, seen, e.required)
+	}
+}
+
+// OpenAPI is the spec of REST routes, built from route table and models of EchoServer
+type OpenAPI struct {
+	defs       []restDefinition
+	routes     map[string]restDefinition // by method and path
+	components map[string]*schema
+	doc        map[string]interface{}
+}
+
+func routeKey(method, path string) string {
+	return method + " " + path
+}
+
+// NewOpenAPI build spec of all /api/v1 routes, it fails if a route refers to an unknown model
+func NewOpenAPI(srv EchoServer) (*OpenAPI, error) {
+	o := &OpenAPI{
+		routes:     make(map[string]restDefinition),
+		components: make(map[string]*schema),
+	}
+	for name, v := range srv.OpenAPIModels() {
+		o.components[name] = schemaOf(reflect.TypeOf(v), make(map[reflect.Type]bool))
+	}
+	if _, ok := o.components[pagingModel]; !ok {
+		return nil, fmt.Errorf("model %s is not registered", pagingModel)
+	}
+	paths := make(map[string]map[string]interface{})
+	o.defs = routes(srv)
+	for _, r := range o.defs {
+		o.routes[routeKey(r.method, r.path)] = r
+		op, err := o.operation(r)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %v", r.method, r.path, err)
+		}
+		path := openAPIPath(r.path)
+		if paths[path] == nil {
+			paths[path] = make(map[string]interface{})
+		}
+		paths[path][strings.ToLower(r.method)] = op
+	}
+	o.doc = map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "KardiaChain Explorer API",
+			"version": cfg.ServerVersion,
+		},
+		"servers": []interface{}{map[string]interface{}{"url": "/api/v1"}},
+		"paths":   paths,
+		"components": map[string]interface{}{
+			"schemas": o.components,
+			"securitySchemes": map[string]interface{}{
+				adminSecurityScheme: map[string]interface{}{"type": "http", "scheme": "bearer"},
+			},
+		},
+	}
+	return o, nil
+}
+
+// Route is a REST route of the spec, so tests can call every handler and validate its response
+type Route struct {
+	Method     string
+	Path       string
+	Handler    echo.HandlerFunc
+	Admin      bool
+	Stream     bool              // CSV or NDJSON download
+	PathParams map[string]string // param types by name
+}
+
+// Routes return all /api/v1 routes in route table order
+func (o *OpenAPI) Routes() []Route {
+	routes := make([]Route, 0, len(o.defs))
+	for _, r := range o.defs {
+		route := Route{
+			Method:     r.method,
+			Path:       r.path,
+			Handler:    r.fn,
+			Admin:      len(r.roles) > 0,
+			Stream:     r.response != nil && r.response.stream,
+			PathParams: make(map[string]string),
+		}
+		for _, p := range r.params {
+			if p.in == paramPath {
+				route.PathParams[p.name] = p.typ
+			}
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+func (o *OpenAPI) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.doc)
+}
+
+// openAPIPath convert echo path params `:name` to `{name}`
+func openAPIPath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, p := range parts {
+		if strings.HasPrefix(p, ":") {
+			parts[i] = "{" + p[1:] + "}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+func operationID(fn func(c echo.Context) error) string {
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	name = strings.TrimSuffix(name[strings.LastIndex(name, ".")+1:], "-fm")
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+func paramSchema(p param) *schema {
+	s := &schema{Type: p.typ, Enum: p.enum}
+	switch p.typ {
+	case paramAddress:
+		s.Type, s.Pattern = "string", addressPattern.String()
+	case paramHash:
+		s.Type, s.Pattern = "string", hashPattern.String()
+	}
+	if p.max > 0 {
+		max := p.max
+		s.Maximum = &max
+	}
+	return s
+}
+
+func (o *OpenAPI) operation(r restDefinition) (map[string]interface{}, error) {
+	op := map[string]interface{}{
+		"operationId": operationID(r.fn),
+		"summary":     r.summary,
+	}
+	var params []interface{}
+	for _, p := range r.params {
+		params = append(params, map[string]interface{}{
+			"name":        p.name,
+			"in":          p.in,
+			"required":    p.required,
+			"description": p.desc,
+			"schema":      paramSchema(p),
+		})
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+	if r.request != nil {
+		s, err := o.bodySchema(r.request)
+		if err != nil {
+			return nil, err
+		}
+		op["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  map[string]interface{}{echo.MIMEApplicationJSON: map[string]interface{}{"schema": s}},
+		}
+	}
+	success, err := o.successSchema(r)
+	if err != nil {
+		return nil, err
+	}
+	var content map[string]interface{}
+	if r.response != nil && r.response.stream {
+		content = map[string]interface{}{
+			"text/csv":             map[string]interface{}{"schema": &schema{Type: "string"}},
+			"application/x-ndjson": map[string]interface{}{"schema": &schema{Type: "string"}},
+		}
+	} else {
+		content = map[string]interface{}{echo.MIMEApplicationJSON: map[string]interface{}{"schema": success}}
+	}
+	op["responses"] = map[string]interface{}{
+		"200": map[string]interface{}{"description": "Success", "content": content},
+		"400": map[string]interface{}{
+			"description": "Bad request, data is the list of invalid params if any",
+			"content":     map[string]interface{}{echo.MIMEApplicationJSON: map[string]interface{}{"schema": envelopeSchema(&schema{})}},
+		},
+	}
+	if len(r.roles) > 0 {
+		op["description"] = "Admin route, operator token must have any of roles: " + strings.Join(r.roles, ", ")
+		op["security"] = []interface{}{map[string]interface{}{adminSecurityScheme: []string{}}}
+		responses := op["responses"].(map[string]interface{})
+		responses["401"] = map[string]interface{}{"description": "Missing, invalid or expired admin token"}
+		responses["403"] = map[string]interface{}{"description": "Operator doesn't have required roles"}
+	}
+	return op, nil
+}
+
+func (o *OpenAPI) bodySchema(b *body) (*schema, error) {
+	if len(b.oneOf) > 0 {
+		s := &schema{}
+		for _, alternative := range b.oneOf {
+			sub, err := o.bodySchema(alternative)
+			if err != nil {
+				return nil, err
+			}
+			s.OneOf = append(s.OneOf, sub)
+		}
+		return s, nil
+	}
+	var s *schema
+	for _, name := range b.models {
+		if _, ok := o.components[name]; !ok {
+			return nil, fmt.Errorf("model %s is not registered", name)
+		}
+	}
+	switch len(b.models) {
+	case 0:
+		s = &schema{}
+	case 1:
+		s = refSchema(b.models[0])
+	default:
+		s = &schema{}
+		for _, name := range b.models {
+			s.AllOf = append(s.AllOf, refSchema(name))
+		}
+	}
+	if b.list {
+		s = &schema{Type: "array", Items: s, Nullable: true}
+	}
+	if b.paged {
+		s = &schema{AllOf: []*schema{
+			refSchema(pagingModel),
+			{Type: "object", Properties: map[string]*schema{"data": s}},
+		}}
+	}
+	return s, nil
+}
+
+// envelopeSchema is schema of EchoResponse
+func envelopeSchema(data *schema) *schema {
+	return &schema{
+		Type: "object",
+		Properties: map[string]*schema{
+			"code": {Type: "integer"},
+			"msg":  {Type: "string"},
+			"data": data,
+		},
+		Required:             []string{"code", "msg"},
+		AdditionalProperties: false,
+	}
+}
+
+func (o *OpenAPI) successSchema(r restDefinition) (*schema, error) {
+	if r.response == nil || r.response.stream {
+		return envelopeSchema(&schema{}), nil
+	}
+	data, err := o.bodySchema(r.response)
+	if err != nil {
+		return nil, err
+	}
+	return envelopeSchema(data), nil
+}
+
+// ValidateResponse check a JSON response of route against its declared schema. Unlike requests, properties
+// which are not declared or required properties which are missing are errors
+func (o *OpenAPI) ValidateResponse(method, path string, body []byte) error {
+	r, ok := o.routes[routeKey(method, path)]
+	if !ok {
+		return fmt.Errorf("unknown route %s %s", method, path)
+	}
+	s, err := o.successSchema(r)
+	if err != nil {
+		return err
+	}
+	value, err := decodeJSON(body)
+	if err != nil {
+		return err
+	}
+	if errs := o.validate(s, value, "", true); len(errs) > 0 {
+		return fmt.Errorf("response of %s %s doesn't match schema: %s", method, path, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func decodeJSON(data []byte) (interface{}, error) {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// validate return mismatches of value against schema, strict mode also checks required and unknown properties
+func (o *OpenAPI) validate(s *schema, value interface{}, path string, strict bool) []string {
+	if s.Ref != "" {
+		return o.validate(o.components[strings.TrimPrefix(s.Ref, "#/components/schemas/")], value, path, strict)
+	}
+	var errs []string
+	if len(s.OneOf) > 0 {
+		for i, sub := range s.OneOf {
+			subErrs := o.validate(sub, value, path, strict)
+			if len(subErrs) == 0 {
+				return nil
+			}
+			errs = append(errs, fmt.Sprintf("%s: doesn't match alternative %d: %s", fieldPath(path), i, strings.Join(subErrs, ", ")))
+		}
+		return errs
+	}
+	if len(s.AllOf) > 0 {
+		merged := &schema{Type: "object", Properties: make(map[string]*schema)}
+		for _, sub := range s.AllOf {
+			sub = o.resolve(sub)
+			for name, p := range sub.Properties {
+				merged.Properties[name] = p
+			}
+			merged.Required = append(merged.Required, sub.Required...)
+			if sub.AdditionalProperties == false {
+				merged.AdditionalProperties = false
+			}
+		}
+		return o.validate(merged, value, path, strict)
+	}
+	if value == nil {
+		if s.Type != "" && !s.Nullable {
+			errs = append(errs, fmt.Sprintf("%s: must not be null", fieldPath(path)))
+		}
+		return errs
+	}
+	switch s.Type {
+	case "":
+		return nil
+	case "string":
+		if _, ok := value.(string); !ok {
+			return append(errs, fmt.Sprintf("%s: must be a string", fieldPath(path)))
+		}
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok || strings.ContainsAny(n.String(), ".eE") {
+			return append(errs, fmt.Sprintf("%s: must be an integer", fieldPath(path)))
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			return append(errs, fmt.Sprintf("%s: must be a number", fieldPath(path)))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return append(errs, fmt.Sprintf("%s: must be a boolean", fieldPath(path)))
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return append(errs, fmt.Sprintf("%s: must be an array", fieldPath(path)))
+		}
+		for i, item := range items {
+			errs = append(errs, o.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i), strict)...)
+		}
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return append(errs, fmt.Sprintf("%s: must be an object", fieldPath(path)))
+		}
+		if strict {
+			for _, name := range s.Required {
+				if _, ok := obj[name]; !ok {
+					errs = append(errs, fmt.Sprintf("%s: is required", joinPath(path, name)))
+				}
+			}
+		}
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if p, ok := s.Properties[name]; ok {
+				errs = append(errs, o.validate(p, obj[name], joinPath(path, name), strict)...)
+				continue
+			}
+			switch additional := s.AdditionalProperties.(type) {
+			case *schema:
+				errs = append(errs, o.validate(additional, obj[name], joinPath(path, name), strict)...)
+			case bool:
+				if strict && !additional {
+					errs = append(errs, fmt.Sprintf("%s: is not declared", joinPath(path, name)))
+				}
+			}
+		}
+	}
+	if len(s.Enum) > 0 {
+		str, _ := value.(string)
+		if !containsString(s.Enum, str) {
+			errs = append(errs, fmt.Sprintf("%s: must be one of %s", fieldPath(path), strings.Join(s.Enum, ", ")))
+		}
+	}
+	return errs
+}
+
+func (o *OpenAPI) resolve(s *schema) *schema {
+	if s.Ref != "" {
+		return o.resolve(o.components[strings.TrimPrefix(s.Ref, "#/components/schemas/")])
+	}
+	return s
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func fieldPath(path string) string {
+	if path == "" {
+		return "body"
+	}
+	return path
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+type paramError struct {
+	Name   string `json:"name"`
+	In     string `json:"in"`
+	Reason string `json:"reason"`
+}
+
+// validateParam return reason if value of param is invalid, empty values are only checked for required params
+func validateParam(p param, value string) string {
+	if value == "" {
+		if p.required {
+			return "is required"
+		}
+		return ""
+	}
+	switch p.typ {
+	case paramInteger:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "must be an integer"
+		}
+		if p.max > 0 && n > int64(p.max) {
+			return fmt.Sprintf("must not be greater than %d", p.max)
+		}
+	case paramNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "must be a number"
+		}
+	case paramBoolean:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return "must be a boolean"
+		}
+	case paramAddress:
+		if !addressPattern.MatchString(value) {
+			return "must be a hex address"
+		}
+	case paramHash:
+		if !hashPattern.MatchString(value) {
+			return "must be a hex hash"
+		}
+	}
+	if len(p.enum) > 0 && !containsString(p.enum, value) {
+		return "must be one of " + strings.Join(p.enum, ", ")
+	}
+	return ""
+}
+
+// validateRequest reject requests whose params or JSON body don't match route definition
+func (o *OpenAPI) validateRequest(r restDefinition) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			var errs []paramError
+			for _, p := range r.params {
+				value := c.QueryParam(p.name)
+				if p.in == paramPath {
+					value = c.Param(p.name)
+				}
+				if reason := validateParam(p, value); reason != "" {
+					errs = append(errs, paramError{Name: p.name, In: p.in, Reason: reason})
+				}
+			}
+			if r.request != nil {
+				errs = append(errs, o.validateBody(c, r.request)...)
+			}
+			if len(errs) > 0 {
+				resp := Invalid
+				return c.JSON(http.StatusBadRequest, resp.SetData(errs))
+			}
+			return next(c)
+		}
+	}
+}
+
+// validateBody only checks types, handlers tolerate missing and unknown fields
+func (o *OpenAPI) validateBody(c echo.Context, b *body) []paramError {
+	req := c.Request()
+	if req.Body == nil {
+		return []paramError{{Name: "body", In: "body", Reason: "is required"}}
+	}
+	data, err := readBody(req)
+	if err != nil {
+		return []paramError{{Name: "body", In: "body", Reason: err.Error()}}
+	}
+	value, err := decodeJSON(data)
+	if err != nil {
+		return []paramError{{Name: "body", In: "body", Reason: "must be valid JSON"}}
+	}
+	s, err := o.bodySchema(b)
+	if err != nil {
+		return nil
+	}
+	var errs []paramError
+	for _, reason := range o.validate(s, value, "", false) {
+		errs = append(errs, paramError{Name: "body", In: "body", Reason: reason})
+	}
+	return errs
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	data, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	_ = req.Body.Close()
+	req.Body = ioutil.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+func (o *OpenAPI) serve(c echo.Context) error {
+	return c.JSON(http.StatusOK, o)
+}
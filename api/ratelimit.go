@@ -0,0 +1,139 @@
+// Package api
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo"
+
+	"github.com/kardiachain/kardia-explorer-backend/cfg"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+// rateBudget is the rate limit bucket of a route, routes of the same budget share tokens
+type rateBudget string
+
+const (
+	budgetDefault   rateBudget = "default"
+	budgetExpensive rateBudget = "expensive" // RPC calls or heavy aggregation per request
+	budgetExport    rateBudget = "export"
+
+	headerAPIKey             = "X-API-Key"
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+	headerRetryAfter         = "Retry-After"
+
+	rateLimitTimeout = 500 * time.Millisecond
+)
+
+type rateLimiter struct {
+	srv       EchoServer
+	budgets   map[rateBudget]types.RateLimit
+	allowlist []*net.IPNet
+}
+
+// newRateLimiter return nil if rate limit is disabled
+func newRateLimiter(srv EchoServer, cfg cfg.ExplorerConfig) (*rateLimiter, error) {
+	if !cfg.RateLimitEnabled {
+		return nil, nil
+	}
+	l := &rateLimiter{
+		srv: srv,
+		budgets: map[rateBudget]types.RateLimit{
+			budgetDefault:   {Name: string(budgetDefault), Burst: cfg.RateLimitDefault, Period: time.Minute},
+			budgetExpensive: {Name: string(budgetExpensive), Burst: cfg.RateLimitExpensive, Period: time.Minute},
+			budgetExport:    {Name: string(budgetExport), Burst: cfg.RateLimitExport, Period: time.Minute},
+		},
+	}
+	for _, entry := range cfg.RateLimitAllowlist {
+		network, err := parseIPNet(strings.TrimSpace(entry))
+		if err != nil {
+			return nil, err
+		}
+		l.allowlist = append(l.allowlist, network)
+	}
+	return l, nil
+}
+
+// parseIPNet accept a CIDR or a single IP
+func parseIPNet(entry string) (*net.IPNet, error) {
+	if strings.Contains(entry, "/") {
+		_, network, err := net.ParseCIDR(entry)
+		return network, err
+	}
+	ip := net.ParseIP(entry)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid rate limit allowlist entry %s", entry)
+	}
+	bits := 8 * net.IPv6len
+	if ip.To4() != nil {
+		ip, bits = ip.To4(), 8*net.IPv4len
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
+
+// allowed match client IP resolved by clientIPResolver
+func (l *rateLimiter) allowed(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range l.allowlist {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// limit reject requests over budget with 429, requests are let through if buckets cannot be checked.
+// Key holders have their own bucket, which is scaled by key tier
+func (l *rateLimiter) limit(budget rateBudget) echo.MiddlewareFunc {
+	if budget == "" {
+		budget = budgetDefault
+	}
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ip := ClientIP(c)
+			if l.allowed(ip) {
+				return next(c)
+			}
+			limit := l.budgets[budget]
+			client := "ip:" + ip
+			if key := RequestAPIKey(c); key != nil {
+				tier := types.APIKeyTierByName(key.Tier)
+				if tier.Unlimited {
+					return next(c)
+				}
+				limit.Burst *= tier.RateMultiplier
+				client = "key:" + key.ID.Hex()
+			}
+			ctx, cancel := context.WithTimeout(c.Request().Context(), rateLimitTimeout)
+			result, err := l.srv.RateLimit(ctx, limit, client)
+			cancel()
+			if err != nil {
+				return next(c)
+			}
+			header := c.Response().Header()
+			header.Set(headerRateLimitLimit, strconv.FormatInt(limit.Burst, 10))
+			header.Set(headerRateLimitRemaining, strconv.FormatInt(result.Remaining, 10))
+			header.Set(headerRateLimitReset, seconds(result.Reset))
+			if !result.Allowed {
+				header.Set(headerRetryAfter, seconds(result.RetryAfter))
+				return TooManyRequests.Build(c)
+			}
+			return next(c)
+		}
+	}
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
@@ -30,6 +30,9 @@ var (
 	InternalServer = EchoResponse{StatusCode: http.StatusInternalServerError, Code: 1100, Msg: "Server busy..."}
 	Invalid        = EchoResponse{StatusCode: http.StatusBadRequest, Code: 1101, Msg: "Bad request"}
 	Unauthorized   = EchoResponse{StatusCode: http.StatusUnauthorized, Code: 401, Msg: "Unauthorized"}
+
+	TooManyRequests = EchoResponse{StatusCode: http.StatusTooManyRequests, Code: 1102, Msg: "Too many requests"}
+	Forbidden       = EchoResponse{StatusCode: http.StatusForbidden, Code: 1103, Msg: "Forbidden"}
 )
 
 type Pagination struct {
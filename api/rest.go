@@ -2,7 +2,11 @@
 package api
 
 import (
+	"context"
+
 	"github.com/labstack/echo"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
 )
 
 // EchoServer define all API expose
@@ -10,8 +14,10 @@ type EchoServer interface {
 	// General
 	Ping(c echo.Context) error
 	Stats(c echo.Context) error
+	NetworkChart(c echo.Context) error
 	TotalHolders(c echo.Context) error
 	TokenInfo(c echo.Context) error
+	PriceHistory(c echo.Context) error
 	Nodes(c echo.Context) error
 
 	// Staking-related
@@ -22,6 +28,17 @@ type EchoServer interface {
 	Candidates(c echo.Context) error
 	MobileValidators(c echo.Context) error
 	MobileCandidates(c echo.Context) error
+	ValidatorSlashEvents(c echo.Context) error
+	SlashEvents(c echo.Context) error
+	DelegatorRewards(c echo.Context) error
+	ValidatorRewards(c echo.Context) error
+	ValidatorHistory(c echo.Context) error
+	ValidatorProduction(c echo.Context) error
+	ProposersProduction(c echo.Context) error
+	ValidatorSetChanges(c echo.Context) error
+	ActiveValidatorSet(c echo.Context) error
+	SimulateStaking(c echo.Context) error
+	DelegatorUnbondingSchedule(c echo.Context) error
 
 	// Proposal
 	GetProposalsList(c echo.Context) error
@@ -54,12 +71,36 @@ type EchoServer interface {
 	UpdateSupplyAmounts(c echo.Context) error
 
 	IContract
+	IWebhook
+	IExport
+	IAPIKey
+	IAdmin
 
 	//
-	SearchAddressByName(c echo.Context) error
+	Search(c echo.Context) error
+	Autocomplete(c echo.Context) error
 
 	GetHoldersListByToken(c echo.Context) error
 	GetInternalTxs(c echo.Context) error
+
+	// GraphQL
+	GraphQL(c echo.Context) error
+
+	// Etherscan compatible API
+	EtherscanAPI(c echo.Context) error
+
+	// JSON-RPC proxy
+	RPC(c echo.Context) error
+
+	// WebSocket streaming
+	Stream(c echo.Context) error
+
+	// OpenAPI models by schema name, see restDefinition
+	OpenAPIModels() map[string]interface{}
+
+	// Rate limit, result is the most restrictive bucket of clients
+	RateLimit(ctx context.Context, limit types.RateLimit, clients ...string) (*types.RateLimitResult, error)
+	RateLimitMetrics(c echo.Context) error
 }
 
 type IContract interface {
@@ -71,3 +112,44 @@ type IContract interface {
 
 	ContractEvents(c echo.Context) error
 }
+
+type IWebhook interface {
+	CreateWebhookWatch(c echo.Context) error
+	WebhookWatches(c echo.Context) error
+	RemoveWebhookWatch(c echo.Context) error
+	WebhookDeliveries(c echo.Context) error
+	RetryWebhookDelivery(c echo.Context) error
+}
+
+type IExport interface {
+	ExportAddressTxs(c echo.Context) error
+	ExportAddressTokenTransfers(c echo.Context) error
+	ExportAddressRewards(c echo.Context) error
+	ExportTokenHolders(c echo.Context) error
+	ExportContractEvents(c echo.Context) error
+}
+
+type IAPIKey interface {
+	ResolveAPIKey(ctx context.Context, key string) (*types.APIKey, error)
+	CountAPIKeyRequest(ctx context.Context, key *types.APIKey) (int64, error)
+	RecordAPIKeyUsage(key *types.APIKey, route string, limited bool)
+
+	CreateAPIKey(c echo.Context) error
+	APIKeys(c echo.Context) error
+	APIKeyInfo(c echo.Context) error
+	UpdateAPIKey(c echo.Context) error
+	RotateAPIKey(c echo.Context) error
+	RevokeAPIKey(c echo.Context) error
+	APIKeyUsage(c echo.Context) error
+	APIKeyTiers(c echo.Context) error
+	OwnAPIKey(c echo.Context) error
+}
+
+type IAdmin interface {
+	RecordAudit(ctx context.Context, log *types.AuditLog)
+	AdminTokenRevoked(ctx context.Context, id string) (bool, error)
+
+	AuditLogs(c echo.Context) error
+	AdminOperator(c echo.Context) error
+	RevokeAdminToken(c echo.Context) error
+}
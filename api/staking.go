@@ -5,53 +5,157 @@ import (
 	"github.com/labstack/echo"
 )
 
-func bindStakingAPIs(gr *echo.Group, srv EchoServer) {
-	apis := []restDefinition{
+var productionParams = timeRangeParams(enumParam("type", "bucket of stats", "day", "window"))
+
+func stakingAPIs(srv EchoServer) []restDefinition {
+	validatorAddress := pathParam("address", paramAddress, "validator SMC address")
+	delegatorAddress := pathParam("address", paramAddress, "delegator address")
+	return []restDefinition{
 		//Validator
 		{
-			method:      echo.GET,
-			path:        "/staking/stats",
-			fn:          srv.StakingStats,
-			middlewares: nil,
+			method:   echo.GET,
+			path:     "/staking/stats",
+			fn:       srv.StakingStats,
+			summary:  "Staking stats",
+			response: model("StakingStats"),
 		},
 		{
-			method:      echo.GET,
-			path:        "/validators/:address",
-			fn:          srv.Validator,
-			middlewares: nil,
+			method:   echo.GET,
+			path:     "/validators/:address",
+			fn:       srv.Validator,
+			summary:  "Validator with its delegators",
+			params:   pagingParams(validatorAddress),
+			response: paged("Validator"),
 		},
 		{
-			method:      echo.GET,
-			path:        "/delegators/:address/validators",
-			fn:          srv.ValidatorsByDelegator,
-			middlewares: nil,
+			method:   echo.GET,
+			path:     "/validators/:address/slashes",
+			fn:       srv.ValidatorSlashEvents,
+			summary:  "Slash events of validator",
+			params:   pagingParams(validatorAddress),
+			response: pagedList("SlashEvent"),
 		},
 		{
-			method:      echo.GET,
-			path:        "/validators/candidates",
-			fn:          srv.MobileCandidates,
-			middlewares: nil,
+			method:   echo.GET,
+			path:     "/staking/slashes",
+			fn:       srv.SlashEvents,
+			summary:  "Slash events",
+			params:   pagingParams(),
+			response: pagedList("SlashEvent"),
 		},
 		{
-			method:      echo.GET,
-			path:        "/validators",
-			fn:          srv.MobileValidators,
-			middlewares: nil,
+			method:  echo.GET,
+			path:    "/validators/:address/history",
+			fn:      srv.ValidatorHistory,
+			summary: "Commission and status history of validator",
+			params: pagingParams(
+				validatorAddress,
+				queryParam("days", paramInteger, "window of recent commission increase"),
+				queryParam("field", paramString, "only changes of this field"),
+			),
+			response: paged("ValidatorHistoryResponse"),
 		},
 		{
-			method:      echo.GET,
-			path:        "/staking/candidates",
-			fn:          srv.Candidates,
-			middlewares: nil,
+			method:   echo.GET,
+			path:     "/validators/:address/production",
+			fn:       srv.ValidatorProduction,
+			summary:  "Block production stats of validator",
+			params:   append([]param{validatorAddress}, productionParams...),
+			response: listOf("ProductionStats"),
 		},
 		{
-			method:      echo.GET,
-			path:        "/staking/validators",
-			fn:          srv.Validators,
-			middlewares: nil,
+			method:   echo.GET,
+			path:     "/staking/production",
+			fn:       srv.ProposersProduction,
+			summary:  "Block production performance of proposers",
+			params:   productionParams,
+			response: listOf("ProposerPerformance"),
+		},
+		{
+			method:   echo.GET,
+			path:     "/staking/validator-sets",
+			fn:       srv.ValidatorSetChanges,
+			summary:  "Validator set changes",
+			params:   pagingParams(queryParam("address", paramAddress, "only changes of this validator")),
+			response: pagedList("ValidatorSetChange"),
+		},
+		{
+			method:   echo.GET,
+			path:     "/staking/validator-sets/active",
+			fn:       srv.ActiveValidatorSet,
+			summary:  "Validator set active at height",
+			params:   []param{queryParam("height", paramInteger, "block height, latest if empty")},
+			response: model("ValidatorSetChange"),
+		},
+		{
+			method:   echo.POST,
+			path:     "/staking/simulate",
+			fn:       srv.SimulateStaking,
+			summary:  "Simulate staking actions",
+			request:  model("StakingSimulationRequest"),
+			response: model("StakingSimulation"),
+			budget:   budgetExpensive,
+		},
+		{
+			method:   echo.GET,
+			path:     "/validators/:address/rewards",
+			fn:       srv.ValidatorRewards,
+			summary:  "Reward history of validator",
+			params:   timeRangeParams(validatorAddress),
+			response: model("RewardHistory"),
+		},
+		{
+			method:   echo.GET,
+			path:     "/delegators/:address/rewards",
+			fn:       srv.DelegatorRewards,
+			summary:  "Reward history of delegator per validator",
+			params:   timeRangeParams(delegatorAddress),
+			response: listOf("RewardHistory"),
+		},
+		{
+			method:   echo.GET,
+			path:     "/delegators/:address/unbonding",
+			fn:       srv.DelegatorUnbondingSchedule,
+			summary:  "Unbonding schedule of delegator",
+			params:   []param{delegatorAddress},
+			response: model("UnbondingSchedule"),
+		},
+		{
+			method:   echo.GET,
+			path:     "/delegators/:address/validators",
+			fn:       srv.ValidatorsByDelegator,
+			summary:  "Validators delegated by delegator",
+			params:   []param{delegatorAddress},
+			response: listOf("ValidatorsByDelegator"),
+			budget:   budgetExpensive,
+		},
+		{
+			method:   echo.GET,
+			path:     "/validators/candidates",
+			fn:       srv.MobileCandidates,
+			summary:  "Staking stats and candidates",
+			response: model("MobileValidators"),
+		},
+		{
+			method:   echo.GET,
+			path:     "/validators",
+			fn:       srv.MobileValidators,
+			summary:  "Staking stats and validators",
+			response: model("MobileValidators"),
+		},
+		{
+			method:   echo.GET,
+			path:     "/staking/candidates",
+			fn:       srv.Candidates,
+			summary:  "Candidates",
+			response: listOf("Validator"),
+		},
+		{
+			method:   echo.GET,
+			path:     "/staking/validators",
+			fn:       srv.Validators,
+			summary:  "Validators sorted by staked amount",
+			response: listOf("Validator"),
 		},
-	}
-	for _, api := range apis {
-		gr.Add(api.method, api.path, api.fn, api.middlewares...)
 	}
 }
@@ -0,0 +1,52 @@
+// Package api
+package api
+
+import (
+	"github.com/labstack/echo"
+)
+
+// webhook APIs are authenticated by X-API-Key header
+func webhookAPIs(srv EchoServer) []restDefinition {
+	return []restDefinition{
+		{
+			method:   echo.POST,
+			path:     "/webhooks/watches",
+			fn:       srv.CreateWebhookWatch,
+			summary:  "Watch an address, token or contract event",
+			request:  model("WebhookWatchRequest"),
+			response: model("WebhookWatch"),
+		},
+		{
+			method:   echo.GET,
+			path:     "/webhooks/watches",
+			fn:       srv.WebhookWatches,
+			summary:  "Watches of API key",
+			response: listOf("WebhookWatch"),
+		},
+		{
+			method:  echo.DELETE,
+			path:    "/webhooks/watches/:id",
+			fn:      srv.RemoveWebhookWatch,
+			summary: "Remove watch",
+			params:  []param{pathParam("id", paramString, "watch ID")},
+		},
+		{
+			method:  echo.GET,
+			path:    "/webhooks/deliveries",
+			fn:      srv.WebhookDeliveries,
+			summary: "Webhook deliveries of API key",
+			params: pagingParams(
+				queryParam("watchId", paramString, "only deliveries of this watch"),
+				enumParam("status", "delivery status", "pending", "delivered", "dead"),
+			),
+			response: pagedList("WebhookDelivery"),
+		},
+		{
+			method:  echo.POST,
+			path:    "/webhooks/deliveries/:id/retry",
+			fn:      srv.RetryWebhookDelivery,
+			summary: "Retry a dead delivery",
+			params:  []param{pathParam("id", paramString, "delivery ID")},
+		},
+	}
+}
@@ -0,0 +1,30 @@
+// Package cache
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	keyAPIKeyRequests = "#apikey#requests#%s#%s" // key ID and UTC day
+
+	apiKeyRequestsTTL = 48 * time.Hour
+)
+
+type IAPIKeys interface {
+	IncrAPIKeyRequests(ctx context.Context, keyID, day string) (int64, error)
+}
+
+// IncrAPIKeyRequests count requests of key in day, it's checked against daily quota of key tier
+func (c *Redis) IncrAPIKeyRequests(ctx context.Context, keyID, day string) (int64, error) {
+	key := fmt.Sprintf(keyAPIKeyRequests, keyID, day)
+	pipe := c.client.TxPipeline()
+	count := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, apiKeyRequestsTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return count.Val(), nil
+}
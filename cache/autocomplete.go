@@ -0,0 +1,177 @@
+// Package cache
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const (
+	keyAutocompletePrefix  = "#autocomplete#prefix#%s" // normalized prefix, sorted set of addresses by popularity
+	keyAutocompleteEntries = "#autocomplete#entries"   // hash of entries by address
+
+	autocompleteMaxPrefix = 16  // longer queries are filtered after lookup
+	autocompleteKeep      = 100 // most popular entries of each prefix
+)
+
+type IAutocomplete interface {
+	UpsertAutocompleteEntries(ctx context.Context, entries []*types.AutocompleteEntry) error
+	RemoveAutocompleteEntry(ctx context.Context, address string) error
+	Autocomplete(ctx context.Context, query string, limit int64) ([]*types.AutocompleteEntry, error)
+}
+
+// normalizeAutocomplete lower case text and turn punctuation into single spaces, so "Kai-Swap" and "kai swap" are the same
+func normalizeAutocomplete(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// autocompletePrefixes index the whole name and every word of it, so "Wrapped KAI" is found by "wra", "wrapped k" and "kai"
+func autocompletePrefixes(terms []string) []string {
+	seen := make(map[string]bool)
+	var prefixes []string
+	for _, term := range terms {
+		normalized := normalizeAutocomplete(term)
+		for _, word := range append([]string{normalized}, strings.Fields(normalized)...) {
+			runes := []rune(word)
+			for i := 1; i <= len(runes) && i <= autocompleteMaxPrefix; i++ {
+				p := strings.TrimSpace(string(runes[:i]))
+				if p != "" && !seen[p] {
+					seen[p] = true
+					prefixes = append(prefixes, p)
+				}
+			}
+		}
+	}
+	return prefixes
+}
+
+// autocompleteMatch check entry against the whole query, it's needed when query is longer than indexed prefixes
+func autocompleteMatch(e *types.AutocompleteEntry, query string) bool {
+	for _, term := range e.Terms() {
+		normalized := normalizeAutocomplete(term)
+		if strings.HasPrefix(normalized, query) {
+			return true
+		}
+		for _, word := range strings.Fields(normalized) {
+			if strings.HasPrefix(word, query) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *Redis) autocompleteEntries(ctx context.Context, addresses []string) ([]*types.AutocompleteEntry, error) {
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+	values, err := c.client.HMGet(ctx, keyAutocompleteEntries, addresses...).Result()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*types.AutocompleteEntry, len(addresses))
+	for i, v := range values {
+		data, ok := v.(string)
+		if !ok {
+			continue
+		}
+		var e *types.AutocompleteEntry
+		if err := json.Unmarshal([]byte(data), &e); err == nil {
+			entries[i] = e
+		}
+	}
+	return entries, nil
+}
+
+// UpsertAutocompleteEntries replace indexed names and popularity of entries, prefixes of old names are removed
+func (c *Redis) UpsertAutocompleteEntries(ctx context.Context, entries []*types.AutocompleteEntry) error {
+	addresses := make([]string, len(entries))
+	for i, e := range entries {
+		addresses[i] = e.Address
+	}
+	olds, err := c.autocompleteEntries(ctx, addresses)
+	if err != nil {
+		return err
+	}
+	pipe := c.client.Pipeline()
+	for i, e := range entries {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		prefixes := autocompletePrefixes(e.Terms())
+		if olds[i] != nil {
+			current := make(map[string]bool)
+			for _, p := range prefixes {
+				current[p] = true
+			}
+			for _, p := range autocompletePrefixes(olds[i].Terms()) {
+				if !current[p] {
+					pipe.ZRem(ctx, fmt.Sprintf(keyAutocompletePrefix, p), e.Address)
+				}
+			}
+		}
+		for _, p := range prefixes {
+			key := fmt.Sprintf(keyAutocompletePrefix, p)
+			pipe.ZAdd(ctx, key, &redis.Z{Score: e.Score, Member: e.Address})
+			pipe.ZRemRangeByRank(ctx, key, 0, -autocompleteKeep-1)
+		}
+		pipe.HSet(ctx, keyAutocompleteEntries, e.Address, data)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (c *Redis) RemoveAutocompleteEntry(ctx context.Context, address string) error {
+	olds, err := c.autocompleteEntries(ctx, []string{address})
+	if err != nil || olds[0] == nil {
+		return err
+	}
+	pipe := c.client.Pipeline()
+	for _, p := range autocompletePrefixes(olds[0].Terms()) {
+		pipe.ZRem(ctx, fmt.Sprintf(keyAutocompletePrefix, p), address)
+	}
+	pipe.HDel(ctx, keyAutocompleteEntries, address)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// Autocomplete return most popular entries which have a name or a word of name starting with query
+func (c *Redis) Autocomplete(ctx context.Context, query string, limit int64) ([]*types.AutocompleteEntry, error) {
+	query = normalizeAutocomplete(query)
+	if query == "" || limit <= 0 {
+		return nil, nil
+	}
+	prefix, fetch := query, limit
+	if runes := []rune(query); len(runes) > autocompleteMaxPrefix {
+		prefix, fetch = strings.TrimSpace(string(runes[:autocompleteMaxPrefix])), autocompleteKeep
+	}
+	addresses, err := c.client.ZRevRange(ctx, fmt.Sprintf(keyAutocompletePrefix, prefix), 0, fetch-1).Result()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := c.autocompleteEntries(ctx, addresses)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*types.AutocompleteEntry, 0, limit)
+	for _, e := range entries {
+		if e == nil || !autocompleteMatch(e, query) {
+			continue
+		}
+		results = append(results, e)
+		if int64(len(results)) == limit {
+			break
+		}
+	}
+	return results, nil
+}
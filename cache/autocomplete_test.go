@@ -0,0 +1,35 @@
+// Package cache
+package cache
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_normalizeAutocomplete(t *testing.T) {
+	assert.Equal(t, normalizeAutocomplete("  Kai-Swap  LP "), "kai swap lp")
+	assert.Equal(t, normalizeAutocomplete("wKAI_v2"), "wkai v2")
+	assert.Equal(t, normalizeAutocomplete("--"), "")
+}
+
+func Test_autocompletePrefixes(t *testing.T) {
+	prefixes := autocompletePrefixes([]string{"Kai Swap", "KSW"})
+	assert.DeepEqual(t, prefixes, []string{
+		"k", "ka", "kai", "kai s", "kai sw", "kai swa", "kai swap",
+		"s", "sw", "swa", "swap",
+		"ks", "ksw",
+	})
+	long := autocompletePrefixes([]string{"abcdefghijklmnopqrstuvwxyz"})
+	assert.Equal(t, len(long), autocompleteMaxPrefix)
+}
+
+func Test_autocompleteMatch(t *testing.T) {
+	e := &types.AutocompleteEntry{Name: "Wrapped KAI Token Version Two", Symbol: "WKAI"}
+	assert.Assert(t, autocompleteMatch(e, "wrapped kai token version t"))
+	assert.Assert(t, autocompleteMatch(e, "version"))
+	assert.Assert(t, autocompleteMatch(e, "wkai"))
+	assert.Assert(t, !autocompleteMatch(e, "wrapped kai token version three"))
+}
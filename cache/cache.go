@@ -33,6 +33,11 @@ type Config struct {
 
 type Client interface {
 	IStaking
+	IRPCProxy
+	IStream
+	IRateLimit
+	IAPIKeys
+	IAutocomplete
 
 	InsertBlock(ctx context.Context, block *types.Block) error
 	InsertTxsOfBlock(ctx context.Context, block *types.Block) error
@@ -65,6 +70,7 @@ type Client interface {
 	IsRequestToCoinMarket(ctx context.Context) bool
 	TokenInfo(ctx context.Context) (*types.TokenInfo, error)
 	UpdateTokenInfo(ctx context.Context, tokenInfo *types.TokenInfo) error
+	SupplyAmounts(ctx context.Context) (*types.SupplyInfo, error)
 	UpdateSupplyAmounts(ctx context.Context, supplyInfo *types.SupplyInfo) error
 
 	Validators(ctx context.Context) (*types.Validators, error)
@@ -0,0 +1,85 @@
+// Package cache
+package cache
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const (
+	keyRateLimitBucket = "#ratelimit#%s#%s" // budget name and client
+)
+
+// takeTokenScript refill bucket by elapsed time then take a token if there is one,
+// it returns 1 or 0 and tokens left as string since Lua numbers are truncated to integers
+var takeTokenScript = redis.NewScript(`
+local burst = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HMSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+type IRateLimit interface {
+	TakeRateLimitToken(ctx context.Context, client string, limit types.RateLimit) (*types.RateLimitResult, error)
+}
+
+// TakeRateLimitToken take a token from bucket of client in limit, buckets of all API instances are shared
+func (c *Redis) TakeRateLimitToken(ctx context.Context, client string, limit types.RateLimit) (*types.RateLimitResult, error) {
+	rate := tokensPerMillisecond(limit)
+	key := fmt.Sprintf(keyRateLimitBucket, limit.Name, client)
+	res, err := takeTokenScript.Run(ctx, c.client, []string{key}, limit.Burst, rate, time.Now().UnixNano()/int64(time.Millisecond)).Result()
+	if err != nil {
+		return nil, err
+	}
+	values, ok := res.([]interface{})
+	if !ok || len(values) != 2 {
+		return nil, fmt.Errorf("unexpected rate limit script result %v", res)
+	}
+	allowed, _ := values[0].(int64)
+	tokensStr, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return nil, err
+	}
+	return rateLimitResult(limit, allowed == 1, tokens), nil
+}
+
+func tokensPerMillisecond(limit types.RateLimit) float64 {
+	return float64(limit.Burst) / float64(limit.Period/time.Millisecond)
+}
+
+// rateLimitResult describe bucket state after a take, tokens is what left in bucket
+func rateLimitResult(limit types.RateLimit, allowed bool, tokens float64) *types.RateLimitResult {
+	rate := tokensPerMillisecond(limit)
+	result := &types.RateLimitResult{
+		Allowed:   allowed,
+		Remaining: int64(math.Floor(tokens)),
+		Reset:     time.Duration(math.Ceil((float64(limit.Burst)-tokens)/rate)) * time.Millisecond,
+	}
+	if !allowed {
+		result.RetryAfter = time.Duration(math.Ceil((1-tokens)/rate)) * time.Millisecond
+	}
+	return result
+}
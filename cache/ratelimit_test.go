@@ -0,0 +1,25 @@
+// Package cache
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_rateLimitResult(t *testing.T) {
+	limit := types.RateLimit{Name: "default", Burst: 60, Period: time.Minute}
+
+	allowed := rateLimitResult(limit, true, 59)
+	assert.Equal(t, allowed.Remaining, int64(59))
+	assert.Equal(t, allowed.RetryAfter, time.Duration(0))
+	assert.Equal(t, allowed.Reset, time.Second)
+
+	limited := rateLimitResult(limit, false, 0.25)
+	assert.Equal(t, limited.Remaining, int64(0))
+	assert.Equal(t, limited.RetryAfter, 750*time.Millisecond)
+	assert.Equal(t, limited.Reset, 59750*time.Millisecond)
+}
@@ -0,0 +1,39 @@
+// Package cache
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	keyRPCResponse = "#rpc#response#%s"
+	keyRPCRequests = "#rpc#requests#%s#%d" // counter of a rate limit window
+)
+
+type IRPCProxy interface {
+	RPCResponse(ctx context.Context, key string) ([]byte, error)
+	UpdateRPCResponse(ctx context.Context, key string, response []byte, ttl time.Duration) error
+	IncrRPCRequests(ctx context.Context, key string, window time.Duration) (int64, error)
+}
+
+func (c *Redis) RPCResponse(ctx context.Context, key string) ([]byte, error) {
+	return c.client.Get(ctx, fmt.Sprintf(keyRPCResponse, key)).Bytes()
+}
+
+func (c *Redis) UpdateRPCResponse(ctx context.Context, key string, response []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, fmt.Sprintf(keyRPCResponse, key), response, ttl).Err()
+}
+
+// IncrRPCRequests count requests of key in current fixed window and return the count
+func (c *Redis) IncrRPCRequests(ctx context.Context, key string, window time.Duration) (int64, error) {
+	windowKey := fmt.Sprintf(keyRPCRequests, key, time.Now().UnixNano()/int64(window))
+	pipe := c.client.TxPipeline()
+	count := pipe.Incr(ctx, windowKey)
+	pipe.Expire(ctx, windowKey, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return count.Val(), nil
+}
@@ -0,0 +1,61 @@
+// Package cache
+package cache
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const (
+	channelStreamBlocks = "#stream#blocks"
+)
+
+type IStream interface {
+	PublishBlock(ctx context.Context, block *types.StreamBlock) error
+	SubscribeBlocks(ctx context.Context) <-chan *types.StreamBlock
+}
+
+func (c *Redis) PublishBlock(ctx context.Context, block *types.StreamBlock) error {
+	data, err := json.Marshal(block)
+	if err != nil {
+		return err
+	}
+	return c.client.Publish(ctx, channelStreamBlocks, data).Err()
+}
+
+// SubscribeBlocks receive published blocks until ctx is done, redis client reconnects by itself
+func (c *Redis) SubscribeBlocks(ctx context.Context) <-chan *types.StreamBlock {
+	lgr := c.logger.With(zap.String("method", "SubscribeBlocks"))
+	sub := c.client.Subscribe(ctx, channelStreamBlocks)
+	blocks := make(chan *types.StreamBlock, 16)
+	go func() {
+		defer close(blocks)
+		defer func() {
+			if err := sub.Close(); err != nil {
+				lgr.Warn("cannot close subscription", zap.Error(err))
+			}
+		}()
+		messages := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				block := &types.StreamBlock{}
+				if err := json.Unmarshal([]byte(msg.Payload), block); err != nil {
+					lgr.Warn("cannot unmarshal published block", zap.Error(err))
+					continue
+				}
+				blocks <- block
+			}
+		}
+	}()
+	return blocks
+}
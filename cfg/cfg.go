@@ -34,9 +34,11 @@ const (
 )
 
 type ExplorerConfig struct {
-	ServerMode        string
-	Port              string
-	HttpRequestSecret string
+	ServerMode       string
+	Port             string
+	AdminTokenSecret string   // HMAC key of admin tokens
+	CORSAllowOrigins []string // origins allowed by CORS and WebSocket stream, `*` allows any
+	TrustedProxies   []string // IPs or CIDRs of proxies whose X-Forwarded-For and X-Real-IP are trusted
 
 	LogLevel string
 
@@ -48,6 +50,9 @@ type ExplorerConfig struct {
 	BufferedBlocks int64
 
 	CoinMarketAPIKey string
+	PriceProviders   []string // coinmarketcap, coingecko, static or stub, tried in order
+	PriceStaticFile  string
+	PriceStubURL     string
 
 	CacheEngine      string
 	CacheURL         string
@@ -72,7 +77,31 @@ type ExplorerConfig struct {
 	BackfillInterval time.Duration
 	VerifierInterval time.Duration
 
+	SlashEventsInterval     time.Duration
+	RewardSnapshotInterval  time.Duration
+	UnbondingSyncInterval   time.Duration
+	WebhookDeliveryInterval time.Duration
+	AutocompleteInterval    time.Duration // full rebuild of autocomplete index
+	RichListInterval        time.Duration
+	PriceSnapshotInterval   time.Duration
+
 	VerifyBlockParam *types.VerifyBlockParam
+
+	ProductionWindowSize uint64
+	RebuildNetworkStats  bool // recompute network charts from stored blocks when watcher starts
+
+	RichListExchanges []string // addresses tagged as exchange in rich list
+
+	GraphQLMaxDepth      int
+	GraphQLMaxComplexity int
+
+	StreamMaxSubscriptions int
+
+	RateLimitEnabled   bool
+	RateLimitDefault   int64 // requests per minute of a client
+	RateLimitExpensive int64
+	RateLimitExport    int64
+	RateLimitAllowlist []string // IPs or CIDRs of internal services
 }
 
 func New() (ExplorerConfig, error) {
@@ -158,6 +187,52 @@ func New() (ExplorerConfig, error) {
 	if err != nil {
 		verifierInterval = 2 * time.Second
 	}
+	slashEventsIntervalStr := os.Getenv("SLASH_EVENTS_INTERVAL")
+	slashEventsInterval, err := time.ParseDuration(slashEventsIntervalStr)
+	if err != nil {
+		slashEventsInterval = 10 * time.Minute
+	}
+	rewardSnapshotIntervalStr := os.Getenv("REWARD_SNAPSHOT_INTERVAL")
+	rewardSnapshotInterval, err := time.ParseDuration(rewardSnapshotIntervalStr)
+	if err != nil {
+		rewardSnapshotInterval = 1 * time.Hour
+	}
+	unbondingSyncIntervalStr := os.Getenv("UNBONDING_SYNC_INTERVAL")
+	unbondingSyncInterval, err := time.ParseDuration(unbondingSyncIntervalStr)
+	if err != nil {
+		unbondingSyncInterval = 30 * time.Minute
+	}
+	webhookDeliveryIntervalStr := os.Getenv("WEBHOOK_DELIVERY_INTERVAL")
+	webhookDeliveryInterval, err := time.ParseDuration(webhookDeliveryIntervalStr)
+	if err != nil {
+		webhookDeliveryInterval = 5 * time.Second
+	}
+	autocompleteIntervalStr := os.Getenv("AUTOCOMPLETE_INTERVAL")
+	autocompleteInterval, err := time.ParseDuration(autocompleteIntervalStr)
+	if err != nil {
+		autocompleteInterval = time.Hour
+	}
+	richListIntervalStr := os.Getenv("RICH_LIST_INTERVAL")
+	richListInterval, err := time.ParseDuration(richListIntervalStr)
+	if err != nil {
+		richListInterval = 30 * time.Minute
+	}
+	priceSnapshotInterval, err := time.ParseDuration(os.Getenv("PRICE_SNAPSHOT_INTERVAL"))
+	if err != nil {
+		priceSnapshotInterval = 10 * time.Minute
+	}
+	priceProviders := []string{"coinmarketcap", "coingecko"}
+	if priceProvidersStr := os.Getenv("PRICE_PROVIDERS"); priceProvidersStr != "" {
+		priceProviders = strings.Split(priceProvidersStr, ",")
+	}
+	var richListExchanges []string
+	if richListExchangesStr := os.Getenv("RICH_LIST_EXCHANGES"); richListExchangesStr != "" {
+		richListExchanges = strings.Split(richListExchangesStr, ",")
+	}
+	rebuildNetworkStats, err := strconv.ParseBool(os.Getenv("REBUILD_NETWORK_STATS"))
+	if err != nil {
+		rebuildNetworkStats = false
+	}
 
 	storageMinConnStr := os.Getenv("STORAGE_MIN_CONN")
 	storageMinConn, err := strconv.Atoi(storageMinConnStr)
@@ -188,16 +263,78 @@ func New() (ExplorerConfig, error) {
 		verifyBlockHash = true
 	}
 
+	productionWindowSizeStr := os.Getenv("PRODUCTION_WINDOW_SIZE")
+	productionWindowSize, err := strconv.ParseUint(productionWindowSizeStr, 10, 64)
+	if err != nil || productionWindowSize == 0 {
+		productionWindowSize = 1000
+	}
+
+	graphQLMaxDepthStr := os.Getenv("GRAPHQL_MAX_DEPTH")
+	graphQLMaxDepth, err := strconv.Atoi(graphQLMaxDepthStr)
+	if err != nil || graphQLMaxDepth <= 0 {
+		graphQLMaxDepth = 8
+	}
+	graphQLMaxComplexityStr := os.Getenv("GRAPHQL_MAX_COMPLEXITY")
+	graphQLMaxComplexity, err := strconv.Atoi(graphQLMaxComplexityStr)
+	if err != nil || graphQLMaxComplexity <= 0 {
+		graphQLMaxComplexity = 1000
+	}
+	streamMaxSubscriptionsStr := os.Getenv("WS_MAX_SUBSCRIPTIONS")
+	streamMaxSubscriptions, err := strconv.Atoi(streamMaxSubscriptionsStr)
+	if err != nil || streamMaxSubscriptions <= 0 {
+		streamMaxSubscriptions = 20
+	}
+
+	rateLimitEnabledStr := os.Getenv("RATE_LIMIT_ENABLED")
+	rateLimitEnabled, err := strconv.ParseBool(rateLimitEnabledStr)
+	if err != nil {
+		rateLimitEnabled = true
+	}
+	rateLimitDefaultStr := os.Getenv("RATE_LIMIT_DEFAULT")
+	rateLimitDefault, err := strconv.ParseInt(rateLimitDefaultStr, 10, 64)
+	if err != nil || rateLimitDefault <= 0 {
+		rateLimitDefault = 300
+	}
+	rateLimitExpensiveStr := os.Getenv("RATE_LIMIT_EXPENSIVE")
+	rateLimitExpensive, err := strconv.ParseInt(rateLimitExpensiveStr, 10, 64)
+	if err != nil || rateLimitExpensive <= 0 {
+		rateLimitExpensive = 30
+	}
+	rateLimitExportStr := os.Getenv("RATE_LIMIT_EXPORT")
+	rateLimitExport, err := strconv.ParseInt(rateLimitExportStr, 10, 64)
+	if err != nil || rateLimitExport <= 0 {
+		rateLimitExport = 5
+	}
+	var rateLimitAllowlist []string
+	if rateLimitAllowlistStr := os.Getenv("RATE_LIMIT_ALLOWLIST"); rateLimitAllowlistStr != "" {
+		rateLimitAllowlist = strings.Split(rateLimitAllowlistStr, ",")
+	}
+
+	var trustedProxies []string
+	if trustedProxiesStr := os.Getenv("TRUSTED_PROXIES"); trustedProxiesStr != "" {
+		trustedProxies = strings.Split(trustedProxiesStr, ",")
+	}
+
+	corsAllowOrigins := []string{"*"}
+	if corsAllowOriginsStr := os.Getenv("CORS_ALLOW_ORIGINS"); corsAllowOriginsStr != "" {
+		corsAllowOrigins = strings.Split(corsAllowOriginsStr, ",")
+	}
+
 	cfg := ExplorerConfig{
 		ServerMode:            os.Getenv("SERVER_MODE"),
 		Port:                  os.Getenv("PORT"),
-		HttpRequestSecret:     os.Getenv("HTTP_REQUEST_SECRET"),
+		AdminTokenSecret:      os.Getenv("ADMIN_TOKEN_SECRET"),
+		CORSAllowOrigins:      corsAllowOrigins,
+		TrustedProxies:        trustedProxies,
 		LogLevel:              os.Getenv("LOG_LEVEL"),
 		IsReloadBootData:      isReloadBootData,
 		DefaultAPITimeout:     time.Duration(apiDefaultTimeout) * time.Second,
 		DefaultBlockFetchTime: time.Duration(apiDefaultBlockFetchTime) * time.Millisecond,
 		BufferedBlocks:        int64(bufferBlocks),
 		CoinMarketAPIKey:      os.Getenv("COIN_MARKET_API_KEY"),
+		PriceProviders:        priceProviders,
+		PriceStaticFile:       os.Getenv("PRICE_STATIC_FILE"),
+		PriceStubURL:          os.Getenv("PRICE_STUB_URL"),
 		CacheEngine:           os.Getenv("CACHE_ENGINE"),
 		CacheURL:              os.Getenv("CACHE_URI"),
 		CacheDB:               cacheDB,
@@ -221,10 +358,34 @@ func New() (ExplorerConfig, error) {
 		BackfillInterval: backfillInterval,
 		VerifierInterval: verifierInterval,
 
+		SlashEventsInterval:     slashEventsInterval,
+		RewardSnapshotInterval:  rewardSnapshotInterval,
+		UnbondingSyncInterval:   unbondingSyncInterval,
+		WebhookDeliveryInterval: webhookDeliveryInterval,
+		AutocompleteInterval:    autocompleteInterval,
+		RichListInterval:        richListInterval,
+		PriceSnapshotInterval:   priceSnapshotInterval,
+
 		VerifyBlockParam: &types.VerifyBlockParam{
 			VerifyTxCount:   verifyTxCount,
 			VerifyBlockHash: verifyBlockHash,
 		},
+
+		ProductionWindowSize: productionWindowSize,
+		RebuildNetworkStats:  rebuildNetworkStats,
+
+		RichListExchanges: richListExchanges,
+
+		GraphQLMaxDepth:      graphQLMaxDepth,
+		GraphQLMaxComplexity: graphQLMaxComplexity,
+
+		StreamMaxSubscriptions: streamMaxSubscriptions,
+
+		RateLimitEnabled:   rateLimitEnabled,
+		RateLimitDefault:   rateLimitDefault,
+		RateLimitExpensive: rateLimitExpensive,
+		RateLimitExport:    rateLimitExport,
+		RateLimitAllowlist: rateLimitAllowlist,
 	}
 
 	return cfg, nil
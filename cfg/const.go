@@ -64,6 +64,10 @@ const (
 	SMCTypeValidator = "Validator"
 	KRCTransferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
 
+	// Validator SMC events
+	ValidatorSlashedTopic  = "0x4f5f38ee30b01a960b4dfdcd520a3ca59c1a664a32dcfe5418ca79b0de6b7236"
+	ValidatorLivenessTopic = "0x121f38bd522ed7e56e65361cd6f558ca87f5cbf69f33be9c7a328444688ad7fd"
+
 	DefaultKRCTokenLogo = "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAMgAAADSCAYAAAAPFY9jAAAUBElEQVR4Xu2dTWxcVxXH7xtPHaet04/QqlRIrVKlQm3ER+ZOQe2CHWxAlVh4gcQCqeqiElLZwKaLsEBCYgFISAihskBiE4lNFSoVFkZqFGzPe3ZcHKHiYvOhqgiatoYWJ3Y8D704E4/H8+ac+3Hu1zve+t5zz/mf87vnvo+ZyUTwf5kQogzcS1of8zy/KcDMzMyxM2fO7AQuRlLuVZnlv0AVGIAx6l6WZT/pdDrfDNTtpNxiQAJL59LS0pOtVmsN65aUsuE5pO3eDRcXW4b04/I8f08Icd/RlXAFwKDQ5IgBodEVbbXuGIU2cHTgtJRy12A+Tx1SgAHxVA4EYIxG8nMp5fOewhspsdBvstSrxIA4rKClpaWHWq3WOw6XFFmWXe90OjMu10xpLQbEQTbzPP+rEOIRB0tNXKJZ1ym4azcoJwwIpJDB/x0co7S8axYoWhLdnmQEyKAAWPDDSQgVjDGl8lMp5QtmJZT2bG1ARoug6ZDkeX5WCFHEWi5p5c/O8arKpQIgB4vW7ZDuRbYnhG5h53n+ZyHEad359ueZaeI+h/YVsGlRAZD9ZaHjQ1MEhnSwmSQftnZ2du58+umnt32sHdKaSoBgiyJlSLAahJRkE1/KsvxRt9v9lomNmOeiAVEtjJQgWVhY+ES73f5HzIm24XtKOcXqgQJEFY7B4rELqhs3VvxYx8WeVxXdQUBMiyRGMYui6JdlCWqjInSKY2PMrWoeJhaBKRyxdRL1eM3uGKkmK+DxL0spnwvYP0XXDvJaC4h6sUz2IdTdZnFx8fGpqak3FRXk4WMUKMtyr9vtttXEcbnJqK81FhDbcITYSfI8f0sI8ZhaMnk0VoFQN0Ss/4NxRwChgiMUSKjjU01A6uMvXLgwde7cuX6scR4CxFXx+NxdyrJsFUWxdzRh6u031qT78DvLsnOdTue7PtY2WfM2IK7g4E5ikq405vrcIFUVvAmIazjoIcF1A19xqyYp1fExgJL5LhLfIvmOP9XiV4lrY2OjPTc3N+bYq2KFZqzXDkLfSXCiMSQ4neyOOtrlsyx7qdPpfM/uOmbWvF2DjLrts5NUT82rp+dmUvJsiwqUUsqWRXvaprzcxarz1jMk7aIo+OtytEuJZqLPmqgicv4cBJLRpyCvvvrqsQcffPAa5CP8f9xNAtgOj/B9DHf6JB2bbnpI6gt4fn5+ZnZ2tvEfFMLmyvW4LMt+0Ol0vu1qXWfvYqkGRA9JvUfr6+vHtra2EJ2EO4VqXm2Od1EjTt7m1RXFhQB1vq2trU1fu3btuq7v/uc1B17KOgE/8+D7Fihl8PVFvF9c58+fnzp16tQN/8XOHmAU6HQ6rSzLrH7PKQhI5VgzITlIie/4McXBYw4p8KKU8sc2NEEBwpDobhLNOebYKEYKG6YnEDQgDIkuJBRpZ5uHFYA3Il1QlABhSBiS2NFUvU5RBqTpkJRlOVUURcMv3OEdO3SQyrJ8qdvtgu99aQHSdEjyPL9DCMG/Nhs6BQj/oKOXNiBNhwT/MBGRJR7iTQFSQBgS7BN3b/nnhQEFyAFhSBiSmCl0AkjTIeFrkngRcQZI0yHh11LihMQpIE2HJIT44yxTf143DpBKaiho6nTwu1vUCtuzD9WK0W3ecW6GUhxQ4PYkHm8pFB2o44zdPlQnyQLiu5PwF0HEgU6jAQkAEv4iiMA5aTwg6pDYfc+In7iHTQgDcis/kBCUadzc3Jy5evUqfxEEpciatqG6SPoaZFQzSAxNjVHTLl26dHx6evp/qME8yJkCUE00ChD145bdPHEnsaunDWsMyBgVIVFsCF9nI/5vS6FUx71tqBYa10EGKYCEoUzV/Px8e3Z2lr/mlFJkpG2oDhoLiO/jVrU+P0xEVjHhMAVA7NzejC3pkECEublpOja9qPVwbR/Kf6M7SAjHrfQgsbPRugJFAxCzAGPdESGhKBPGXwRBqe7keobyzh1kKDeQWJRp5LtblOrW24ZyzoCMaAcJRplGe79PQumlLdtmJxVbXkD5ZkACe07C727ZKn2cHQYEp9ORUZBwmmZR0/hHfFAyWRkE5Zk7yASZIfGsZKjGSHyd5ODIVOm2srLy6N7e3ialRma29/2FcjwGELOzYax3serEhgQ0S9Lk2TF+W8qwXr1e71SWZX+h1MjUNpRf7iAIhSERESa0h8R0C3icTnmePyaEeEtbAOKJUG6z/R+6tfejPKl1kEF+ICGJ86jwxN1uPrFxTdLHLSRq8UN5Vewg8OKpAlIVCiQmtph0x5lpC+dO1y+MLqEetyDfFQGBJTRLImzf9whIUEr/QvwiCBU9QoQE8p8B0ahoSFQNk+gpIb0qX69Dfbe6fPny4zdu3HgTHTDxQCiXDIhmAiBhNc2ipoXwWopJ/EVRfKosy1VUsMSDoDgYEIMEQOIamAan+nyYaCPuxcXFT09NTV0GAyUeAMXCgBgmABLY0PzE6T6+CMJmvDSdRO1mBBQPA2JcwZmQsmNdR6xbLiGBignr8/C4paWlJ1ut1prOXBtzoJisJ1btLpYa7TYEobIBCU21bmXXxXGLMr6FhYUn2u32FUqN6mxDcXkGxIckdGtCYttf+WCDobxw148LvwH2er0zWZb90b5Gky1CsTEgljMCCW55uUPmKG4Bu4xH7fRhR0koPgbEjs6HrECiEyx526TNh4ku4/ABRyUaFCMDQlStkPBEy942a1pwLv039dVESyhOBsREXWAuJD7h0sLkNxNd+u0TjgZ1EPzFIGVRjrPtsthG19e5cHfpr284GgQIZdmbw+ey6EaV2L8FfGIb85EGWj8P6xgCHAyINjfmUIwuTVt8kwPN8/xOIcRHk0a59C8UOBgQbUBoJroswsMRZGJ19fJdu7u7H/o+BoYEBwNCU+dGVukhqe9+4zoJvT8HcoUGBwNiVMp0k10W5WgUw+9uufQjRDgYELoaN7asXpz2rouqrxQ6ffr0dfUg9HwIFQ4GRL0CnM5Qh8Spe1YWCxmOekAONgJ+UGilDPSNpAxJ6HBwB9GvW6cz9yHRO744dVRhsRjgYEAUEup7aEqdJBY4GBDfVa+4fgqQmMPhtpNCmvM1iGIRUw+HEka9vol9czhMVtebC+nNgOjpSjoLShrp4prGY4SDj1iayQ5hWtiQhPnioU7eIJ25g+io6mgOlDxaN3DXAvF0jvHxQBozILRVNsY6rvAGE6EEOnd/aMF44KhXCdKXAfFZYci1oSQizVgdlgIcfA1itST8GvMPyUHnSwUOBsRvTVtf3T8kQuGHfKyHT2IQ0pTiiPUnIcQn7UejdnaP99WNyXFCCbWv+4HFzc3NmatXr25TruHaNqSndUB6vd4vsyz7uutAVderhIn1qAAlVVULlfGYj++q2PM9FtLSOiDLy8tP9Pt9L9+zihV7WBSGBKvawbg8z+8RQnygPjO8Gc4BqSQIuejGCRKyv5NKCkouZTleunTp/unp6auUa7iwDWlovYOEDMgkMRgS9XJcXFw8OTU19a76zHBmeAAkE3nes/e70pa0hIQIGWxIAkxskA3d/+d5/jEhxL9153uetyOlPDbJh0Z0EJUC4k6iXrIRd5KXpZTPNRoQFTgGQjEk6pC88cYb9+3s7LynPtPfDExtJN1BMALUpYchUS/chYWFE+12e0t9pp8ZmPpIFhBM8FBaGBJIoaP/d/mbiereHZ6BqZEkAcEEjhWXIcEqdTAuz/M7hBA76jPdzsDUCQkgvV5vJcuyz7gNd381TNCqfjEkqoq5+WFRda8C6SCVGz6KigKOuC/c/f5E9erqau0XZpsWt435mHoh6SA+AMEEayqqD+hNfbbbVVVfGL25UQb7WgqmZpIABBOojUIbD7560djyRcWOS41G/QrxFnCr1Xr27Nmzr0AaJgGI3V0SkszP8RH2Ch7hE5IrV67cv729Hcy7W1gtkgGEIYEBca3RqEchQRICIG8LIR7Gpc3eKGzgR1dUPyqFc02i5ru+RuZ50jtuqcWH8RKrAVkH8XGhPhAGGzxGSGhMOJBAnh7+v0uNRj2bn5+/d3Z29n01j+2OxsafJCCujxKqkAySozrPbonQPDPC+njx4sXZmZmZ/2DHWx53QUr5FYxNS4CMb4FNKgBsrKM7F3YeJpk6Y7A7qY5taI6v11JUYrYEyHgpfCc/tE5SlxjfOqkUDFT0qv/38UUQKvGSAlIUxYtlWf5QVTTb41UEMV27rtghH5oMiesvgoByMVwDpID4vFAfLXQVUWxDgl27yZC4fFUem4+qDhoDiK/jlkoyQthQVP013UyG5+vdAlbzQDW+xACB75erCqQmv53Rzegk43NF/fFd1fyTA7K0tPTVVqv1azulY8eKqkh2VlWz0gxIam/ukL3gqJp7ckBCODaMS4OqUIdtwJ1KDYcw7wKaaWSmANFbwH0p5ZSKZxMAGS4Cs4LwvRvWCeKzALBJ8q2dT43W19dPbG1tWfuMu04sje0ggwLVEQ1b3LbGNRkSm0/cdXLtBJBQj1kMCR5hneLCW5888rXXXrvr5MmTH5ra04mBAbmlurp4ZsdOnWQ3uZOYvpaint/9DEUOiN0i1RVRp9h15vgGpPLZp0YmT9x1/XYGyORjlt1C1ym+0I9bIcBBpxE+/7qQRA6ISUnbn6srpn1P9i2GBAcdJHj1VC/cNzY22nNzc3v4FQ5GBtJBdFynnRMKJCHCEQIkKs9JTHLpFJBQd8M61EyEtYFvyHCEAMnKysq9e3t74CcTTfLIgACVbCKuCSQxwBECJK+//vp9x48fr/1WedP8+QDkHSHEQybF43quqciq/sYER+iQmObOOSCxHbNcF0CMcNjRCH8na9yGU3PcKqSU0uQnwRkQhe3ddDeClooZDjuQQApN/v/oh65s5MsLILF2kcpvG6KPS3M8cMA7PZVGGHyGvzDbhh8MCEb1kTE2hB82GQ8ceLFsa4RfWYi1tbW7z5w5Y/zuVrWmN0CWl5e/0+/3v68SeEhjbRVAinCEcNyyVSveAHF7zIKPBTqCmkKSMhypQOIbkP8KIe7WKc5Q5uhC0gQ4UoDEKyBuuwgdUqqQhA+H/Y5br5H9tWxmmgGxpCYWkvDhsCTIGDNYjeg8ULfsHRB/XcT+zgUVQJPhiPW41WBABimzC0odJAzHwe4NbSTq+zzdjCAAMesidgvchtSjBcBwHFU1FkgSAMRGSdu3MSgAhqNe2xggCQYQsy5iv8DZohsFQockNECuCyGm3aSGVwlFAfeQ4I/lhIDgnRhOFB9JQilbt364hwQXHyEg4xyAoen1ep/NsmwZ5z6PiluBw/UQIiSOAcGlk7sITqcUR4UGSZCA8AV7iqWPjykkSBgQfN54pEMFQoEkWEC4izisxkCXCgGS0AG5UwjxUaD5Y7ccKOAbkqAB4S7ioAIjWMInJMEDUn0qOM97ZQR5ZBcJFfAFSQSACLG6uvq53d3dBUL92bQzBeBnYXWu+IAkCkD4qOWseoNfyDUkCED0ibetNj9AtK1onPZcQoIAJCwRGZKw8uHLG1eQ3AIknC4BCc6AQAo15/8uIImug/D1SHMAwERKDUmUgPiHJJ6Oiymy2MdQQhItIGVZZkVR9A+Sy0Ube6Gb+E8FSbSAVGIWRbFZluWjJsKmM5c3CApIogbE/1ErHbxSicQ2JNEDwpCkUtr24rAJSWby81T2QjK3xLd/zTVMyQIekslH0yQ6yCCxDElKJW4eCx6S+rUAQOK78GNIzAsrJQumkCTVQarErq+vn9ja2to6nOT4QE+pSH3HYgJJcoDcumj/mhDiV74Tk+b6cW42upAkCUhVmL1e7xdZln0jzSLlqHQU0IEkWUBuQdLLskzqiMlz0lRAFZIEATl8BMjzvBBCnHWTbp/HD59r49WtCtT3jRQVSBQBiSMJo+nK8/wPQojP49PIIykUGC7MWCBRBIRCNjc2i6J4vizLn7lZjVcZVWDcrh0DJI0BpErY8vLyA/1+/19cvm4VmHSkCR2SRgFSlcXR1+TdFkvTVsOc90OGhACQOK5TfCelAaC8I6V8GBun73zUgUwACFYS/+N8J8W/AjQeXL9+/dFnnnnmb6rWfedjHCSNBqRKoO+kqBZR6OMxR6pJMfjOx6j/jQfkFiQfCCHuCb34QvfPFI5BfCFBwoDcykqe5xF+k3w413u24AgNEgZkZFv2vXuF3iVG/SvL8ovdbvd3FH77zkUFPQNyKLP7O7LvxFAUG4VN211jnI/muTDrsgxITeXMz8+3Z2dndykKKwWbLuDwfdziDoKoVPMdDLFIREOklK2bbZb878hLpw7WPAhqsAFodBCzlkWuK8ECy8vLX+j3+78nMB2VSZddg+a4hZN7OE4NQHCLpDiqqd1kY2OjPTc3txdCTqlzwM9BDLO8srJy797e3vuGZqKZ7rtruOwk/CTdYllS72QWXdUyFSIYw4HY1j+wd7HSuY6xnSitarY76QEp5bt2TdJYs6X9pM0g8WsQdyDaShZNKaGs/lZK+SXUyIAGwbpPrgGoUybz1aMh5Oz8+fNTp06duhGCLwo+7Ekp2wrjgxsKQzLeZQiOalbiHcRfLnWT5srjsiy3u91u9f5ZEn+qemPgYEAclIZq4hy49Hcp5SMO1nG+BFZrLBwMiMMUYpNH5VK/3//4U0899U8q+6HY3de5/rpDBQ4PgLi7aA4lYaN+5HmxLUQ548o/1YJw5RflOnWbkY4WfA1CmakJts2/PGLiZvNlKeVvji7fnA1qFBIdODx0EE/VGPiyFy9efHhmZuZtQzffk1KeNLQR8fSj8A8g0YWDAQmwHFQ+2ViW5SvdbvfZAMNIxiXHR6zmtHhbFZLn+RUhxBMDeya7oS2fmmTn//tchq7ru43wAAAAAElFTkSuQmCC"
 )
 
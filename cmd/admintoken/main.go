@@ -0,0 +1,74 @@
+// Package main issue admin tokens signed by ADMIN_TOKEN_SECRET, e.g. `admintoken -operator alice -roles labels,contracts -ttl 720h`.
+// The token is printed to stdout and its jti to stderr, keep the jti to revoke the token with /admin/tokens/:jti/revoke
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"github.com/kardiachain/kardia-explorer-backend/api"
+	"github.com/kardiachain/kardia-explorer-backend/cfg"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func main() {
+	operator := flag.String("operator", "", "name of operator, recorded in audit log")
+	roles := flag.String("roles", "", "comma separated roles: "+strings.Join(types.AdminRoles, ", "))
+	ttl := flag.Duration("ttl", 24*time.Hour, "token lifetime")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		panic(err.Error())
+	}
+	serviceCfg, err := cfg.New()
+	if err != nil {
+		panic(err.Error())
+	}
+
+	if *operator == "" || *roles == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		panic(err.Error())
+	}
+	now := time.Now()
+	claims := &types.Operator{
+		ID:        hex.EncodeToString(jti),
+		Name:      *operator,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(*ttl).Unix(),
+	}
+	for _, role := range strings.Split(*roles, ",") {
+		role = strings.TrimSpace(role)
+		if !isAdminRole(role) {
+			fmt.Fprintf(os.Stderr, "unknown role %s\n", role)
+			os.Exit(2)
+		}
+		claims.Roles = append(claims.Roles, role)
+	}
+	token, err := api.SignAdminToken(serviceCfg.AdminTokenSecret, claims)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	fmt.Fprintln(os.Stderr, "jti", claims.ID)
+	fmt.Println(token)
+}
+
+func isAdminRole(role string) bool {
+	for _, r := range types.AdminRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
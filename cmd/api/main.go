@@ -47,12 +47,24 @@ func main() {
 		KardiaURLs:         serviceCfg.KardiaPublicNodes,
 		KardiaTrustedNodes: serviceCfg.KardiaTrustedNodes,
 
-		CacheAdapter:      cache.Adapter(serviceCfg.CacheEngine),
-		CacheURL:          serviceCfg.CacheURL,
-		CacheDB:           serviceCfg.CacheDB,
-		CacheIsFlush:      serviceCfg.CacheIsFlush,
-		BlockBuffer:       serviceCfg.BufferedBlocks,
-		HttpRequestSecret: serviceCfg.HttpRequestSecret,
+		CacheAdapter: cache.Adapter(serviceCfg.CacheEngine),
+		CacheURL:     serviceCfg.CacheURL,
+		CacheDB:      serviceCfg.CacheDB,
+		CacheIsFlush: serviceCfg.CacheIsFlush,
+		BlockBuffer:  serviceCfg.BufferedBlocks,
+
+		PriceProviders:   serviceCfg.PriceProviders,
+		CoinMarketAPIKey: serviceCfg.CoinMarketAPIKey,
+		PriceStaticFile:  serviceCfg.PriceStaticFile,
+		PriceStubURL:     serviceCfg.PriceStubURL,
+
+		ProductionWindowSize: serviceCfg.ProductionWindowSize,
+
+		GraphQLMaxDepth:      serviceCfg.GraphQLMaxDepth,
+		GraphQLMaxComplexity: serviceCfg.GraphQLMaxComplexity,
+
+		StreamMaxSubscriptions: serviceCfg.StreamMaxSubscriptions,
+		CORSAllowOrigins:       serviceCfg.CORSAllowOrigins,
 
 		Metrics: nil,
 		Logger:  logger,
@@ -72,5 +84,12 @@ func main() {
 		}
 	}
 
-	api.Start(srv, serviceCfg)
+	if err := srv.MigrateWebhookOwners(ctx); err != nil {
+		logger.Warn("cannot migrate webhook owners", zap.Error(err))
+	}
+	if err := srv.MigrateSearchNames(ctx); err != nil {
+		logger.Warn("cannot migrate search names", zap.Error(err))
+	}
+
+	api.Start(srv, serviceCfg, logger)
 }
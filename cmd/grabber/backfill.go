@@ -87,6 +87,18 @@ func backfill(ctx context.Context, srv *server.Server, interval time.Duration) {
 				_ = srv.InsertErrorBlocks(ctx, blockHeight-1, blockHeight+1)
 				continue
 			}
+			// subscribers missed this block while it was not imported
+			if err := srv.PublishBlock(ctx, block); err != nil {
+				lgr.Warn("Refilling: Failed to publish block", zap.Error(err))
+			}
 		}
 	}
 }
+
+// backfillTxs run on start until it has finished once, txs imported by older versions miss fields which listings are
+// filtered by
+func backfillTxs(ctx context.Context, srv *server.Server) {
+	if err := srv.BackfillTxs(ctx); err != nil {
+		srv.Logger.Error("Failed to backfill txs, it's continued on next start", zap.Error(err))
+	}
+}
@@ -84,6 +84,10 @@ func listener(ctx context.Context, srv *server.Server, interval time.Duration) {
 					lgr.Debug("Listener: Failed to import block", zap.Error(err))
 					continue
 				}
+				// notify WebSocket subscribers through API replicas
+				if err := srv.PublishBlock(ctx, block); err != nil {
+					lgr.Warn("Listener: Failed to publish block", zap.Error(err))
+				}
 				if latest-1 > prevHeader {
 					lgr.Warn("Listener: We are behind network, inserting error blocks", zap.Uint64("from", prevHeader), zap.Uint64("to", latest))
 					err := srv.InsertErrorBlocks(ctx, prevHeader, latest)
@@ -86,6 +86,8 @@ func main() {
 		CacheIsFlush: serviceCfg.CacheIsFlush,
 		BlockBuffer:  serviceCfg.BufferedBlocks,
 
+		ProductionWindowSize: serviceCfg.ProductionWindowSize,
+
 		Metrics: nil,
 		Logger:  logger.With(zap.String("service", "listener")),
 	}
@@ -110,6 +112,8 @@ func main() {
 		CacheIsFlush: serviceCfg.CacheIsFlush,
 		BlockBuffer:  serviceCfg.BufferedBlocks,
 
+		ProductionWindowSize: serviceCfg.ProductionWindowSize,
+
 		Metrics: nil,
 		Logger:  logger.With(zap.String("service", "backfill")),
 	}
@@ -133,7 +137,8 @@ func main() {
 		CacheIsFlush: serviceCfg.CacheIsFlush,
 		BlockBuffer:  serviceCfg.BufferedBlocks,
 
-		VerifyBlockParam: serviceCfg.VerifyBlockParam,
+		VerifyBlockParam:     serviceCfg.VerifyBlockParam,
+		ProductionWindowSize: serviceCfg.ProductionWindowSize,
 
 		Metrics: nil,
 		Logger:  logger.With(zap.String("service", "verifier")),
@@ -147,6 +152,7 @@ func main() {
 	go listener(ctx, srv, serviceCfg.ListenerInterval)
 	backfillCtx, _ := context.WithCancel(context.Background())
 	go backfill(backfillCtx, backfillSrv, serviceCfg.BackfillInterval)
+	go backfillTxs(ctx, backfillSrv)
 	verifyCtx, _ := context.WithCancel(context.Background())
 	go verify(verifyCtx, verifySrv, serviceCfg.VerifierInterval)
 	<-waitExit
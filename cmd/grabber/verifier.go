@@ -54,6 +54,10 @@ func verify(ctx context.Context, srv *server.Server, interval time.Duration) {
 			}
 			if result {
 				lgr.Warn("Verifier: Block in database is corrupted and successfully replaced")
+				// publish again, subscribers may have missed the block or got it with missing txs
+				if err := srv.PublishBlock(ctx, networkBlock); err != nil {
+					lgr.Warn("Verifier: Failed to publish block", zap.Error(err))
+				}
 			}
 		}
 	}
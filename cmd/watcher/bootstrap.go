@@ -38,6 +38,11 @@ func loadStakingBootData(ctx context.Context, cfg cfg.ExplorerConfig) error {
 		return err
 	}
 
+	kaiClient, err := kardia.NewKaiClient(kardia.NewConfig(cfg.KardiaPublicNodes, cfg.KardiaTrustedNodes, logger))
+	if err != nil {
+		return err
+	}
+
 	dbConfig := db.Config{
 		DbAdapter: db.Adapter(cfg.StorageDriver),
 		DbName:    cfg.StorageDB,
@@ -154,6 +159,14 @@ func loadStakingBootData(ctx context.Context, cfg cfg.ExplorerConfig) error {
 		lgr.Error("cannot upsert validators", zap.Error(err))
 		return err
 	}
+	latestBlock, err := kaiClient.LatestBlockNumber(ctx)
+	if err != nil {
+		lgr.Warn("cannot get latest block number", zap.Error(err))
+	}
+	if err := dbClient.RecordValidatorChanges(ctx, validators, latestBlock); err != nil {
+		lgr.Error("cannot record validator changes", zap.Error(err))
+		return err
+	}
 	lgr.Debug("Finished loading boot data ", zap.Any("Total", time.Now().Sub(loadBootDataTime)))
 
 	return nil
@@ -0,0 +1,30 @@
+// Package main
+package main
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// runPeriodically call fn every interval until ctx is done, used for jobs which
+// reload data from network in case we missed any events while subscribing
+func runPeriodically(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error, logger *zap.Logger) {
+	lgr := logger.With(zap.String("job", name))
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			startTime := time.Now()
+			if err := fn(ctx); err != nil {
+				lgr.Error("job failed", zap.Error(err))
+				continue
+			}
+			lgr.Debug("job finished", zap.Duration("TimeConsumed", time.Since(startTime)))
+		}
+	}
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 
+	"go.uber.org/zap"
+
 	"github.com/kardiachain/kardia-explorer-backend/cache"
 	"github.com/kardiachain/kardia-explorer-backend/cfg"
 	"github.com/kardiachain/kardia-explorer-backend/db"
@@ -30,6 +32,13 @@ func runStakingSubscriber(ctx context.Context, serviceCfg cfg.ExplorerConfig) er
 		CacheURL:     serviceCfg.CacheURL,
 		CacheDB:      serviceCfg.CacheDB,
 
+		Exchanges: serviceCfg.RichListExchanges,
+
+		PriceProviders:   serviceCfg.PriceProviders,
+		CoinMarketAPIKey: serviceCfg.CoinMarketAPIKey,
+		PriceStaticFile:  serviceCfg.PriceStaticFile,
+		PriceStubURL:     serviceCfg.PriceStubURL,
+
 		Logger: logger,
 	}
 	h, err := handler.New(handlerCfg)
@@ -43,5 +52,20 @@ func runStakingSubscriber(ctx context.Context, serviceCfg cfg.ExplorerConfig) er
 
 	go h.SubscribeStakingEvent(ctx)
 	go h.SubscribeValidatorEvent(ctx)
+	go h.SubscribeSlashEvent(ctx)
+	go runPeriodically(ctx, "syncSlashEvents", serviceCfg.SlashEventsInterval, h.SyncSlashEvents, logger)
+	go runPeriodically(ctx, "snapshotRewards", serviceCfg.RewardSnapshotInterval, h.SnapshotRewards, logger)
+	go runPeriodically(ctx, "syncUnbondingEntries", serviceCfg.UnbondingSyncInterval, h.SyncUnbondingEntries, logger)
+	go runPeriodically(ctx, "deliverWebhooks", serviceCfg.WebhookDeliveryInterval, h.DeliverWebhooks, logger)
+	go runPeriodically(ctx, "reindexAutocomplete", serviceCfg.AutocompleteInterval, h.ReindexAutocomplete, logger)
+	go runPeriodically(ctx, "refreshRichList", serviceCfg.RichListInterval, h.RefreshRichList, logger)
+	go runPeriodically(ctx, "snapshotPrice", serviceCfg.PriceSnapshotInterval, h.SnapshotPrice, logger)
+	if serviceCfg.RebuildNetworkStats {
+		go func() {
+			if err := h.RebuildNetworkStats(ctx); err != nil {
+				logger.Error("cannot rebuild network stats", zap.Error(err))
+			}
+		}()
+	}
 	return nil
 }
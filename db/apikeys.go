@@ -0,0 +1,195 @@
+// Package db
+package db
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+var (
+	cAPIKeys     = "APIKeys"
+	cAPIKeyUsage = "APIKeyUsage"
+)
+
+type IAPIKeys interface {
+	createAPIKeysCollectionIndexes() []mongo.IndexModel
+	createAPIKeyUsageCollectionIndexes() []mongo.IndexModel
+
+	InsertAPIKey(ctx context.Context, key *types.APIKey) error
+	APIKey(ctx context.Context, id string) (*types.APIKey, error)
+	APIKeyByHash(ctx context.Context, hash string) (*types.APIKey, error)
+	APIKeys(ctx context.Context, filter *types.APIKeysFilter) ([]*types.APIKey, uint64, error)
+	UpdateAPIKey(ctx context.Context, key *types.APIKey) error
+	RotateAPIKey(ctx context.Context, id, hash, prefix string) (*types.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) (*types.APIKey, error)
+
+	IncrAPIKeyUsage(ctx context.Context, usage []*types.APIKeyUsage) error
+	APIKeyUsage(ctx context.Context, keyID string, from, to string) ([]*types.APIKeyUsage, error)
+}
+
+func (m *mongoDB) createAPIKeysCollectionIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.M{"hash": 1}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "owner", Value: 1}, {Key: "createdAt", Value: -1}}, Options: options.Index().SetSparse(true)},
+	}
+}
+
+func (m *mongoDB) createAPIKeyUsageCollectionIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.D{{Key: "keyId", Value: 1}, {Key: "day", Value: 1}}, Options: options.Index().SetUnique(true)},
+	}
+}
+
+func (m *mongoDB) InsertAPIKey(ctx context.Context, key *types.APIKey) error {
+	key.ID = primitive.NewObjectID()
+	_, err := m.wrapper.C(cAPIKeys).Insert(key)
+	return err
+}
+
+// APIKey return nil if there is no key with id
+func (m *mongoDB) APIKey(ctx context.Context, id string) (*types.APIKey, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	return m.findAPIKey(bson.M{"_id": objectID})
+}
+
+// APIKeyByHash return nil if no key has the hash
+func (m *mongoDB) APIKeyByHash(ctx context.Context, hash string) (*types.APIKey, error) {
+	return m.findAPIKey(bson.M{"hash": hash})
+}
+
+func (m *mongoDB) findAPIKey(crit bson.M) (*types.APIKey, error) {
+	var key *types.APIKey
+	err := m.wrapper.C(cAPIKeys).FindOne(crit).Decode(&key)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (m *mongoDB) APIKeys(ctx context.Context, filter *types.APIKeysFilter) ([]*types.APIKey, uint64, error) {
+	var crit bson.M
+	critBytes, err := bson.Marshal(filter)
+	if err != nil {
+		m.logger.Warn("Cannot marshal API keys filter criteria", zap.Error(err))
+	}
+	if err := bson.Unmarshal(critBytes, &crit); err != nil {
+		m.logger.Warn("Cannot unmarshal API keys filter criteria", zap.Error(err))
+	}
+	opts := []*options.FindOptions{options.Find().SetSort(bson.M{"createdAt": -1})}
+	if filter.Pagination != nil {
+		opts = append(opts, options.Find().SetSkip(int64(filter.Pagination.Skip)), options.Find().SetLimit(int64(filter.Pagination.Limit)))
+	}
+	cursor, err := m.wrapper.C(cAPIKeys).Find(crit, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+	var keys []*types.APIKey
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, 0, err
+	}
+	total, err := m.wrapper.C(cAPIKeys).Count(crit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return keys, uint64(total), nil
+}
+
+// UpdateAPIKey update owner metadata and tier, hash and revocation have their own methods
+func (m *mongoDB) UpdateAPIKey(ctx context.Context, key *types.APIKey) error {
+	_, err := m.wrapper.C(cAPIKeys).Update(bson.M{"_id": key.ID}, bson.M{"$set": bson.M{
+		"owner":       key.Owner,
+		"email":       key.Email,
+		"description": key.Description,
+		"tier":        key.Tier,
+	}})
+	return err
+}
+
+// RotateAPIKey replace hash of a key which is not revoked, it returns nil if there is no such key
+func (m *mongoDB) RotateAPIKey(ctx context.Context, id, hash, prefix string) (*types.APIKey, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	var key *types.APIKey
+	err = m.wrapper.C(cAPIKeys).FindOneAndUpdate(
+		bson.M{"_id": objectID, "revoked": false},
+		bson.M{"$set": bson.M{"hash": hash, "prefix": prefix, "rotatedAt": time.Now()}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&key)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// RevokeAPIKey keep the key for usage history, it returns nil if there is no key with id
+func (m *mongoDB) RevokeAPIKey(ctx context.Context, id string) (*types.APIKey, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	var key *types.APIKey
+	err = m.wrapper.C(cAPIKeys).FindOneAndUpdate(
+		bson.M{"_id": objectID},
+		bson.M{"$set": bson.M{"revoked": true, "revokedAt": time.Now()}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&key)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// IncrAPIKeyUsage add counts of usage to daily usage of keys
+func (m *mongoDB) IncrAPIKeyUsage(ctx context.Context, usage []*types.APIKeyUsage) error {
+	models := make([]mongo.WriteModel, 0, len(usage))
+	for _, u := range usage {
+		inc := bson.M{"requests": u.Requests, "limited": u.Limited}
+		for route, count := range u.Routes {
+			inc["routes."+route] = count
+		}
+		models = append(models, mongo.NewUpdateOneModel().SetUpsert(true).SetFilter(bson.M{"keyId": u.KeyID, "day": u.Day}).SetUpdate(bson.M{"$inc": inc}))
+	}
+	if len(models) == 0 {
+		return nil
+	}
+	_, err := m.wrapper.C(cAPIKeyUsage).BulkWrite(models)
+	return err
+}
+
+// APIKeyUsage return daily usage of key between from and to days, inclusive
+func (m *mongoDB) APIKeyUsage(ctx context.Context, keyID string, from, to string) ([]*types.APIKeyUsage, error) {
+	crit := bson.M{"keyId": keyID, "day": bson.M{"$gte": from, "$lte": to}}
+	cursor, err := m.wrapper.C(cAPIKeyUsage).Find(crit, options.Find().SetSort(bson.M{"day": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var usage []*types.APIKeyUsage
+	if err := cursor.All(ctx, &usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
@@ -0,0 +1,97 @@
+// Package db
+package db
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+var (
+	cAuditLogs          = "AuditLogs"
+	cRevokedAdminTokens = "RevokedAdminTokens"
+)
+
+type IAuditLogs interface {
+	createAuditLogsCollectionIndexes() []mongo.IndexModel
+
+	InsertAuditLog(ctx context.Context, log *types.AuditLog) error
+	AuditLogs(ctx context.Context, filter *types.AuditLogsFilter) ([]*types.AuditLog, uint64, error)
+
+	RevokeAdminToken(ctx context.Context, token *types.RevokedAdminToken) error
+	AdminTokenRevoked(ctx context.Context, id string) (bool, error)
+}
+
+func (m *mongoDB) createAuditLogsCollectionIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.M{"time": -1}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "operator", Value: 1}, {Key: "time", Value: -1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "route", Value: 1}, {Key: "time", Value: -1}}, Options: options.Index().SetSparse(true)},
+	}
+}
+
+func (m *mongoDB) InsertAuditLog(ctx context.Context, log *types.AuditLog) error {
+	_, err := m.wrapper.C(cAuditLogs).Insert(log)
+	return err
+}
+
+// AuditLogs return latest calls first
+func (m *mongoDB) AuditLogs(ctx context.Context, filter *types.AuditLogsFilter) ([]*types.AuditLog, uint64, error) {
+	var crit bson.M
+	critBytes, err := bson.Marshal(filter)
+	if err != nil {
+		m.logger.Warn("Cannot marshal audit logs filter criteria", zap.Error(err))
+	}
+	if err := bson.Unmarshal(critBytes, &crit); err != nil {
+		m.logger.Warn("Cannot unmarshal audit logs filter criteria", zap.Error(err))
+	}
+	if crit == nil {
+		crit = bson.M{}
+	}
+	timeRange := bson.M{}
+	if !filter.From.IsZero() {
+		timeRange["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		timeRange["$lte"] = filter.To
+	}
+	if len(timeRange) > 0 {
+		crit["time"] = timeRange
+	}
+	opts := []*options.FindOptions{options.Find().SetSort(bson.M{"time": -1})}
+	if filter.Pagination != nil {
+		opts = append(opts, options.Find().SetSkip(int64(filter.Pagination.Skip)), options.Find().SetLimit(int64(filter.Pagination.Limit)))
+	}
+	cursor, err := m.wrapper.C(cAuditLogs).Find(crit, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+	var logs []*types.AuditLog
+	if err := cursor.All(ctx, &logs); err != nil {
+		return nil, 0, err
+	}
+	total, err := m.wrapper.C(cAuditLogs).Count(crit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return logs, uint64(total), nil
+}
+
+func (m *mongoDB) RevokeAdminToken(ctx context.Context, token *types.RevokedAdminToken) error {
+	_, err := m.wrapper.C(cRevokedAdminTokens).Upsert(bson.M{"_id": token.ID}, bson.M{"revokedBy": token.RevokedBy, "revokedAt": token.RevokedAt})
+	return err
+}
+
+func (m *mongoDB) AdminTokenRevoked(ctx context.Context, id string) (bool, error) {
+	count, err := m.wrapper.C(cRevokedAdminTokens).Count(bson.M{"_id": id})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -33,12 +33,14 @@ type IContract interface {
 func (m *mongoDB) InsertContract(ctx context.Context, contract *types.Contract, addrInfo *types.Address) error {
 	if contract != nil {
 		contract.CreatedAt = time.Now().Unix()
+		setContractSearchNames(contract)
 		if _, err := m.wrapper.C(cContract).Insert(contract); err != nil {
 			return err
 		}
 	}
 	if addrInfo != nil {
 		addrInfo.UpdatedAt = time.Now().Unix()
+		setAddressSearchNames(addrInfo)
 		if _, err := m.wrapper.C(cAddresses).Insert(addrInfo); err != nil {
 			return err
 		}
@@ -118,11 +120,13 @@ func (m *mongoDB) Contract(ctx context.Context, contractAddr string) (*types.Con
 
 func (m *mongoDB) UpdateContract(ctx context.Context, contract *types.Contract, addrInfo *types.Address) error {
 	contract.CreatedAt = time.Now().Unix()
+	setContractSearchNames(contract)
 	if _, err := m.wrapper.C(cContract).Upsert(bson.M{"address": contract.Address}, contract); err != nil {
 		return err
 	}
 	if addrInfo != nil {
 		addrInfo.UpdatedAt = time.Now().Unix()
+		setAddressSearchNames(addrInfo)
 		if _, err := m.wrapper.C(cAddresses).Upsert(bson.M{"address": addrInfo.Address}, addrInfo); err != nil {
 			return err
 		}
@@ -137,6 +141,7 @@ func (m *mongoDB) UpdateKRCTotalSupply(ctx context.Context, krcTokenAddress, tot
 	}
 	addrInfo.TotalSupply = totalSupply
 	addrInfo.UpdatedAt = time.Now().Unix()
+	setAddressSearchNames(addrInfo)
 	if _, err := m.wrapper.C(cAddresses).Upsert(bson.M{"address": addrInfo.Address}, addrInfo); err != nil {
 		return err
 	}
@@ -0,0 +1,58 @@
+// Package db
+package db
+
+import (
+	"reflect"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+// txsPageIndex is the order of txs pages, hash break ties of txs in the same block
+var txsPageIndex = bson.D{{Key: "time", Value: -1}, {Key: "hash", Value: -1}}
+
+// keysetCriteria return items after cursor in descending order of field, or before it for prev cursor.
+// Items with same value of field are ordered by tieField
+func keysetCriteria(field string, value interface{}, tieField string, tieValue interface{}, prev bool) bson.M {
+	op := "$lt"
+	if prev {
+		op = "$gt"
+	}
+	if tieField == "" {
+		return bson.M{field: bson.M{op: value}}
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{field: bson.M{op: value}},
+		bson.M{field: value, tieField: bson.M{op: tieValue}},
+	}}
+}
+
+// keysetSort is descending, prev cursor walk backward so results must be reversed after querying
+func keysetSort(field, tieField string, prev bool) bson.D {
+	order := -1
+	if prev {
+		order = 1
+	}
+	sort := bson.D{{Key: field, Value: order}}
+	if tieField != "" {
+		sort = append(sort, bson.E{Key: tieField, Value: order})
+	}
+	return sort
+}
+
+// restoreKeysetOrder reverse items queried by a prev cursor back to descending order, items must be a slice
+func restoreKeysetOrder(pagination *types.Pagination, items interface{}) {
+	if pagination == nil || pagination.Cursor == nil || !pagination.Cursor.Prev {
+		return
+	}
+	swap := reflect.Swapper(items)
+	for i, j := 0, reflect.ValueOf(items).Len()-1; i < j; i, j = i+1, j-1 {
+		swap(i, j)
+	}
+}
+
+func cursorTime(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
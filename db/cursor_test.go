@@ -0,0 +1,22 @@
+// Package db
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_restoreKeysetOrder(t *testing.T) {
+	blocks := []*types.Block{{Height: 1}, {Height: 2}, {Height: 3}}
+	restoreKeysetOrder(&types.Pagination{Cursor: &types.Cursor{}}, blocks)
+	assert.Equal(t, uint64(1), blocks[0].Height)
+
+	restoreKeysetOrder(&types.Pagination{Cursor: &types.Cursor{Prev: true}}, blocks)
+	assert.Equal(t, []uint64{3, 2, 1}, []uint64{blocks[0].Height, blocks[1].Height, blocks[2].Height})
+
+	restoreKeysetOrder(nil, blocks)
+	restoreKeysetOrder(&types.Pagination{Cursor: &types.Cursor{Prev: true}}, []*types.Block{})
+}
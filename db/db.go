@@ -4,6 +4,7 @@ package db
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -42,6 +43,22 @@ type Client interface {
 	IEvents
 	IHolders
 	IInternalTransaction
+	ISlashEvents
+	IRewards
+	IValidatorHistory
+	IProductionStats
+	INetworkStats
+	IRichList
+	IValidatorSets
+	IUnbondingEntries
+	IWebhooks
+	IPrices
+	IExport
+	IAPIKeys
+	IAuditLogs
+	ISearch
+	IListing
+	IMigrations
 	ping() error
 	dropCollection(collectionName string)
 	dropDatabase(ctx context.Context) error
@@ -53,25 +70,35 @@ type Client interface {
 	// Block details
 	BlockByHeight(ctx context.Context, blockHeight uint64) (*types.Block, error)
 	BlockByHash(ctx context.Context, blockHash string) (*types.Block, error)
+	BlocksByHeights(ctx context.Context, heights []uint64) ([]*types.Block, error)
+	BlockByTime(ctx context.Context, t time.Time, before bool) (*types.Block, error)
 	IsBlockExist(ctx context.Context, blockHeight uint64) (bool, error)
 
 	// Interact with blocks
 	Blocks(ctx context.Context, pagination *types.Pagination) ([]*types.Block, error)
+	LatestBlockHeight(ctx context.Context) (uint64, error)
 	InsertBlock(ctx context.Context, block *types.Block) error
 	DeleteLatestBlock(ctx context.Context) (uint64, error)
 	DeleteBlockByHeight(ctx context.Context, blockHeight uint64) error
+	MarkBlockCounted(ctx context.Context, blockHeight uint64, stats string) error
+	ClaimBlockStats(ctx context.Context, blockHeight uint64, stats string) (bool, error)
+	ReleaseBlockStats(ctx context.Context, blockHeight uint64, stats string) error
+	BlocksPendingStats(ctx context.Context, stats string) ([]*types.Block, error)
 	BlocksByProposer(ctx context.Context, proposer string, pagination *types.Pagination) ([]*types.Block, uint64, error)
 	CountBlocksOfProposer(ctx context.Context, proposerAddress string) (int64, error)
 
 	// Txs
 	TxsByBlockHash(ctx context.Context, blockHash string, pagination *types.Pagination) ([]*types.Transaction, uint64, error)
 	TxsByBlockHeight(ctx context.Context, blockNumber uint64, pagination *types.Pagination) ([]*types.Transaction, uint64, error)
-	TxsByAddress(ctx context.Context, address string, pagination *types.Pagination) ([]*types.Transaction, uint64, error)
+	TxsInBlockRange(ctx context.Context, fromHeight, toHeight uint64) ([]*types.Transaction, error)
+	TxsByAddress(ctx context.Context, address, txType string, pagination *types.Pagination) ([]*types.Transaction, uint64, error)
+	TxsByAddressInRange(ctx context.Context, filter *types.TxsByAddressFilter) ([]*types.Transaction, error)
 	LatestTxs(ctx context.Context, pagination *types.Pagination) ([]*types.Transaction, error)
 	TxsCount(ctx context.Context) (uint64, error)
 
 	// Tx detail
 	TxByHash(ctx context.Context, txHash string) (*types.Transaction, error)
+	TxsByHashes(ctx context.Context, txHashes []string) ([]*types.Transaction, error)
 
 	// Interact with tx
 	InsertTxs(ctx context.Context, txs []*types.Transaction) error
@@ -79,6 +106,7 @@ type Client interface {
 
 	// Address
 	AddressByHash(ctx context.Context, addressHash string) (*types.Address, error)
+	AddressesByHashes(ctx context.Context, addressHashes []string) ([]*types.Address, error)
 	InsertAddress(ctx context.Context, address *types.Address) error
 	UpdateAddresses(ctx context.Context, addresses []*types.Address) error
 	GetTotalAddresses(ctx context.Context) (uint64, uint64, error)
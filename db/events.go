@@ -30,6 +30,15 @@ func (m *mongoDB) createEventsCollectionIndexes() []mongo.IndexModel {
 		{Keys: bson.D{{Key: "address", Value: 1}, {Key: "timestamp", Value: -1}}, Options: options.Index().SetSparse(true)},
 		{Keys: bson.D{{Key: "methodName", Value: 1}, {Key: "timestamp", Value: -1}}, Options: options.Index().SetSparse(true)},
 		{Keys: bson.M{"blockHeight": -1}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "address", Value: 1}, {Key: "time", Value: 1}}, Options: options.Index().SetSparse(true)},
+		// events export, sorted by time then logIndex
+		{Keys: bson.D{{Key: "address", Value: 1}, {Key: "time", Value: 1}, {Key: "logIndex", Value: 1}}, Options: options.Index().SetSparse(true)},
+		// getLogs filters
+		{Keys: bson.D{{Key: "address", Value: 1}, {Key: "blockHeight", Value: -1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "topics.0", Value: 1}, {Key: "blockHeight", Value: -1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "topics.1", Value: 1}, {Key: "blockHeight", Value: -1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "topics.2", Value: 1}, {Key: "blockHeight", Value: -1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "topics.3", Value: 1}, {Key: "blockHeight", Value: -1}}, Options: options.Index().SetSparse(true)},
 	}
 }
 
@@ -68,6 +77,31 @@ func (m *mongoDB) GetListEvents(ctx context.Context, filter *types.EventsFilter)
 	if err != nil {
 		m.logger.Warn("Cannot unmarshal events filter criteria", zap.Error(err))
 	}
+	if crit == nil {
+		crit = bson.M{}
+	}
+	blockRange := bson.M{}
+	if filter.FromBlock > 0 {
+		blockRange["$gte"] = filter.FromBlock
+	}
+	if filter.ToBlock > 0 {
+		blockRange["$lte"] = filter.ToBlock
+	}
+	if len(blockRange) > 0 {
+		crit["blockHeight"] = blockRange
+	}
+	for i, topic := range filter.Topics {
+		if topic != "" {
+			crit[fmt.Sprintf("topics.%d", i)] = topic
+		}
+	}
+	if len(blockRange) > 0 || hasTopic(filter.Topics) {
+		// let mongo pick one of topic or block height indexes, hints above are for the other filters
+		opts = []*options.FindOptions{options.Find().SetSort(bson.M{"blockHeight": -1})}
+	}
+	if filter.Ascending {
+		opts = append(opts, options.Find().SetSort(bson.M{"blockHeight": 1}))
+	}
 	if filter.Pagination != nil {
 		opts = append(opts, options.Find().SetSkip(int64(filter.Pagination.Skip)))
 		opts = append(opts, options.Find().SetLimit(int64(filter.Pagination.Limit)))
@@ -101,3 +135,12 @@ func (m *mongoDB) DeleteEmptyEvents(ctx context.Context, contractAddress string)
 	_, err := m.wrapper.C(cEvents).RemoveAll(bson.M{"address": contractAddress, "methodName": ""})
 	return err
 }
+
+func hasTopic(topics []string) bool {
+	for _, topic := range topics {
+		if topic != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+// Package db
+package db
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+// exportBatchSize is number of documents fetched per round trip, exports never hold more than a batch in memory
+const exportBatchSize = 500
+
+// IExport stream matched documents to fn one by one, iteration stops at the first error returned by fn
+type IExport interface {
+	ExportTxs(ctx context.Context, filter *types.ExportFilter, fn func(tx *types.Transaction) error) error
+	ExportTokenTransfers(ctx context.Context, filter *types.ExportFilter, fn func(transfer *types.TokenTransfer) error) error
+	ExportTokenHolders(ctx context.Context, filter *types.ExportFilter, fn func(holder *types.TokenHolder) error) error
+	ExportEvents(ctx context.Context, filter *types.ExportFilter, fn func(event *types.Log) error) error
+	ExportRewardSnapshots(ctx context.Context, filter *types.ExportFilter, fn func(snapshot *types.RewardSnapshot) error) error
+}
+
+func exportTimeRange(filter *types.ExportFilter) bson.M {
+	return bson.M{"$gte": filter.From, "$lte": filter.To}
+}
+
+func exportCursor(ctx context.Context, cursor *mongo.Cursor, fn func(cursor *mongo.Cursor) error) error {
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		if err := fn(cursor); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func (m *mongoDB) ExportTxs(ctx context.Context, filter *types.ExportFilter, fn func(tx *types.Transaction) error) error {
+	crit := bson.M{
+		"$or":  []bson.M{{"from": filter.Address}, {"to": filter.Address}},
+		"time": exportTimeRange(filter),
+	}
+	cursor, err := m.wrapper.C(cTxs).Find(crit, options.Find().SetSort(bson.M{"time": 1}).SetBatchSize(exportBatchSize))
+	if err != nil {
+		return err
+	}
+	return exportCursor(ctx, cursor, func(cursor *mongo.Cursor) error {
+		var tx types.Transaction
+		if err := cursor.Decode(&tx); err != nil {
+			return err
+		}
+		return fn(&tx)
+	})
+}
+
+func (m *mongoDB) ExportTokenTransfers(ctx context.Context, filter *types.ExportFilter, fn func(transfer *types.TokenTransfer) error) error {
+	crit := bson.M{"time": exportTimeRange(filter)}
+	if filter.Address != "" {
+		crit["$or"] = []bson.M{{"from": filter.Address}, {"to": filter.Address}}
+	}
+	if filter.Contract != "" {
+		crit["contractAddress"] = filter.Contract
+	}
+	cursor, err := m.wrapper.C(cInternalTxs).Find(crit, options.Find().SetSort(bson.M{"time": 1}).SetBatchSize(exportBatchSize))
+	if err != nil {
+		return err
+	}
+	return exportCursor(ctx, cursor, func(cursor *mongo.Cursor) error {
+		var transfer types.TokenTransfer
+		if err := cursor.Decode(&transfer); err != nil {
+			return err
+		}
+		return fn(&transfer)
+	})
+}
+
+// ExportTokenHolders is a snapshot of current balances, so time range is not applied
+func (m *mongoDB) ExportTokenHolders(ctx context.Context, filter *types.ExportFilter, fn func(holder *types.TokenHolder) error) error {
+	cursor, err := m.wrapper.C(cHolders).Find(bson.M{"contractAddress": filter.Contract},
+		options.Find().SetSort(bson.M{"balanceFloat": -1}).SetBatchSize(exportBatchSize))
+	if err != nil {
+		return err
+	}
+	return exportCursor(ctx, cursor, func(cursor *mongo.Cursor) error {
+		var holder types.TokenHolder
+		if err := cursor.Decode(&holder); err != nil {
+			return err
+		}
+		return fn(&holder)
+	})
+}
+
+func (m *mongoDB) ExportEvents(ctx context.Context, filter *types.ExportFilter, fn func(event *types.Log) error) error {
+	crit := bson.M{"address": filter.Contract, "time": exportTimeRange(filter)}
+	cursor, err := m.wrapper.C(cEvents).Find(crit, options.Find().SetSort(bson.D{{Key: "time", Value: 1}, {Key: "logIndex", Value: 1}}).SetBatchSize(exportBatchSize))
+	if err != nil {
+		return err
+	}
+	return exportCursor(ctx, cursor, func(cursor *mongo.Cursor) error {
+		var event types.Log
+		if err := cursor.Decode(&event); err != nil {
+			return err
+		}
+		return fn(&event)
+	})
+}
+
+func (m *mongoDB) ExportRewardSnapshots(ctx context.Context, filter *types.ExportFilter, fn func(snapshot *types.RewardSnapshot) error) error {
+	crit := bson.M{"delegatorAddress": filter.Address, "time": exportTimeRange(filter)}
+	cursor, err := m.wrapper.C(cRewardSnapshots).Find(crit, options.Find().SetSort(bson.M{"time": 1}).SetBatchSize(exportBatchSize))
+	if err != nil {
+		return err
+	}
+	return exportCursor(ctx, cursor, func(cursor *mongo.Cursor) error {
+		var snapshot types.RewardSnapshot
+		if err := cursor.Decode(&snapshot); err != nil {
+			return err
+		}
+		return fn(&snapshot)
+	})
+}
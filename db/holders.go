@@ -24,6 +24,8 @@ func (m *mongoDB) createHoldersCollectionIndexes() []mongo.IndexModel {
 		{Keys: bson.M{"balanceFloat": -1}, Options: options.Index().SetSparse(true)},
 		{Keys: bson.M{"contractAddress": 1}, Options: options.Index().SetSparse(true)},
 		{Keys: bson.M{"holderAddress": 1}, Options: options.Index().SetSparse(true)},
+		// holders of a token sorted by balance, for export
+		{Keys: bson.D{{Key: "contractAddress", Value: 1}, {Key: "balanceFloat", Value: -1}}, Options: options.Index().SetSparse(true)},
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
@@ -25,6 +26,13 @@ func (m *mongoDB) createInternalTxsCollectionIndexes() []mongo.IndexModel {
 		{Keys: bson.M{"to": 1}, Options: options.Index().SetSparse(true)},
 		{Keys: bson.M{"txHash": 1}, Options: options.Index().SetSparse(true)},
 		{Keys: bson.M{"time": -1}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "from", Value: 1}, {Key: "time", Value: -1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "to", Value: 1}, {Key: "time", Value: -1}}, Options: options.Index().SetSparse(true)},
+		// pages are sorted by time then _id, so cursor pages are range scans of these
+		{Keys: bson.D{{Key: "time", Value: -1}, {Key: "_id", Value: -1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "from", Value: 1}, {Key: "time", Value: -1}, {Key: "_id", Value: -1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "to", Value: 1}, {Key: "time", Value: -1}, {Key: "_id", Value: -1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "contractAddress", Value: 1}, {Key: "time", Value: -1}, {Key: "_id", Value: -1}}, Options: options.Index().SetSparse(true)},
 	}
 }
 
@@ -60,15 +68,26 @@ func (m *mongoDB) GetListInternalTxs(ctx context.Context, filter *types.Internal
 	if filter.TransactionHash != "" {
 		andCrit = append(andCrit, bson.M{"txHash": filter.TransactionHash})
 	}
+	if len(filter.Contracts) > 0 {
+		andCrit = append(andCrit, bson.M{"contractAddress": bson.M{"$in": filter.Contracts}})
+	}
+	if len(filter.ExcludeContracts) > 0 {
+		andCrit = append(andCrit, bson.M{"contractAddress": bson.M{"$nin": filter.ExcludeContracts}})
+	}
+	// ascending pages walk forward the way prev cursors of descending pages do
+	sort := keysetSort("time", "_id", filter.Ascending)
+	if filter.Pagination != nil && filter.Pagination.Cursor != nil {
+		c := filter.Pagination.Cursor
+		id, _ := primitive.ObjectIDFromHex(c.Key)
+		backward := c.Prev != filter.Ascending
+		andCrit = append(andCrit, keysetCriteria("time", cursorTime(c.Time), "_id", id, backward))
+		sort = keysetSort("time", "_id", backward)
+	}
 	crit := bson.M{"$and": andCrit}
 
+	// indexes are picked by planner, filters are combined in too many ways to hint one
 	opts := []*options.FindOptions{
-		options.Find().SetHint(bson.M{"time": -1}),
-		options.Find().SetHint(bson.M{"txHash": 1}),
-		options.Find().SetHint(bson.M{"contractAddress": 1}),
-		options.Find().SetHint(bson.M{"from": 1}),
-		options.Find().SetHint(bson.M{"to": 1}),
-		options.Find().SetSort(bson.M{"time": -1}),
+		options.Find().SetSort(sort),
 	}
 	if filter.Pagination != nil {
 		filter.Pagination.Sanitize()
@@ -82,6 +101,7 @@ func (m *mongoDB) GetListInternalTxs(ctx context.Context, filter *types.Internal
 	if err := cursor.All(ctx, &iTxs); err != nil {
 		return nil, 0, err
 	}
+	restoreKeysetOrder(filter.Pagination, iTxs)
 
 	total, err := m.wrapper.C(cInternalTxs).Count(crit)
 	if err != nil {
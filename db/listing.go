@@ -0,0 +1,388 @@
+// Package db
+package db
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+	"github.com/kardiachain/kardia-explorer-backend/utils"
+)
+
+// ErrUnindexedFilter is returned for filter combinations which have no index, they would scan whole collection
+var ErrUnindexedFilter = errors.New("filter combination is not supported")
+
+const (
+	txsMethodNameField = "decodedInputData.methodname"
+	methodIDLength     = 10 // 0x and 4 bytes selector
+
+	// filterCountLimit cap total of filtered listings, counting a large result scans its whole index range
+	filterCountLimit = 10000
+)
+
+// txsFilterIndexes map filtered fields of txs listing, sorted and comma joined, to their index.
+// Equality fields come first, then sort keys, then value range, so every listing is a bounded index scan
+var txsFilterIndexes = map[string]bson.D{
+	"":            txsPageIndex,
+	"value":       {{Key: "time", Value: -1}, {Key: "hash", Value: -1}, {Key: "valueFloat", Value: 1}},
+	"status":      {{Key: "status", Value: 1}, {Key: "time", Value: -1}, {Key: "hash", Value: -1}},
+	"to":          {{Key: "to", Value: 1}, {Key: "time", Value: -1}, {Key: "hash", Value: -1}},
+	"to,value":    {{Key: "to", Value: 1}, {Key: "time", Value: -1}, {Key: "hash", Value: -1}, {Key: "valueFloat", Value: 1}},
+	"status,to":   {{Key: "to", Value: 1}, {Key: "status", Value: 1}, {Key: "time", Value: -1}, {Key: "hash", Value: -1}},
+	"method":      {{Key: txsMethodNameField, Value: 1}, {Key: "time", Value: -1}, {Key: "hash", Value: -1}},
+	"method,to":   {{Key: "to", Value: 1}, {Key: txsMethodNameField, Value: 1}, {Key: "time", Value: -1}, {Key: "hash", Value: -1}},
+	"selector":    {{Key: "methodID", Value: 1}, {Key: "time", Value: -1}, {Key: "hash", Value: -1}},
+	"selector,to": {{Key: "to", Value: 1}, {Key: "methodID", Value: 1}, {Key: "time", Value: -1}, {Key: "hash", Value: -1}},
+	"type":        {{Key: "type", Value: 1}, {Key: "time", Value: -1}, {Key: "hash", Value: -1}},
+	"to,type":     {{Key: "to", Value: 1}, {Key: "type", Value: 1}, {Key: "time", Value: -1}, {Key: "hash", Value: -1}},
+}
+
+// blocksFilterIndexes is the same as txsFilterIndexes for blocks listing, which is sorted by height
+var blocksFilterIndexes = map[string]bson.D{
+	"":         {{Key: "height", Value: -1}},
+	"proposer": {{Key: "proposerAddress", Value: 1}, {Key: "height", Value: -1}},
+}
+
+type IListing interface {
+	createTxsFilterIndexes() []mongo.IndexModel
+	createBlocksFilterIndexes() []mongo.IndexModel
+	TxsByFilter(ctx context.Context, filter *types.TxsFilter) ([]*types.Transaction, uint64, error)
+	BlocksByFilter(ctx context.Context, filter *types.BlocksFilter) ([]*types.Block, uint64, error)
+
+	TxsToBackfill(ctx context.Context, before time.Time, limit int64) ([]*types.Transaction, error)
+	BackfillTxs(ctx context.Context, txs []*types.Transaction) error
+}
+
+// filterIndexModels skip index of unfiltered listing, it's created with other indexes of collection
+func filterIndexModels(indexes map[string]bson.D) []mongo.IndexModel {
+	var models []mongo.IndexModel
+	for fields, keys := range indexes {
+		if fields == "" {
+			continue
+		}
+		models = append(models, mongo.IndexModel{Keys: keys, Options: options.Index().SetSparse(true)})
+	}
+	return models
+}
+
+func (m *mongoDB) createTxsFilterIndexes() []mongo.IndexModel {
+	return filterIndexModels(txsFilterIndexes)
+}
+
+func (m *mongoDB) createBlocksFilterIndexes() []mongo.IndexModel {
+	return filterIndexModels(blocksFilterIndexes)
+}
+
+func filterIndex(indexes map[string]bson.D, fields []string) (bson.D, error) {
+	sort.Strings(fields)
+	keys, ok := indexes[strings.Join(fields, ",")]
+	if !ok {
+		return nil, ErrUnindexedFilter
+	}
+	return keys, nil
+}
+
+func isMethodID(method string) bool {
+	return len(method) == methodIDLength && strings.HasPrefix(method, "0x")
+}
+
+// txsFilterCriteria build query of filter without time range, and return index to query with
+func txsFilterCriteria(filter *types.TxsFilter) (bson.M, bson.D, error) {
+	var (
+		crit   = bson.M{}
+		fields []string
+	)
+	if filter.Status != nil {
+		crit["status"] = *filter.Status
+		fields = append(fields, "status")
+	}
+	if filter.To != "" {
+		crit["to"] = filter.To
+		fields = append(fields, "to")
+	}
+	if filter.Type != "" {
+		crit["type"] = filter.Type
+		fields = append(fields, "type")
+	}
+	switch {
+	case isMethodID(filter.Method):
+		crit["methodID"] = strings.ToLower(filter.Method)
+		fields = append(fields, "selector")
+	case filter.Method != "":
+		crit[txsMethodNameField] = filter.Method
+		fields = append(fields, "method")
+	}
+	valueRange := bson.M{}
+	if filter.MinValue > 0 {
+		valueRange["$gte"] = filter.MinValue
+	}
+	if filter.MaxValue > 0 {
+		valueRange["$lte"] = filter.MaxValue
+	}
+	if len(valueRange) > 0 {
+		crit["valueFloat"] = valueRange
+		fields = append(fields, "value")
+	}
+	index, err := filterIndex(txsFilterIndexes, fields)
+	return crit, index, err
+}
+
+// setTxFilterFields fill fields which txs listing is filtered by
+func setTxFilterFields(tx *types.Transaction) {
+	if tx.Value != "" {
+		tx.ValueFloat = utils.BalanceToFloat(tx.Value)
+	}
+	if len(tx.InputData) >= methodIDLength && tx.InputData != "0x" {
+		tx.MethodID = strings.ToLower(tx.InputData[:methodIDLength])
+	}
+}
+
+// txBackfillFields are fields which txs imported before filters and types existed have to be updated with
+func txBackfillFields(tx *types.Transaction) bson.M {
+	setTxFilterFields(tx)
+	fields := bson.M{"valueFloat": tx.ValueFloat}
+	if tx.MethodID != "" {
+		fields["methodID"] = tx.MethodID
+	}
+	if tx.Type != "" {
+		fields["type"] = tx.Type
+	}
+	return fields
+}
+
+// txsToBackfill match txs which miss filter fields or type, and failed txs which were classified by what they called
+var txsToBackfill = bson.A{
+	bson.M{"valueFloat": bson.M{"$exists": false}},
+	bson.M{"type": bson.M{"$exists": false}},
+	bson.M{"status": types.TxStatusFailed, "type": bson.M{"$ne": types.TxTypeFailed}},
+}
+
+// TxsToBackfill return newest txs at or before time which have to be backfilled, txs inserted by now always match
+// none of txsToBackfill so they are skipped
+func (m *mongoDB) TxsToBackfill(ctx context.Context, before time.Time, limit int64) ([]*types.Transaction, error) {
+	crit := bson.M{
+		"time": bson.M{"$lte": before},
+		"$or":  txsToBackfill,
+	}
+	opts := []*options.FindOptions{
+		options.Find().SetHint(txsFilterIndexes[""]),
+		options.Find().SetSort(bson.M{"time": -1}),
+		options.Find().SetLimit(limit),
+	}
+	cursor, err := m.wrapper.C(cTxs).Find(crit, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			m.logger.Warn("Error when close cursor", zap.Error(err))
+		}
+	}()
+	var txs []*types.Transaction
+	if err := cursor.All(ctx, &txs); err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
+
+// BackfillTxs set filter fields and type of txs which are returned by TxsToBackfill
+func (m *mongoDB) BackfillTxs(ctx context.Context, txs []*types.Transaction) error {
+	if len(txs) == 0 {
+		return nil
+	}
+	models := make([]mongo.WriteModel, 0, len(txs))
+	for _, tx := range txs {
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(bson.M{"hash": tx.Hash}).
+			SetUpdate(bson.M{"$set": txBackfillFields(tx)}))
+	}
+	if _, err := m.wrapper.C(cTxs).BulkWrite(models); err != nil {
+		return err
+	}
+	return nil
+}
+
+// withCursor add keyset criteria of cursor to filter criteria, they may constrain the same field
+func withCursor(crit, cursorCrit bson.M) bson.M {
+	if len(crit) == 0 {
+		return cursorCrit
+	}
+	return bson.M{"$and": bson.A{crit, cursorCrit}}
+}
+
+// txsTimeRange resolve time range of filter, block range is narrowed to time of its first and last blocks
+func (m *mongoDB) txsTimeRange(ctx context.Context, filter *types.TxsFilter) (bson.M, error) {
+	timeRange := bson.M{}
+	if !filter.FromTime.IsZero() {
+		timeRange["$gte"] = filter.FromTime
+	}
+	if !filter.ToTime.IsZero() {
+		timeRange["$lte"] = filter.ToTime
+	}
+	if filter.FromBlock > 0 {
+		block, err := m.BlockByHeight(ctx, filter.FromBlock)
+		if err != nil {
+			return nil, err
+		}
+		if from, ok := timeRange["$gte"]; !ok || block.Time.After(from.(time.Time)) {
+			timeRange["$gte"] = block.Time
+		}
+	}
+	if filter.ToBlock > 0 {
+		block, err := m.BlockByHeight(ctx, filter.ToBlock)
+		if err != nil {
+			return nil, err
+		}
+		if to, ok := timeRange["$lte"]; !ok || block.Time.Before(to.(time.Time)) {
+			timeRange["$lte"] = block.Time
+		}
+	}
+	return timeRange, nil
+}
+
+// TxsByFilter return a page of filtered txs and their total which is capped at filterCountLimit.
+// Value and selector filters only see txs imported by older versions after BackfillTxs has updated them
+func (m *mongoDB) TxsByFilter(ctx context.Context, filter *types.TxsFilter) ([]*types.Transaction, uint64, error) {
+	crit, index, err := txsFilterCriteria(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	timeRange, err := m.txsTimeRange(ctx, filter)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+	if len(timeRange) > 0 {
+		crit["time"] = timeRange
+	}
+	total, err := m.wrapper.C(cTxs).Count(crit, options.Count().SetHint(index).SetLimit(filterCountLimit))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pagination := filter.Pagination
+	sort := keysetSort("time", "hash", false)
+	if c := pagination.Cursor; c != nil {
+		crit = withCursor(crit, keysetCriteria("time", cursorTime(c.Time), "hash", c.Key, c.Prev))
+		sort = keysetSort("time", "hash", c.Prev)
+	}
+	opts := []*options.FindOptions{
+		options.Find().SetHint(index),
+		options.Find().SetSort(sort),
+		options.Find().SetSkip(int64(pagination.Skip)),
+		options.Find().SetLimit(int64(pagination.Limit)),
+	}
+	cursor, err := m.wrapper.C(cTxs).Find(crit, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			m.logger.Warn("Error when close cursor", zap.Error(err))
+		}
+	}()
+	var txs []*types.Transaction
+	if err := cursor.All(ctx, &txs); err != nil {
+		return nil, 0, err
+	}
+	restoreKeysetOrder(pagination, txs)
+	return txs, uint64(total), nil
+}
+
+// blocksHeightRange resolve height range of filter, time range is narrowed to heights of first and last blocks in it
+func (m *mongoDB) blocksHeightRange(ctx context.Context, filter *types.BlocksFilter) (bson.M, error) {
+	heightRange := bson.M{}
+	from, to := filter.FromHeight, filter.ToHeight
+	if !filter.FromTime.IsZero() {
+		block, err := m.BlockByTime(ctx, filter.FromTime, false)
+		if err != nil {
+			return nil, err
+		}
+		if block.Height > from {
+			from = block.Height
+		}
+	}
+	if !filter.ToTime.IsZero() {
+		block, err := m.BlockByTime(ctx, filter.ToTime, true)
+		if err != nil {
+			return nil, err
+		}
+		if to == 0 || block.Height < to {
+			to = block.Height
+		}
+	}
+	if from > 0 {
+		heightRange["$gte"] = from
+	}
+	if to > 0 {
+		heightRange["$lte"] = to
+	}
+	return heightRange, nil
+}
+
+// BlocksByFilter return a page of filtered blocks and their total which is capped at filterCountLimit
+func (m *mongoDB) BlocksByFilter(ctx context.Context, filter *types.BlocksFilter) ([]*types.Block, uint64, error) {
+	var (
+		crit   = bson.M{}
+		fields []string
+	)
+	if filter.Proposer != "" {
+		crit["proposerAddress"] = filter.Proposer
+		fields = append(fields, "proposer")
+	}
+	index, err := filterIndex(blocksFilterIndexes, fields)
+	if err != nil {
+		return nil, 0, err
+	}
+	heightRange, err := m.blocksHeightRange(ctx, filter)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+	if len(heightRange) > 0 {
+		crit["height"] = heightRange
+	}
+	total, err := m.wrapper.C(cBlocks).Count(crit, options.Count().SetHint(index).SetLimit(filterCountLimit))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pagination := filter.Pagination
+	opts := []*options.FindOptions{
+		options.Find().SetHint(index),
+		options.Find().SetProjection(bson.M{"txs": 0, "receipts": 0}),
+		options.Find().SetSort(bson.M{"height": -1}),
+		options.Find().SetSkip(int64(pagination.Skip)),
+		options.Find().SetLimit(int64(pagination.Limit)),
+	}
+	if c := pagination.Cursor; c != nil {
+		crit = withCursor(crit, keysetCriteria("height", c.Height, "", nil, c.Prev))
+		opts = append(opts, options.Find().SetSort(keysetSort("height", "", c.Prev)))
+	}
+	cursor, err := m.wrapper.C(cBlocks).Find(crit, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			m.logger.Warn("Error when close cursor", zap.Error(err))
+		}
+	}()
+	var blocks []*types.Block
+	if err := cursor.All(ctx, &blocks); err != nil {
+		return nil, 0, err
+	}
+	restoreKeysetOrder(pagination, blocks)
+	return blocks, uint64(total), nil
+}
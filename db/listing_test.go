@@ -0,0 +1,96 @@
+// Package db
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_txsFilterCriteria(t *testing.T) {
+	success := types.TxStatusSuccess
+	to := "0x14191195F9BB6e54465a341CeC6cce4491599ccC"
+	tests := []struct {
+		name   string
+		filter *types.TxsFilter
+		crit   bson.M
+		index  bson.D
+		err    error
+	}{
+		{
+			name:   "no filter",
+			filter: &types.TxsFilter{},
+			crit:   bson.M{},
+			index:  txsFilterIndexes[""],
+		},
+		{
+			name:   "status of contract",
+			filter: &types.TxsFilter{Status: &success, To: to},
+			crit:   bson.M{"status": success, "to": to},
+			index:  bson.D{{Key: "to", Value: 1}, {Key: "status", Value: 1}, {Key: "time", Value: -1}, {Key: "hash", Value: -1}},
+		},
+		{
+			name:   "selector",
+			filter: &types.TxsFilter{Method: "0xA9059CBB"},
+			crit:   bson.M{"methodID": "0xa9059cbb"},
+			index:  txsFilterIndexes["selector"],
+		},
+		{
+			name:   "method name of contract",
+			filter: &types.TxsFilter{Method: "transfer", To: to},
+			crit:   bson.M{txsMethodNameField: "transfer", "to": to},
+			index:  txsFilterIndexes["method,to"],
+		},
+		{
+			name:   "value range",
+			filter: &types.TxsFilter{MinValue: 10, MaxValue: 100},
+			crit:   bson.M{"valueFloat": bson.M{"$gte": float64(10), "$lte": float64(100)}},
+			index:  txsFilterIndexes["value"],
+		},
+		{
+			name:   "type of contract",
+			filter: &types.TxsFilter{Type: types.TxTypeStake, To: to},
+			crit:   bson.M{"type": types.TxTypeStake, "to": to},
+			index:  bson.D{{Key: "to", Value: 1}, {Key: "type", Value: 1}, {Key: "time", Value: -1}, {Key: "hash", Value: -1}},
+		},
+		{
+			name:   "unindexed",
+			filter: &types.TxsFilter{Status: &success, Method: "transfer"},
+			err:    ErrUnindexedFilter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crit, index, err := txsFilterCriteria(tt.filter)
+			assert.Equal(t, tt.err, err)
+			if tt.err != nil {
+				return
+			}
+			assert.Equal(t, tt.crit, crit)
+			assert.Equal(t, tt.index, index)
+		})
+	}
+}
+
+func Test_setTxFilterFields(t *testing.T) {
+	tx := &types.Transaction{Value: "1500000000000000000", InputData: "0xA9059CBB000000000000000000000000"}
+	setTxFilterFields(tx)
+	assert.Equal(t, 1.5, tx.ValueFloat)
+	assert.Equal(t, "0xa9059cbb", tx.MethodID)
+
+	tx = &types.Transaction{InputData: "0x"}
+	setTxFilterFields(tx)
+	assert.Equal(t, float64(0), tx.ValueFloat)
+	assert.Equal(t, "", tx.MethodID)
+}
+
+func Test_txBackfillFields(t *testing.T) {
+	fields := txBackfillFields(&types.Transaction{Value: "2000000000000000000", InputData: "0xA9059CBB00", Type: types.TxTypeTokenTransfer})
+	assert.Equal(t, bson.M{"valueFloat": float64(2), "methodID": "0xa9059cbb", "type": types.TxTypeTokenTransfer}, fields)
+
+	fields = txBackfillFields(&types.Transaction{Value: "0", InputData: "0x"})
+	assert.Equal(t, bson.M{"valueFloat": float64(0)}, fields)
+}
@@ -100,10 +100,19 @@ func createIndexes(dbClient *mongoDB) error {
 		// Add index in `from` and `to` fields to improve get txs of address, considering if memory is increasing rapidly
 		{c: cTxs, model: []mongo.IndexModel{{Keys: bson.D{{Key: "from", Value: 1}, {Key: "time", Value: -1}}, Options: options.Index().SetSparse(true)}}},
 		{c: cTxs, model: []mongo.IndexModel{{Keys: bson.D{{Key: "to", Value: 1}, {Key: "time", Value: -1}}, Options: options.Index().SetSparse(true)}}},
+		// txs of address by type, to side is one of txs listing filter indexes
+		{c: cTxs, model: []mongo.IndexModel{{Keys: bson.D{{Key: "from", Value: 1}, {Key: "type", Value: 1}, {Key: "time", Value: -1}, {Key: "hash", Value: -1}}, Options: options.Index().SetSparse(true)}}},
 		{c: cTxs, model: []mongo.IndexModel{{Keys: bson.M{"time": -1}, Options: options.Index().SetSparse(true)}}},
-		// Add index to improve querying blocks by proposer, hash and height
+		// txs pages are sorted by time then hash, so cursor pages are range scans of these. To side is one of txs
+		// listing filter indexes
+		{c: cTxs, model: []mongo.IndexModel{{Keys: txsPageIndex, Options: options.Index().SetSparse(true)}}},
+		{c: cTxs, model: []mongo.IndexModel{{Keys: bson.D{{Key: "from", Value: 1}, {Key: "time", Value: -1}, {Key: "hash", Value: -1}}, Options: options.Index().SetSparse(true)}}},
+		// Add index to improve querying blocks by proposer, hash, height and time
 		{c: cBlocks, model: []mongo.IndexModel{{Keys: bson.M{"height": -1}, Options: options.Index().SetUnique(true).SetSparse(true)}}},
 		{c: cBlocks, model: []mongo.IndexModel{{Keys: bson.M{"hash": 1}, Options: options.Index().SetUnique(true).SetSparse(true)}}},
+		{c: cBlocks, model: []mongo.IndexModel{{Keys: bson.M{"time": -1}, Options: options.Index().SetSparse(true)}}},
+		// blocks left for stats to count, the field is empty once they are
+		{c: cBlocks, model: []mongo.IndexModel{{Keys: bson.M{"statsPending": 1}, Options: options.Index().SetSparse(true)}}},
 		{c: cBlocks, model: []mongo.IndexModel{{Keys: bson.D{{Key: "proposerAddress", Value: 1}, {Key: "time", Value: -1}}, Options: options.Index().SetSparse(true)}}},
 		// indexing addresses collection
 		{c: cAddresses, model: []mongo.IndexModel{{Keys: bson.M{"address": 1}, Options: options.Index().SetUnique(true).SetSparse(true)}}},
@@ -112,6 +121,7 @@ func createIndexes(dbClient *mongoDB) error {
 		{c: cAddresses, model: []mongo.IndexModel{{Keys: bson.M{"balanceFloat": -1}, Options: options.Index().SetSparse(true)}}},
 		{c: cAddresses, model: []mongo.IndexModel{{Keys: bson.M{"tokenName": 1}, Options: options.Index().SetSparse(true)}}},
 		{c: cAddresses, model: []mongo.IndexModel{{Keys: bson.M{"tokenSymbol": 1}, Options: options.Index().SetSparse(true)}}},
+		{c: cAddresses, model: []mongo.IndexModel{{Keys: bson.M{"searchNames": 1}, Options: options.Index().SetSparse(true)}}},
 		// indexing proposal collection
 		{c: cProposal, model: []mongo.IndexModel{{Keys: bson.M{"id": -1}, Options: options.Index().SetUnique(true).SetSparse(true)}}},
 		// indexing validator collection
@@ -119,6 +129,7 @@ func createIndexes(dbClient *mongoDB) error {
 		{c: cValidators, model: []mongo.IndexModel{{Keys: bson.M{"name": 1}, Options: options.Index().SetSparse(true)}}},
 		// indexing contract & ABI collection
 		{c: cContract, model: []mongo.IndexModel{{Keys: bson.M{"name": 1}, Options: options.Index().SetSparse(true)}}},
+		{c: cContract, model: []mongo.IndexModel{{Keys: bson.M{"searchNames": 1}, Options: options.Index().SetSparse(true)}}},
 		{c: cContract, model: []mongo.IndexModel{{Keys: bson.M{"type": 1}, Options: options.Index().SetSparse(true)}}},
 		{c: cContract, model: []mongo.IndexModel{{Keys: bson.M{"address": 1}, Options: options.Index().SetUnique(true).SetSparse(true)}}},
 		{c: cABI, model: []mongo.IndexModel{{Keys: bson.M{"type": 1}, Options: options.Index().SetUnique(true).SetSparse(true)}}},
@@ -129,6 +140,37 @@ func createIndexes(dbClient *mongoDB) error {
 		// indexing internal txs collection
 		{c: cInternalTxs, model: dbClient.createInternalTxsCollectionIndexes()},
 		{c: cDelegator, model: createDelegatorCollectionIndexes()},
+		// indexing slash events collection
+		{c: cSlashEvents, model: dbClient.createSlashEventsCollectionIndexes()},
+		// indexing reward snapshots collection
+		{c: cRewardSnapshots, model: dbClient.createRewardSnapshotsCollectionIndexes()},
+		// indexing validator history collection
+		{c: cValidatorHistory, model: dbClient.createValidatorHistoryCollectionIndexes()},
+		// indexing block production stats collection
+		{c: cProductionStats, model: dbClient.createProductionStatsCollectionIndexes()},
+		// indexing validator set changes collection
+		{c: cValidatorSets, model: dbClient.createValidatorSetsCollectionIndexes()},
+		// indexing unbonding entries collection
+		{c: cUnbondingEntries, model: dbClient.createUnbondingEntriesCollectionIndexes()},
+		// indexing webhook watches and deliveries collections
+		{c: cWebhookWatches, model: dbClient.createWebhookWatchesCollectionIndexes()},
+		{c: cWebhookDeliveries, model: dbClient.createWebhookDeliveriesCollectionIndexes()},
+		// indexing price history collection
+		{c: cPriceHistory, model: dbClient.createPriceHistoryCollectionIndexes()},
+		// indexing API keys and usage collections
+		{c: cAPIKeys, model: dbClient.createAPIKeysCollectionIndexes()},
+		{c: cAPIKeyUsage, model: dbClient.createAPIKeyUsageCollectionIndexes()},
+		// indexing admin audit log collection
+		{c: cAuditLogs, model: dbClient.createAuditLogsCollectionIndexes()},
+		// indexing network activity charts collections
+		{c: cNetworkStats, model: dbClient.createNetworkStatsCollectionIndexes()},
+		{c: cNetworkStatsAddresses, model: dbClient.createNetworkStatsAddressesCollectionIndexes()},
+		// indexing rich list and its daily snapshots
+		{c: cRichList, model: dbClient.createRichListCollectionIndexes()},
+		{c: cRichListSnapshots, model: dbClient.createRichListSnapshotsCollectionIndexes()},
+		// indexing filters of txs and blocks listings
+		{c: cTxs, model: dbClient.createTxsFilterIndexes()},
+		{c: cBlocks, model: dbClient.createBlocksFilterIndexes()},
 	}
 	for _, cIdx := range indexes {
 		if err := dbClient.wrapper.C(cIdx.c).EnsureIndex(cIdx.model); err != nil {
@@ -255,9 +297,14 @@ func (m *mongoDB) Blocks(ctx context.Context, pagination *types.Pagination) ([]*
 		options.Find().SetSkip(int64(pagination.Skip)),
 		options.Find().SetLimit(int64(pagination.Limit)),
 	}
+	crit := bson.M{}
+	if c := pagination.Cursor; c != nil {
+		crit = keysetCriteria("height", c.Height, "", nil, c.Prev)
+		opts = append(opts, options.Find().SetSort(keysetSort("height", "", c.Prev)))
+	}
 
 	cursor, err := m.wrapper.C(cBlocks).
-		Find(bson.D{}, opts...)
+		Find(crit, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get latest blocks: %v", err)
 	}
@@ -274,6 +321,7 @@ func (m *mongoDB) Blocks(ctx context.Context, pagination *types.Pagination) ([]*
 		}
 		blocks = append(blocks, block)
 	}
+	restoreKeysetOrder(pagination, blocks)
 
 	return blocks, nil
 }
@@ -299,6 +347,41 @@ func (m *mongoDB) BlockByHash(ctx context.Context, blockHash string) (*types.Blo
 	return &block, nil
 }
 
+// BlocksByHeights get multiple blocks without txs in one query, for batch loading
+func (m *mongoDB) BlocksByHeights(ctx context.Context, heights []uint64) ([]*types.Block, error) {
+	var blocks []*types.Block
+	cursor, err := m.wrapper.C(cBlocks).Find(bson.M{"height": bson.M{"$in": heights}},
+		options.Find().SetProjection(bson.M{"txs": 0, "receipts": 0}),
+		options.Find().SetHint(bson.M{"height": -1}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocks: %v", err)
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &blocks); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// BlockByTime return latest block mined at or before t, or earliest block mined at or after t
+func (m *mongoDB) BlockByTime(ctx context.Context, t time.Time, before bool) (*types.Block, error) {
+	var (
+		block types.Block
+		crit  = bson.M{"time": bson.M{"$gte": t}}
+		sort  = bson.M{"time": 1}
+	)
+	if before {
+		crit = bson.M{"time": bson.M{"$lte": t}}
+		sort = bson.M{"time": -1}
+	}
+	if err := m.wrapper.C(cBlocks).FindOne(crit,
+		options.FindOne().SetProjection(bson.M{"txs": 0, "receipts": 0}),
+		options.FindOne().SetSort(sort)).Decode(&block); err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
 func (m *mongoDB) IsBlockExist(ctx context.Context, blockHeight uint64) (bool, error) {
 	var dbBlock types.Block
 	err := m.wrapper.C(cBlocks).FindOne(bson.M{"height": blockHeight}, options.FindOne().SetProjection(bson.M{"txs": 0, "receipts": 0})).Decode(&dbBlock)
@@ -348,6 +431,43 @@ func (m *mongoDB) DeleteLatestBlock(ctx context.Context) (uint64, error) {
 	return blocks[0].Height, nil
 }
 
+// MarkBlockCounted remove stats from pending stats of block, after block is added to them
+func (m *mongoDB) MarkBlockCounted(ctx context.Context, blockHeight uint64, stats string) error {
+	_, err := m.wrapper.C(cBlocks).Update(bson.M{"height": blockHeight}, bson.M{"$pull": bson.M{"statsPending": stats}})
+	return err
+}
+
+// ClaimBlockStats mark block counted in stats, and return false if it was already. Only one of importers and
+// rebuild which count the same block at once claims it
+func (m *mongoDB) ClaimBlockStats(ctx context.Context, blockHeight uint64, stats string) (bool, error) {
+	result, err := m.wrapper.C(cBlocks).Update(bson.M{"height": blockHeight, "statsPending": stats}, bson.M{"$pull": bson.M{"statsPending": stats}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// ReleaseBlockStats mark claimed block pending again, when counting it failed
+func (m *mongoDB) ReleaseBlockStats(ctx context.Context, blockHeight uint64, stats string) error {
+	_, err := m.wrapper.C(cBlocks).Update(bson.M{"height": blockHeight}, bson.M{"$addToSet": bson.M{"statsPending": stats}})
+	return err
+}
+
+// BlocksPendingStats return blocks, without txs, which are not counted in stats yet
+func (m *mongoDB) BlocksPendingStats(ctx context.Context, stats string) ([]*types.Block, error) {
+	cursor, err := m.wrapper.C(cBlocks).Find(bson.M{"statsPending": stats},
+		options.Find().SetProjection(bson.M{"txs": 0, "receipts": 0}).SetSort(bson.M{"height": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var blocks []*types.Block
+	if err := cursor.All(ctx, &blocks); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
 func (m *mongoDB) DeleteBlockByHeight(ctx context.Context, blockHeight uint64) error {
 	if _, err := m.wrapper.C(cBlocks).RemoveAll(bson.M{"height": blockHeight}); err != nil {
 		m.logger.Warn("cannot remove old latest block", zap.Error(err), zap.Uint64("latest block height", blockHeight))
@@ -499,19 +619,41 @@ func (m *mongoDB) TxsByBlockHeight(ctx context.Context, blockHeight uint64, pagi
 	return txs, uint64(total), nil
 }
 
-// TxsByAddress return txs match input address in FROM/TO field
-func (m *mongoDB) TxsByAddress(ctx context.Context, address string, pagination *types.Pagination) ([]*types.Transaction, uint64, error) {
+// TxsInBlockRange return all txs of blocks from fromHeight to toHeight, for rebuilding aggregates
+func (m *mongoDB) TxsInBlockRange(ctx context.Context, fromHeight, toHeight uint64) ([]*types.Transaction, error) {
+	cursor, err := m.wrapper.C(cTxs).Find(bson.M{"blockNumber": bson.M{"$gte": fromHeight, "$lte": toHeight}},
+		options.Find().SetHint(bson.M{"blockNumber": -1}),
+		options.Find().SetProjection(bson.M{"logs": 0}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
 	var txs []*types.Transaction
-	opts := []*options.FindOptions{
-		options.Find().SetHint(bson.D{{Key: "from", Value: 1}, {Key: "time", Value: -1}}),
-		options.Find().SetHint(bson.D{{Key: "to", Value: 1}, {Key: "time", Value: -1}}),
-		options.Find().SetSort(bson.M{"time": -1}),
+	if err := cursor.All(ctx, &txs); err != nil {
+		return nil, err
 	}
+	return txs, nil
+}
+
+// TxsByAddress return txs match input address in FROM/TO field, txType is optional
+func (m *mongoDB) TxsByAddress(ctx context.Context, address, txType string, pagination *types.Pagination) ([]*types.Transaction, uint64, error) {
+	var txs []*types.Transaction
+	// each side of $or picks its own address index, which are sorted as pages are so they are merged without sorting
+	opts := []*options.FindOptions{options.Find().SetSort(keysetSort("time", "hash", false))}
+	crit := bson.M{"$or": []bson.M{{"from": address}, {"to": address}}}
+	if txType != "" {
+		crit = bson.M{"$or": []bson.M{{"from": address, "type": txType}, {"to": address, "type": txType}}}
+	}
+	totalCrit := crit
 	if pagination != nil {
 		opts = append(opts, options.Find().SetSkip(int64(pagination.Skip)), options.Find().SetLimit(int64(pagination.Limit)))
+		if c := pagination.Cursor; c != nil {
+			crit = bson.M{"$and": []bson.M{crit, keysetCriteria("time", cursorTime(c.Time), "hash", c.Key, c.Prev)}}
+			opts = append(opts, options.Find().SetSort(keysetSort("time", "hash", c.Prev)))
+		}
 	}
 	cursor, err := m.wrapper.C(cTxs).
-		Find(bson.M{"$or": []bson.M{{"from": address}, {"to": address}}}, opts...)
+		Find(crit, opts...)
 	defer func() {
 		err = cursor.Close(ctx)
 		if err != nil {
@@ -531,7 +673,8 @@ func (m *mongoDB) TxsByAddress(ctx context.Context, address string, pagination *
 		}
 		txs = append(txs, tx)
 	}
-	total, err := m.wrapper.C(cTxs).Count(bson.M{"$or": []bson.M{{"from": address}, {"to": address}}}, nil)
+	restoreKeysetOrder(pagination, txs)
+	total, err := m.wrapper.C(cTxs).Count(totalCrit, nil)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -539,6 +682,43 @@ func (m *mongoDB) TxsByAddress(ctx context.Context, address string, pagination *
 	return txs, uint64(total), nil
 }
 
+// TxsByAddressInRange return txs of address in block range, sorted by time
+func (m *mongoDB) TxsByAddressInRange(ctx context.Context, filter *types.TxsByAddressFilter) ([]*types.Transaction, error) {
+	var txs []*types.Transaction
+	sortDirection := -1
+	if filter.Ascending {
+		sortDirection = 1
+	}
+	opts := []*options.FindOptions{
+		options.Find().SetHint(bson.D{{Key: "from", Value: 1}, {Key: "time", Value: -1}}),
+		options.Find().SetHint(bson.D{{Key: "to", Value: 1}, {Key: "time", Value: -1}}),
+		options.Find().SetSort(bson.M{"time": sortDirection}),
+	}
+	if filter.Pagination != nil {
+		opts = append(opts, options.Find().SetSkip(int64(filter.Pagination.Skip)), options.Find().SetLimit(int64(filter.Pagination.Limit)))
+	}
+	crit := bson.M{"$or": []bson.M{{"from": filter.Address}, {"to": filter.Address}}}
+	blockRange := bson.M{}
+	if filter.FromBlock > 0 {
+		blockRange["$gte"] = filter.FromBlock
+	}
+	if filter.ToBlock > 0 {
+		blockRange["$lte"] = filter.ToBlock
+	}
+	if len(blockRange) > 0 {
+		crit["blockNumber"] = blockRange
+	}
+	cursor, err := m.wrapper.C(cTxs).Find(crit, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get txs of address: %v", err)
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &txs); err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
+
 func (m *mongoDB) TxByHash(ctx context.Context, txHash string) (*types.Transaction, error) {
 	var tx *types.Transaction
 	err := m.wrapper.C(cTxs).FindOne(bson.M{"hash": txHash}, options.FindOne().SetHint(bson.M{"hash": -1})).Decode(&tx)
@@ -551,12 +731,26 @@ func (m *mongoDB) TxByHash(ctx context.Context, txHash string) (*types.Transacti
 	return tx, nil
 }
 
+func (m *mongoDB) TxsByHashes(ctx context.Context, txHashes []string) ([]*types.Transaction, error) {
+	var txs []*types.Transaction
+	cursor, err := m.wrapper.C(cTxs).Find(bson.M{"hash": bson.M{"$in": txHashes}}, options.Find().SetHint(bson.M{"hash": -1}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get txs: %v", err)
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &txs); err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
+
 // InsertTxs create bulk writer
 func (m *mongoDB) InsertTxs(ctx context.Context, txs []*types.Transaction) error {
 	var (
 		txsBulkWriter []mongo.WriteModel
 	)
 	for _, tx := range txs {
+		setTxFilterFields(tx)
 		txModel := mongo.NewInsertOneModel().SetDocument(tx)
 		txsBulkWriter = append(txsBulkWriter, txModel)
 	}
@@ -572,6 +766,7 @@ func (m *mongoDB) InsertTxs(ctx context.Context, txs []*types.Transaction) error
 func (m *mongoDB) UpsertTxs(ctx context.Context, txs []*types.Transaction) error {
 	var txsBulkWriter []mongo.WriteModel
 	for _, tx := range txs {
+		setTxFilterFields(tx)
 		txModel := mongo.NewInsertOneModel().SetDocument(tx)
 		txsBulkWriter = append(txsBulkWriter, txModel)
 	}
@@ -597,14 +792,19 @@ func (m *mongoDB) InsertListTxByAddress(ctx context.Context, list []*types.Trans
 
 func (m *mongoDB) LatestTxs(ctx context.Context, pagination *types.Pagination) ([]*types.Transaction, error) {
 	opts := []*options.FindOptions{
-		options.Find().SetHint(bson.M{"time": -1}),
-		options.Find().SetSort(bson.M{"time": -1}),
+		options.Find().SetHint(txsPageIndex),
+		options.Find().SetSort(keysetSort("time", "hash", false)),
 		options.Find().SetSkip(int64(pagination.Skip)),
 		options.Find().SetLimit(int64(pagination.Limit)),
 	}
+	crit := bson.M{}
+	if c := pagination.Cursor; c != nil {
+		crit = keysetCriteria("time", cursorTime(c.Time), "hash", c.Key, c.Prev)
+		opts = append(opts, options.Find().SetSort(keysetSort("time", "hash", c.Prev)))
+	}
 
 	var txs []*types.Transaction
-	cursor, err := m.wrapper.C(cTxs).Find(bson.D{}, opts...)
+	cursor, err := m.wrapper.C(cTxs).Find(crit, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -621,6 +821,7 @@ func (m *mongoDB) LatestTxs(ctx context.Context, pagination *types.Pagination) (
 		}
 		txs = append(txs, tx)
 	}
+	restoreKeysetOrder(pagination, txs)
 
 	return txs, nil
 }
@@ -638,11 +839,26 @@ func (m *mongoDB) AddressByHash(ctx context.Context, address string) (*types.Add
 	return &c, nil
 }
 
+// AddressesByHashes get multiple addresses in one query, for batch loading
+func (m *mongoDB) AddressesByHashes(ctx context.Context, addressHashes []string) ([]*types.Address, error) {
+	var addrs []*types.Address
+	cursor, err := m.wrapper.C(cAddresses).Find(bson.M{"address": bson.M{"$in": addressHashes}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get addresses: %v", err)
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &addrs); err != nil {
+		return nil, err
+	}
+	return addrs, nil
+}
+
 func (m *mongoDB) InsertAddress(ctx context.Context, address *types.Address) error {
 	if address.Address != "0x" {
 		address.Address = common.HexToAddress(address.Address).String()
 	}
 	address.BalanceFloat = utils.BalanceToFloat(address.BalanceString)
+	setAddressSearchNames(address)
 	_, err := m.wrapper.C(cAddresses).Insert(address)
 	if err != nil {
 		return err
@@ -659,6 +875,7 @@ func (m *mongoDB) UpdateAddresses(ctx context.Context, addresses []*types.Addres
 	for _, info := range addresses {
 		info.Address = common.HexToAddress(info.Address).String()
 		info.BalanceFloat = utils.BalanceToFloat(info.BalanceString)
+		setAddressSearchNames(info)
 		updateAddressOperations = append(updateAddressOperations,
 			mongo.NewUpdateOneModel().SetUpsert(true).SetFilter(bson.M{"address": info.Address}).SetUpdate(bson.M{"$set": info}))
 	}
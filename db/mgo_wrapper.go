@@ -125,6 +125,11 @@ func (w *KaiMgo) BulkUpsert(models []mongo.WriteModel,
 	return w.col.BulkWrite(context.Background(), models, opts...)
 }
 
+func (w *KaiMgo) FindOneAndUpdate(filter interface{}, update interface{},
+	opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+	return w.col.FindOneAndUpdate(context.Background(), filter, update, opts...)
+}
+
 func (w *KaiMgo) Distinct(field string, filter interface{}, opts ...*options.DistinctOptions) ([]interface{}, error) {
 	return w.col.Distinct(context.Background(), field, filter, opts...)
 }
@@ -157,6 +162,20 @@ func (w *KaiMgo) FindOneSetSort(data string) *options.FindOneOptions {
 
 }
 
+func (w *KaiMgo) Drop(ctx context.Context) error {
+	return w.col.Drop(ctx)
+}
+
+// Rename replace collection to with this one in one step, to is dropped if it exists
+func (w *KaiMgo) Rename(ctx context.Context, to string) error {
+	cmd := bson.D{
+		{Key: "renameCollection", Value: w.DB.Name() + "." + w.col.Name()},
+		{Key: "to", Value: w.DB.Name() + "." + to},
+		{Key: "dropTarget", Value: true},
+	}
+	return w.DB.Client().Database("admin").RunCommand(ctx, cmd).Err()
+}
+
 func (w *KaiMgo) DropDatabase(ctx context.Context) error {
 	if err := w.DB.Drop(ctx); err != nil {
 		return err
@@ -0,0 +1,30 @@
+// Package db
+package db
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var cMigrations = "Migrations"
+
+// IMigrations record one-off data migrations which are done, so services don't scan for them on every start
+type IMigrations interface {
+	MigrationDone(ctx context.Context, name string) (bool, error)
+	MarkMigrationDone(ctx context.Context, name string) error
+}
+
+func (m *mongoDB) MigrationDone(ctx context.Context, name string) (bool, error) {
+	count, err := m.wrapper.C(cMigrations).Count(bson.M{"_id": name})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (m *mongoDB) MarkMigrationDone(ctx context.Context, name string) error {
+	_, err := m.wrapper.C(cMigrations).Upsert(bson.M{"_id": name}, bson.M{"doneAt": time.Now()})
+	return err
+}
@@ -0,0 +1,455 @@
+// Package db
+package db
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+	"github.com/kardiachain/kardia-explorer-backend/utils"
+)
+
+var (
+	cNetworkStats          = "NetworkStats"
+	cNetworkStatsAddresses = "NetworkStatsAddresses"
+	// stats are rebuilt into these and swapped in when done, so charts keep being served meanwhile
+	cNetworkStatsRebuild          = "NetworkStatsRebuild"
+	cNetworkStatsAddressesRebuild = "NetworkStatsAddressesRebuild"
+	cNetworkStatsState            = "NetworkStatsState"
+)
+
+const (
+	// addresses seen in a bucket are kept a while after it ends, blocks imported late still count them once
+	networkAddressesRetention = 48 * time.Hour
+	// bucket type of first seen addresses, they are kept forever to count new addresses
+	networkBucketAll = "all"
+
+	duplicateKeyCode = 11000
+
+	networkStatsRebuildID = "rebuild"
+	// importers count blocks again if rebuild hasn't reported progress for this long, e.g. it crashed
+	networkStatsRebuildStale = 10 * time.Minute
+)
+
+// networkStatsCollections are buckets and seen addresses which are updated together
+type networkStatsCollections struct {
+	stats     string
+	addresses string
+}
+
+var (
+	liveNetworkStats    = networkStatsCollections{stats: cNetworkStats, addresses: cNetworkStatsAddresses}
+	rebuiltNetworkStats = networkStatsCollections{stats: cNetworkStatsRebuild, addresses: cNetworkStatsAddressesRebuild}
+)
+
+var networkBucketTypes = []string{types.NetworkBucketDay, types.NetworkBucketHour}
+
+type INetworkStats interface {
+	createNetworkStatsCollectionIndexes() []mongo.IndexModel
+	createNetworkStatsAddressesCollectionIndexes() []mongo.IndexModel
+	IncNetworkStats(ctx context.Context, blocks []*types.Block) error
+	RevertNetworkStats(ctx context.Context, block *types.Block) error
+	NetworkStats(ctx context.Context, filter *types.NetworkStatsFilter) ([]*types.NetworkStats, error)
+
+	StartNetworkStatsRebuild(ctx context.Context) error
+	NetworkStatsRebuilding(ctx context.Context) (bool, error)
+	IncRebuiltNetworkStats(ctx context.Context, blocks []*types.Block) error
+	FinishNetworkStatsRebuild(ctx context.Context, latest uint64) error
+	CancelNetworkStatsRebuild(ctx context.Context) error
+}
+
+func (m *mongoDB) createNetworkStatsCollectionIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.D{{Key: "bucketType", Value: 1}, {Key: "bucket", Value: -1}}, Options: options.Index().SetUnique(true).SetSparse(true)},
+	}
+}
+
+func (m *mongoDB) createNetworkStatsAddressesCollectionIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.D{{Key: "bucketType", Value: 1}, {Key: "bucket", Value: 1}, {Key: "address", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.M{"expireAt": 1}, Options: options.Index().SetExpireAfterSeconds(0).SetSparse(true)},
+	}
+}
+
+// networkStatsDelta is what a batch of blocks adds to a bucket
+type networkStatsDelta struct {
+	bucketType string
+	bucket     int64
+	blocks     int64
+	txs        int64
+	gasUsed    int64
+	kai        float64
+	fromHeight uint64
+	toHeight   uint64
+	firstTime  time.Time
+	lastTime   time.Time
+	addresses  map[string]int64 // number of blocks each address is seen in
+	active     int64
+	new        int64
+}
+
+// networkFirstSeen is the earliest block an address is seen in
+type networkFirstSeen struct {
+	time   time.Time
+	height uint64
+}
+
+func networkBucket(bucketType string, t time.Time) int64 {
+	return t.UTC().Truncate(types.NetworkBucketDuration(bucketType)).Unix()
+}
+
+func txAddresses(tx *types.Transaction) []string {
+	var addrs []string
+	for _, addr := range []string{tx.From, tx.To, tx.ContractAddress} {
+		if addr != "" && addr != "0x" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// networkStatsDeltas group blocks with their txs into buckets, and return block each address is first seen in
+func networkStatsDeltas(blocks []*types.Block) (map[string]map[int64]*networkStatsDelta, map[string]networkFirstSeen) {
+	deltas := make(map[string]map[int64]*networkStatsDelta)
+	firstSeen := make(map[string]networkFirstSeen)
+	for _, block := range blocks {
+		var kai float64
+		seen := make(map[string]bool)
+		for _, tx := range block.Txs {
+			if tx.Status == types.TxStatusSuccess && tx.Value != "" {
+				kai += utils.BalanceToFloat(tx.Value)
+			}
+			for _, addr := range txAddresses(tx) {
+				seen[addr] = true
+				if first, ok := firstSeen[addr]; !ok || block.Time.Before(first.time) {
+					firstSeen[addr] = networkFirstSeen{time: block.Time, height: block.Height}
+				}
+			}
+		}
+		for _, bucketType := range networkBucketTypes {
+			if deltas[bucketType] == nil {
+				deltas[bucketType] = make(map[int64]*networkStatsDelta)
+			}
+			bucket := networkBucket(bucketType, block.Time)
+			d := deltas[bucketType][bucket]
+			if d == nil {
+				d = &networkStatsDelta{
+					bucketType: bucketType,
+					bucket:     bucket,
+					fromHeight: block.Height,
+					toHeight:   block.Height,
+					firstTime:  block.Time,
+					lastTime:   block.Time,
+					addresses:  make(map[string]int64),
+				}
+				deltas[bucketType][bucket] = d
+			}
+			d.blocks++
+			d.txs += int64(block.NumTxs)
+			d.gasUsed += int64(block.GasUsed)
+			d.kai += kai
+			if block.Height < d.fromHeight {
+				d.fromHeight, d.firstTime = block.Height, block.Time
+			}
+			if block.Height > d.toHeight {
+				d.toHeight, d.lastTime = block.Height, block.Time
+			}
+			for addr := range seen {
+				d.addresses[addr]++
+			}
+		}
+	}
+	return deltas, firstSeen
+}
+
+// onlyDuplicateKeys is true when all failed writes of bulk upsert are duplicate keys,
+// which means other importer has just inserted the same address
+func onlyDuplicateKeys(err error) bool {
+	bwe, ok := err.(mongo.BulkWriteException)
+	if !ok || bwe.WriteConcernError != nil {
+		return false
+	}
+	for _, we := range bwe.WriteErrors {
+		if we.Code != duplicateKeyCode {
+			return false
+		}
+	}
+	return true
+}
+
+// upsertNetworkAddresses insert addresses which are not seen yet, and return which models inserted a new one
+func (m *mongoDB) upsertNetworkAddresses(collection string, models []mongo.WriteModel) (map[int64]interface{}, error) {
+	if len(models) == 0 {
+		return nil, nil
+	}
+	result, err := m.wrapper.C(collection).BulkUpsert(models)
+	if err != nil && (result == nil || !onlyDuplicateKeys(err)) {
+		return nil, err
+	}
+	return result.UpsertedIDs, nil
+}
+
+// IncNetworkStats add blocks to their day and hour buckets, txs of blocks must be merged with receipts.
+// Active and new addresses are counted once by keeping addresses seen in each bucket
+func (m *mongoDB) IncNetworkStats(ctx context.Context, blocks []*types.Block) error {
+	return m.incNetworkStats(liveNetworkStats, blocks)
+}
+
+// IncRebuiltNetworkStats is IncNetworkStats into collections being rebuilt, it also tells importers that rebuild
+// is still running
+func (m *mongoDB) IncRebuiltNetworkStats(ctx context.Context, blocks []*types.Block) error {
+	if err := m.setNetworkStatsRebuilding(); err != nil {
+		return err
+	}
+	return m.incNetworkStats(rebuiltNetworkStats, blocks)
+}
+
+func (m *mongoDB) incNetworkStats(colls networkStatsCollections, blocks []*types.Block) error {
+	lgr := m.logger.With(zap.String("method", "IncNetworkStats"))
+	deltas, firstSeen := networkStatsDeltas(blocks)
+
+	var (
+		models  []mongo.WriteModel
+		byModel []*networkStatsDelta
+		now     = time.Now()
+	)
+	for _, bucketType := range networkBucketTypes {
+		for _, d := range deltas[bucketType] {
+			expireAt := time.Unix(d.bucket, 0).Add(types.NetworkBucketDuration(bucketType))
+			if expireAt.Before(now) {
+				expireAt = now
+			}
+			expireAt = expireAt.Add(networkAddressesRetention)
+			for addr, refs := range d.addresses {
+				models = append(models, mongo.NewUpdateOneModel().SetUpsert(true).
+					SetFilter(bson.M{"bucketType": bucketType, "bucket": d.bucket, "address": addr}).
+					SetUpdate(bson.M{"$setOnInsert": bson.M{"expireAt": expireAt}, "$inc": bson.M{"refs": refs}}))
+				byModel = append(byModel, d)
+			}
+		}
+	}
+	upserted, err := m.upsertNetworkAddresses(colls.addresses, models)
+	if err != nil {
+		lgr.Warn("cannot update active addresses", zap.Error(err))
+		return err
+	}
+	for i := range upserted {
+		byModel[i].active++
+	}
+
+	models = nil
+	var firstSeenAt []time.Time
+	for addr, first := range firstSeen {
+		models = append(models, mongo.NewUpdateOneModel().SetUpsert(true).
+			SetFilter(bson.M{"bucketType": networkBucketAll, "bucket": 0, "address": addr}).
+			SetUpdate(bson.M{"$setOnInsert": bson.M{"firstSeen": first.time, "height": first.height}}))
+		firstSeenAt = append(firstSeenAt, first.time)
+	}
+	upserted, err = m.upsertNetworkAddresses(colls.addresses, models)
+	if err != nil {
+		lgr.Warn("cannot update new addresses", zap.Error(err))
+		return err
+	}
+	for i := range upserted {
+		for _, bucketType := range networkBucketTypes {
+			if d := deltas[bucketType][networkBucket(bucketType, firstSeenAt[i])]; d != nil {
+				d.new++
+			}
+		}
+	}
+
+	models = nil
+	for _, bucketType := range networkBucketTypes {
+		for _, d := range deltas[bucketType] {
+			models = append(models, mongo.NewUpdateOneModel().SetUpsert(true).
+				SetFilter(bson.M{"bucketType": bucketType, "bucket": d.bucket}).
+				SetUpdate(bson.M{
+					"$inc": bson.M{
+						"blocks":          d.blocks,
+						"txs":             d.txs,
+						"gasUsed":         d.gasUsed,
+						"kaiTransferred":  d.kai,
+						"activeAddresses": d.active,
+						"newAddresses":    d.new,
+					},
+					"$min": bson.M{"fromHeight": d.fromHeight, "firstBlockTime": d.firstTime},
+					"$max": bson.M{"toHeight": d.toHeight, "lastBlockTime": d.lastTime},
+				}))
+		}
+	}
+	if len(models) == 0 {
+		return nil
+	}
+	if _, err := m.wrapper.C(colls.stats).BulkUpsert(models); err != nil {
+		lgr.Warn("cannot update network stats", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// RevertNetworkStats remove counters of block from its buckets, used before re-importing a block. Addresses
+// which no other block of a bucket is seen in aren't active in it anymore, and addresses first seen in block
+// aren't new anymore
+func (m *mongoDB) RevertNetworkStats(ctx context.Context, block *types.Block) error {
+	deltas, firstSeen := networkStatsDeltas([]*types.Block{block})
+	var firstSeenHere []string
+	for addr := range firstSeen {
+		firstSeenHere = append(firstSeenHere, addr)
+	}
+	var unseen int64
+	if len(firstSeenHere) > 0 {
+		// addresses seen before this version only have firstSeen, they stay seen
+		result, err := m.wrapper.C(cNetworkStatsAddresses).RemoveAll(bson.M{"bucketType": networkBucketAll, "bucket": 0,
+			"address": bson.M{"$in": firstSeenHere}, "height": block.Height})
+		if err != nil {
+			return err
+		}
+		unseen = result.DeletedCount
+	}
+
+	var models []mongo.WriteModel
+	for _, bucketType := range networkBucketTypes {
+		for _, d := range deltas[bucketType] {
+			inactive, err := m.revertNetworkAddresses(bucketType, d)
+			if err != nil {
+				return err
+			}
+			models = append(models, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"bucketType": bucketType, "bucket": d.bucket}).
+				SetUpdate(bson.M{"$inc": bson.M{
+					"blocks":          -d.blocks,
+					"txs":             -d.txs,
+					"gasUsed":         -d.gasUsed,
+					"kaiTransferred":  -d.kai,
+					"activeAddresses": -inactive,
+					"newAddresses":    -unseen, // all of them were first seen at time of block, which is in this bucket
+				}}))
+		}
+	}
+	if _, err := m.wrapper.C(cNetworkStats).BulkWrite(models); err != nil {
+		m.logger.Warn("cannot revert network stats", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (m *mongoDB) NetworkStats(ctx context.Context, filter *types.NetworkStatsFilter) ([]*types.NetworkStats, error) {
+	crit := bson.M{"bucketType": filter.BucketType}
+	bucketRange := bson.M{}
+	if filter.FromBucket > 0 {
+		bucketRange["$gte"] = filter.FromBucket
+	}
+	if filter.ToBucket > 0 {
+		bucketRange["$lte"] = filter.ToBucket
+	}
+	if len(bucketRange) > 0 {
+		crit["bucket"] = bucketRange
+	}
+	cursor, err := m.wrapper.C(cNetworkStats).Find(crit, options.Find().SetSort(bson.M{"bucket": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stats []*types.NetworkStats
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// revertNetworkAddresses remove a block from addresses seen in bucket of d, and return number of addresses
+// which are not seen in any block of bucket anymore
+func (m *mongoDB) revertNetworkAddresses(bucketType string, d *networkStatsDelta) (int64, error) {
+	if len(d.addresses) == 0 {
+		return 0, nil
+	}
+	addrs := make([]string, 0, len(d.addresses))
+	for addr := range d.addresses {
+		addrs = append(addrs, addr)
+	}
+	crit := bson.M{"bucketType": bucketType, "bucket": d.bucket, "address": bson.M{"$in": addrs}}
+	if _, err := m.wrapper.C(cNetworkStatsAddresses).UpdateMany(crit, bson.M{"$inc": bson.M{"refs": -1}}); err != nil {
+		return 0, err
+	}
+	// addresses seen before refs were counted go below zero, as if they were seen in this block only
+	crit["refs"] = bson.M{"$lte": 0}
+	result, err := m.wrapper.C(cNetworkStatsAddresses).RemoveAll(crit)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// StartNetworkStatsRebuild stop importers from counting blocks, they are left pending for rebuild, and empty
+// collections it's rebuilt into
+func (m *mongoDB) StartNetworkStatsRebuild(ctx context.Context) error {
+	if err := m.setNetworkStatsRebuilding(); err != nil {
+		return err
+	}
+	for _, c := range []struct {
+		name  string
+		model []mongo.IndexModel
+	}{
+		{name: cNetworkStatsRebuild, model: m.createNetworkStatsCollectionIndexes()},
+		{name: cNetworkStatsAddressesRebuild, model: m.createNetworkStatsAddressesCollectionIndexes()},
+	} {
+		if err := m.wrapper.C(c.name).Drop(ctx); err != nil {
+			return err
+		}
+		if err := m.wrapper.C(c.name).EnsureIndex(c.model); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mongoDB) setNetworkStatsRebuilding() error {
+	_, err := m.wrapper.C(cNetworkStatsState).Upsert(bson.M{"_id": networkStatsRebuildID}, bson.M{"updatedAt": time.Now()})
+	return err
+}
+
+// NetworkStatsRebuilding is true while a rebuild which is making progress is running
+func (m *mongoDB) NetworkStatsRebuilding(ctx context.Context) (bool, error) {
+	count, err := m.wrapper.C(cNetworkStatsState).Count(bson.M{
+		"_id":       networkStatsRebuildID,
+		"updatedAt": bson.M{"$gt": time.Now().Add(-networkStatsRebuildStale)},
+	})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// FinishNetworkStatsRebuild swap rebuilt collections in, and mark blocks up to latest as counted since they are
+// in rebuilt stats. Importers count blocks again after it
+func (m *mongoDB) FinishNetworkStatsRebuild(ctx context.Context, latest uint64) error {
+	if err := m.wrapper.C(cNetworkStatsRebuild).Rename(ctx, cNetworkStats); err != nil {
+		return err
+	}
+	if err := m.wrapper.C(cNetworkStatsAddressesRebuild).Rename(ctx, cNetworkStatsAddresses); err != nil {
+		return err
+	}
+	if _, err := m.wrapper.C(cBlocks).UpdateMany(bson.M{"height": bson.M{"$lte": latest}, "statsPending": types.BlockStatsNetwork},
+		bson.M{"$pull": bson.M{"statsPending": types.BlockStatsNetwork}}); err != nil {
+		return err
+	}
+	_, err := m.wrapper.C(cNetworkStatsState).Remove(bson.M{"_id": networkStatsRebuildID})
+	return err
+}
+
+// CancelNetworkStatsRebuild drop what is rebuilt so far and let importers count blocks again
+func (m *mongoDB) CancelNetworkStatsRebuild(ctx context.Context) error {
+	if _, err := m.wrapper.C(cNetworkStatsState).Remove(bson.M{"_id": networkStatsRebuildID}); err != nil {
+		return err
+	}
+	if err := m.wrapper.C(cNetworkStatsRebuild).Drop(ctx); err != nil {
+		return err
+	}
+	return m.wrapper.C(cNetworkStatsAddressesRebuild).Drop(ctx)
+}
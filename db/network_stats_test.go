@@ -0,0 +1,45 @@
+// Package db
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_networkStatsDeltas(t *testing.T) {
+	day := time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)
+	alice, bob, token := "0xA", "0xB", "0xC"
+	blocks := []*types.Block{
+		{Height: 11, Time: day.Add(90 * time.Minute), NumTxs: 1, GasUsed: 50, Txs: []*types.Transaction{
+			{From: bob, To: token, Status: types.TxStatusFailed, Value: "1000000000000000000"},
+		}},
+		{Height: 10, Time: day.Add(10 * time.Minute), NumTxs: 2, GasUsed: 100, Txs: []*types.Transaction{
+			{From: alice, To: bob, Status: types.TxStatusSuccess, Value: "2000000000000000000"},
+			{From: alice, ContractAddress: token, Status: types.TxStatusSuccess, Value: "0"},
+		}},
+	}
+	deltas, firstSeen := networkStatsDeltas(blocks)
+
+	d := deltas[types.NetworkBucketDay][day.Unix()]
+	assert.Equal(t, int64(2), d.blocks)
+	assert.Equal(t, int64(3), d.txs)
+	assert.Equal(t, int64(150), d.gasUsed)
+	assert.Equal(t, float64(2), d.kai)
+	assert.Equal(t, uint64(10), d.fromHeight)
+	assert.Equal(t, uint64(11), d.toHeight)
+	assert.Equal(t, day.Add(10*time.Minute), d.firstTime)
+	// alice is in two txs of block 10, but one block
+	assert.Equal(t, map[string]int64{alice: 1, bob: 2, token: 2}, d.addresses)
+
+	assert.Len(t, deltas[types.NetworkBucketHour], 2)
+	h := deltas[types.NetworkBucketHour][day.Add(time.Hour).Unix()]
+	assert.Equal(t, int64(1), h.blocks)
+	assert.Equal(t, map[string]int64{bob: 1, token: 1}, h.addresses)
+
+	first := networkFirstSeen{time: day.Add(10 * time.Minute), height: 10}
+	assert.Equal(t, map[string]networkFirstSeen{alice: first, bob: first, token: first}, firstSeen)
+}
@@ -0,0 +1,111 @@
+// Package db
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+var cPriceHistory = "PriceHistory"
+
+// priceHistoryResolution is how often a price point is kept, newer points in same period replace older one
+const priceHistoryResolution = time.Hour
+
+type IPrices interface {
+	createPriceHistoryCollectionIndexes() []mongo.IndexModel
+	InsertPricePoint(ctx context.Context, point *types.PricePoint) error
+	UpsertPricePoints(ctx context.Context, points []*types.PricePoint) error
+	PricePoints(ctx context.Context, symbol, currency string, from, to time.Time) ([]*types.PricePoint, error)
+	LatestPricePoint(ctx context.Context, symbol, currency string) (*types.PricePoint, error)
+}
+
+func (m *mongoDB) createPriceHistoryCollectionIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.D{{Key: "symbol", Value: 1}, {Key: "currency", Value: 1}, {Key: "time", Value: 1}}, Options: options.Index().SetUnique(true).SetSparse(true)},
+	}
+}
+
+func (m *mongoDB) InsertPricePoint(ctx context.Context, point *types.PricePoint) error {
+	point.Time = point.Time.Truncate(priceHistoryResolution)
+	_, err := m.wrapper.C(cPriceHistory).Upsert(bson.M{"symbol": point.Symbol, "currency": point.Currency, "time": point.Time}, point)
+	return err
+}
+
+// UpsertPricePoints write points in one bulk, of points in the same period only the last one is kept
+func (m *mongoDB) UpsertPricePoints(ctx context.Context, points []*types.PricePoint) error {
+	var (
+		models   []mongo.WriteModel
+		byPeriod = make(map[string]int)
+	)
+	for _, point := range points {
+		point.Time = point.Time.Truncate(priceHistoryResolution)
+		model := mongo.NewUpdateOneModel().SetUpsert(true).
+			SetFilter(bson.M{"symbol": point.Symbol, "currency": point.Currency, "time": point.Time}).
+			SetUpdate(bson.M{"$set": point})
+		period := fmt.Sprintf("%s/%s/%d", point.Symbol, point.Currency, point.Time.Unix())
+		if i, ok := byPeriod[period]; ok {
+			models[i] = model
+			continue
+		}
+		byPeriod[period] = len(models)
+		models = append(models, model)
+	}
+	if len(models) == 0 {
+		return nil
+	}
+	_, err := m.wrapper.C(cPriceHistory).BulkUpsert(models)
+	return err
+}
+
+// PricePoints return points in [from, to] ascending by time, plus the latest point before from if any,
+// so price at any time in range can be looked up
+func (m *mongoDB) PricePoints(ctx context.Context, symbol, currency string, from, to time.Time) ([]*types.PricePoint, error) {
+	var points []*types.PricePoint
+	var before *types.PricePoint
+	err := m.wrapper.C(cPriceHistory).FindOne(
+		bson.M{"symbol": symbol, "currency": currency, "time": bson.M{"$lt": from}},
+		options.FindOne().SetSort(bson.M{"time": -1}),
+	).Decode(&before)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+	if before != nil {
+		points = append(points, before)
+	}
+	cursor, err := m.wrapper.C(cPriceHistory).Find(
+		bson.M{"symbol": symbol, "currency": currency, "time": bson.M{"$gte": from, "$lte": to}},
+		options.Find().SetSort(bson.M{"time": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var inRange []*types.PricePoint
+	if err := cursor.All(ctx, &inRange); err != nil {
+		return nil, err
+	}
+	return append(points, inRange...), nil
+}
+
+// LatestPricePoint return nil when there is no price of symbol yet
+func (m *mongoDB) LatestPricePoint(ctx context.Context, symbol, currency string) (*types.PricePoint, error) {
+	var point *types.PricePoint
+	err := m.wrapper.C(cPriceHistory).FindOne(
+		bson.M{"symbol": symbol, "currency": currency},
+		options.FindOne().SetSort(bson.M{"time": -1}),
+	).Decode(&point)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return point, nil
+}
@@ -0,0 +1,129 @@
+// Package db
+package db
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+var cProductionStats = "ProductionStats"
+
+type IProductionStats interface {
+	createProductionStatsCollectionIndexes() []mongo.IndexModel
+	IncProductionStats(ctx context.Context, block *types.Block, windowSize uint64) error
+	RevertProductionStats(ctx context.Context, block *types.Block, windowSize uint64) error
+	ProductionStats(ctx context.Context, filter *types.ProductionStatsFilter) ([]*types.ProductionStats, error)
+}
+
+// productionStatsDoc store rewards as Decimal128 so we can $inc it like other counters
+type productionStatsDoc struct {
+	types.ProductionStats `bson:",inline"`
+	Rewards               primitive.Decimal128 `bson:"rewards"`
+}
+
+func (m *mongoDB) createProductionStatsCollectionIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.D{{Key: "proposerAddress", Value: 1}, {Key: "bucketType", Value: 1}, {Key: "bucket", Value: -1}}, Options: options.Index().SetUnique(true).SetSparse(true)},
+		{Keys: bson.D{{Key: "bucketType", Value: 1}, {Key: "bucket", Value: -1}}, Options: options.Index().SetSparse(true)},
+	}
+}
+
+// IncProductionStats add block to day and window buckets of its proposer
+func (m *mongoDB) IncProductionStats(ctx context.Context, block *types.Block, windowSize uint64) error {
+	return m.updateProductionStats(block, windowSize, 1)
+}
+
+// RevertProductionStats remove block from buckets of its proposer, used before re-importing a block
+func (m *mongoDB) RevertProductionStats(ctx context.Context, block *types.Block, windowSize uint64) error {
+	return m.updateProductionStats(block, windowSize, -1)
+}
+
+func (m *mongoDB) updateProductionStats(block *types.Block, windowSize uint64, sign int64) error {
+	lgr := m.logger.With(zap.String("method", "updateProductionStats"))
+	rewardsStr := block.Rewards
+	if sign < 0 {
+		rewardsStr = "-" + rewardsStr
+	}
+	rewards, err := primitive.ParseDecimal128(rewardsStr)
+	if err != nil {
+		rewards, _ = primitive.ParseDecimal128("0")
+	}
+	inc := bson.M{
+		"blocksProposed": sign,
+		"txsIncluded":    sign * int64(block.NumTxs),
+		"gasUsed":        sign * int64(block.GasUsed),
+		"rewards":        rewards,
+	}
+	day := block.Time.UTC().Truncate(24 * time.Hour).Unix()
+	window := int64(block.Height / windowSize)
+	models := []mongo.WriteModel{
+		mongo.NewUpdateOneModel().SetUpsert(true).
+			SetFilter(bson.M{"proposerAddress": block.ProposerAddress, "bucketType": types.ProductionBucketDay, "bucket": day}).
+			SetUpdate(bson.M{"$inc": inc}),
+		mongo.NewUpdateOneModel().SetUpsert(true).
+			SetFilter(bson.M{"proposerAddress": block.ProposerAddress, "bucketType": types.ProductionBucketWindow, "bucket": window}).
+			SetUpdate(bson.M{
+				"$inc": inc,
+				"$set": bson.M{"fromHeight": uint64(window) * windowSize, "toHeight": uint64(window+1)*windowSize - 1},
+			}),
+	}
+	if _, err := m.wrapper.C(cProductionStats).BulkUpsert(models); err != nil {
+		lgr.Warn("cannot update production stats", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (m *mongoDB) ProductionStats(ctx context.Context, filter *types.ProductionStatsFilter) ([]*types.ProductionStats, error) {
+	crit := bson.M{"bucketType": filter.BucketType}
+	if filter.ProposerAddress != "" {
+		crit["proposerAddress"] = filter.ProposerAddress
+	}
+	bucketRange := bson.M{}
+	if filter.FromBucket > 0 {
+		bucketRange["$gte"] = filter.FromBucket
+	}
+	if filter.ToBucket > 0 {
+		bucketRange["$lte"] = filter.ToBucket
+	}
+	if len(bucketRange) > 0 {
+		crit["bucket"] = bucketRange
+	}
+	cursor, err := m.wrapper.C(cProductionStats).Find(crit, options.Find().SetSort(bson.M{"bucket": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []*productionStatsDoc
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	stats := make([]*types.ProductionStats, len(docs))
+	for i, d := range docs {
+		stats[i] = &d.ProductionStats
+		stats[i].Rewards = decimal128ToIntString(d.Rewards)
+	}
+	return stats, nil
+}
+
+// decimal128ToIntString return integer string of d, String() of big values is in exponent form like 1E+18
+func decimal128ToIntString(d primitive.Decimal128) string {
+	bi, exp, err := d.BigInt()
+	if err != nil {
+		return "0"
+	}
+	if exp < 0 {
+		return bi.Quo(bi, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-exp)), nil)).String()
+	}
+	return bi.Mul(bi, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)).String()
+}
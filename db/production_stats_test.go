@@ -0,0 +1,23 @@
+// Package db
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func Test_decimal128ToIntString(t *testing.T) {
+	for input, expected := range map[string]string{
+		"1E+18":                  "1000000000000000000",
+		"1500000000000000000000": "1500000000000000000000",
+		"-2E+3":                  "-2000",
+		"12.5":                   "12",
+		"0":                      "0",
+	} {
+		d, err := primitive.ParseDecimal128(input)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, decimal128ToIntString(d), input)
+	}
+}
@@ -0,0 +1,152 @@
+// Package db
+package db
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+var cRewardSnapshots = "RewardSnapshots"
+
+const withdrawRewardsMethod = "WithdrawRewards"
+
+type IRewards interface {
+	createRewardSnapshotsCollectionIndexes() []mongo.IndexModel
+	InsertRewardSnapshots(ctx context.Context, snapshots []*types.RewardSnapshot) error
+	LatestRewardSnapshots(ctx context.Context, validatorSMCAddress string) ([]*types.RewardSnapshot, error)
+	RewardSnapshots(ctx context.Context, filter *types.RewardSnapshotsFilter) ([]*types.RewardSnapshot, uint64, error)
+	WithdrawnRewards(ctx context.Context, validatorSMCAddress string, from, to time.Time) (map[string]*big.Int, error)
+}
+
+func (m *mongoDB) createRewardSnapshotsCollectionIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.D{{Key: "validatorSMCAddress", Value: 1}, {Key: "time", Value: -1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "delegatorAddress", Value: 1}, {Key: "time", Value: -1}}, Options: options.Index().SetSparse(true)},
+	}
+}
+
+func (m *mongoDB) InsertRewardSnapshots(ctx context.Context, snapshots []*types.RewardSnapshot) error {
+	lgr := m.logger.With(zap.String("method", "InsertRewardSnapshots"))
+	var models []mongo.WriteModel
+	for _, s := range snapshots {
+		models = append(models, mongo.NewInsertOneModel().SetDocument(s))
+	}
+	if len(models) == 0 {
+		return nil
+	}
+	if _, err := m.wrapper.C(cRewardSnapshots).BulkWrite(models); err != nil {
+		lgr.Warn("cannot write reward snapshots", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// LatestRewardSnapshots return all delegations of validator in the latest snapshot
+func (m *mongoDB) LatestRewardSnapshots(ctx context.Context, validatorSMCAddress string) ([]*types.RewardSnapshot, error) {
+	var latest *types.RewardSnapshot
+	opts := options.FindOne().SetSort(bson.M{"time": -1})
+	if err := m.wrapper.C(cRewardSnapshots).FindOne(bson.M{"validatorSMCAddress": validatorSMCAddress}, opts).Decode(&latest); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	cursor, err := m.wrapper.C(cRewardSnapshots).Find(bson.M{"validatorSMCAddress": validatorSMCAddress, "time": latest.Time})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var snapshots []*types.RewardSnapshot
+	if err := cursor.All(ctx, &snapshots); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+func (m *mongoDB) RewardSnapshots(ctx context.Context, filter *types.RewardSnapshotsFilter) ([]*types.RewardSnapshot, uint64, error) {
+	var (
+		snapshots []*types.RewardSnapshot
+		crit      = bson.M{}
+		opts      = []*options.FindOptions{
+			options.Find().SetSort(bson.M{"time": 1}),
+		}
+	)
+	critBytes, err := bson.Marshal(filter)
+	if err != nil {
+		m.logger.Warn("Cannot marshal reward snapshots filter criteria", zap.Error(err))
+	}
+	err = bson.Unmarshal(critBytes, &crit)
+	if err != nil {
+		m.logger.Warn("Cannot unmarshal reward snapshots filter criteria", zap.Error(err))
+	}
+	if filter.TimeFilter != nil {
+		filter.TimeFilter.Sanitize()
+		crit["time"] = bson.M{"$gte": filter.TimeFilter.FromTime, "$lte": filter.TimeFilter.ToTime}
+	}
+	if filter.Pagination != nil {
+		filter.Pagination.Sanitize()
+		opts = append(opts, options.Find().SetSkip(int64(filter.Pagination.Skip)), options.Find().SetLimit(int64(filter.Pagination.Limit)))
+	}
+	cursor, err := m.wrapper.C(cRewardSnapshots).Find(crit, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &snapshots); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := m.wrapper.C(cRewardSnapshots).Count(crit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return snapshots, uint64(total), nil
+}
+
+// WithdrawnRewards sum WithdrawRewards events of validator SMC in time range, grouped by delegator
+func (m *mongoDB) WithdrawnRewards(ctx context.Context, validatorSMCAddress string, from, to time.Time) (map[string]*big.Int, error) {
+	crit := bson.M{
+		"address":    validatorSMCAddress,
+		"methodName": withdrawRewardsMethod,
+		"time":       bson.M{"$gt": from, "$lte": to},
+	}
+	cursor, err := m.wrapper.C(cEvents).Find(crit)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var events []*types.Log
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+
+	withdrawn := make(map[string]*big.Int)
+	for _, e := range events {
+		delegator, ok := e.Arguments["_to"].(string)
+		if !ok {
+			continue
+		}
+		amountStr, ok := e.Arguments["_amount"].(string)
+		if !ok {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(amountStr, 10)
+		if !ok {
+			continue
+		}
+		if _, exist := withdrawn[delegator]; !exist {
+			withdrawn[delegator] = big.NewInt(0)
+		}
+		withdrawn[delegator] = new(big.Int).Add(withdrawn[delegator], amount)
+	}
+	return withdrawn, nil
+}
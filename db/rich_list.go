@@ -0,0 +1,114 @@
+// Package db
+package db
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+var (
+	cRichList          = "RichList"
+	cRichListSnapshots = "RichListSnapshots"
+)
+
+type IRichList interface {
+	createRichListCollectionIndexes() []mongo.IndexModel
+	createRichListSnapshotsCollectionIndexes() []mongo.IndexModel
+	UpsertRichList(ctx context.Context, entries []*types.RichListEntry) error
+	RichList(ctx context.Context, pagination *types.Pagination) ([]*types.RichListEntry, uint64, error)
+	UpsertRichListSnapshots(ctx context.Context, snapshots []*types.RichListSnapshot) error
+	RichListSnapshots(ctx context.Context, day int64) ([]*types.RichListSnapshot, error)
+}
+
+func (m *mongoDB) createRichListCollectionIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.M{"rank": 1}, Options: options.Index().SetUnique(true)},
+	}
+}
+
+func (m *mongoDB) createRichListSnapshotsCollectionIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.D{{Key: "day", Value: -1}, {Key: "address", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.M{"expireAt": 1}, Options: options.Index().SetExpireAfterSeconds(0)},
+	}
+}
+
+// UpsertRichList replace entries rank by rank, so readers never see an empty list while it's refreshed
+func (m *mongoDB) UpsertRichList(ctx context.Context, entries []*types.RichListEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	var models []mongo.WriteModel
+	for _, e := range entries {
+		models = append(models, mongo.NewReplaceOneModel().SetUpsert(true).
+			SetFilter(bson.M{"rank": e.Rank}).SetReplacement(e))
+	}
+	if _, err := m.wrapper.C(cRichList).BulkWrite(models); err != nil {
+		m.logger.Warn("cannot upsert rich list", zap.Error(err))
+		return err
+	}
+	if _, err := m.wrapper.C(cRichList).RemoveAll(bson.M{"rank": bson.M{"$gt": len(entries)}}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *mongoDB) RichList(ctx context.Context, pagination *types.Pagination) ([]*types.RichListEntry, uint64, error) {
+	opts := []*options.FindOptions{
+		options.Find().SetSort(bson.M{"rank": 1}),
+		options.Find().SetSkip(int64(pagination.Skip)),
+		options.Find().SetLimit(int64(pagination.Limit)),
+	}
+	cursor, err := m.wrapper.C(cRichList).Find(bson.M{}, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*types.RichListEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, 0, err
+	}
+	total, err := m.wrapper.C(cRichList).Count(bson.M{})
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, uint64(total), nil
+}
+
+// UpsertRichListSnapshots overwrite snapshots of the same day, so a day keeps ranks of its last refresh
+func (m *mongoDB) UpsertRichListSnapshots(ctx context.Context, snapshots []*types.RichListSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	var models []mongo.WriteModel
+	for _, s := range snapshots {
+		models = append(models, mongo.NewReplaceOneModel().SetUpsert(true).
+			SetFilter(bson.M{"day": s.Day, "address": s.Address}).SetReplacement(s))
+	}
+	if _, err := m.wrapper.C(cRichListSnapshots).BulkUpsert(models); err != nil {
+		m.logger.Warn("cannot upsert rich list snapshots", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (m *mongoDB) RichListSnapshots(ctx context.Context, day int64) ([]*types.RichListSnapshot, error) {
+	cursor, err := m.wrapper.C(cRichListSnapshots).Find(bson.M{"day": day})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var snapshots []*types.RichListSnapshot
+	if err := cursor.All(ctx, &snapshots); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,207 @@
+// Package db
+package db
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+type ISearch interface {
+	SearchAddresses(ctx context.Context, text string, limit int64) ([]*types.Address, error)
+	SearchContracts(ctx context.Context, text string, limit int64) ([]*types.Contract, error)
+	NamedAddresses(ctx context.Context) ([]*types.Address, error)
+	BackfillSearchNames(ctx context.Context) (int, error)
+}
+
+// searchInfixMinLength is the shortest text which is searched in the middle of names, shorter texts match too many
+// names to be worth scanning the index for
+const searchInfixMinLength = 3
+
+// searchNames return lower case names to be matched by search, they are indexed so exact and prefix tiers are
+// index range scans
+func searchNames(names ...string) []string {
+	var normalized []string
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		dup := false
+		for _, n := range normalized {
+			dup = dup || n == name
+		}
+		if !dup {
+			normalized = append(normalized, name)
+		}
+	}
+	return normalized
+}
+
+func setAddressSearchNames(addr *types.Address) {
+	addr.SearchNames = searchNames(addr.Name, addr.TokenName, addr.TokenSymbol)
+}
+
+func setContractSearchNames(smc *types.Contract) {
+	smc.SearchNames = searchNames(smc.Name)
+}
+
+// searchTiers return criteria of exact, prefix and substring matches of text in search names. Tiers are queried
+// in order, so limit cuts off the weakest matches instead of whatever db returns first
+func searchTiers(text string) []bson.M {
+	text = strings.ToLower(strings.TrimSpace(text))
+	quoted := regexp.QuoteMeta(text)
+	tiers := []bson.M{
+		{"searchNames": text},
+		{"searchNames": primitive.Regex{Pattern: "^" + quoted}},
+	}
+	if len([]rune(text)) >= searchInfixMinLength {
+		tiers = append(tiers, bson.M{"searchNames": primitive.Regex{Pattern: quoted}})
+	}
+	return tiers
+}
+
+// findSearchTiers query tiers until limit documents are found, documents are appended to results by decode
+func (m *mongoDB) findSearchTiers(ctx context.Context, collection string, tiers []bson.M, limit int64, decode func(cursor *mongo.Cursor) ([]string, error), opts ...*options.FindOptions) error {
+	var found []string // addresses of previous tiers
+	for _, crit := range tiers {
+		if int64(len(found)) >= limit {
+			break
+		}
+		if len(found) > 0 {
+			crit = bson.M{"$and": []bson.M{crit, {"address": bson.M{"$nin": found}}}}
+		}
+		cursor, err := m.wrapper.C(collection).Find(crit, append(opts, options.Find().SetLimit(limit-int64(len(found))))...)
+		if err != nil {
+			return err
+		}
+		addresses, err := decode(cursor)
+		cursor.Close(ctx)
+		if err != nil {
+			return err
+		}
+		found = append(found, addresses...)
+	}
+	return nil
+}
+
+// SearchAddresses return addresses whose label, token name or token symbol contains text, best matches first
+func (m *mongoDB) SearchAddresses(ctx context.Context, text string, limit int64) ([]*types.Address, error) {
+	var addrs []*types.Address
+	err := m.findSearchTiers(ctx, cAddresses, searchTiers(text), limit, func(cursor *mongo.Cursor) ([]string, error) {
+		var tier []*types.Address
+		if err := cursor.All(ctx, &tier); err != nil {
+			return nil, err
+		}
+		addresses := make([]string, len(tier))
+		for i, addr := range tier {
+			addresses[i] = addr.Address
+		}
+		addrs = append(addrs, tier...)
+		return addresses, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return addrs, nil
+}
+
+// SearchContracts return contracts whose name contains text, best matches first
+func (m *mongoDB) SearchContracts(ctx context.Context, text string, limit int64) ([]*types.Contract, error) {
+	var contracts []*types.Contract
+	err := m.findSearchTiers(ctx, cContract, searchTiers(text), limit, func(cursor *mongo.Cursor) ([]string, error) {
+		var tier []*types.Contract
+		if err := cursor.All(ctx, &tier); err != nil {
+			return nil, err
+		}
+		addresses := make([]string, len(tier))
+		for i, smc := range tier {
+			addresses[i] = smc.Address
+		}
+		contracts = append(contracts, tier...)
+		return addresses, nil
+	}, options.Find().SetProjection(bson.M{"abi": 0, "bytecode": 0}))
+	if err != nil {
+		return nil, err
+	}
+	return contracts, nil
+}
+
+// NamedAddresses return addresses which have a label or token name, for building autocomplete index
+func (m *mongoDB) NamedAddresses(ctx context.Context) ([]*types.Address, error) {
+	crit := bson.M{"$or": []bson.M{
+		{"name": bson.M{"$nin": []interface{}{"", nil}}},
+		{"tokenName": bson.M{"$nin": []interface{}{"", nil}}},
+		{"tokenSymbol": bson.M{"$nin": []interface{}{"", nil}}},
+	}}
+	cursor, err := m.wrapper.C(cAddresses).Find(crit)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var addrs []*types.Address
+	if err := cursor.All(ctx, &addrs); err != nil {
+		return nil, err
+	}
+	return addrs, nil
+}
+
+// BackfillSearchNames set search names of named addresses and contracts which were written before they existed,
+// it returns number of updated documents
+func (m *mongoDB) BackfillSearchNames(ctx context.Context) (int, error) {
+	named := bson.M{"$nin": []interface{}{"", nil}}
+	missing := bson.M{"$exists": false}
+	var updated int
+	cursor, err := m.wrapper.C(cAddresses).Find(bson.M{"searchNames": missing, "$or": []bson.M{{"name": named}, {"tokenName": named}, {"tokenSymbol": named}}})
+	if err != nil {
+		return 0, err
+	}
+	var addrs []*types.Address
+	err = cursor.All(ctx, &addrs)
+	cursor.Close(ctx)
+	if err != nil {
+		return 0, err
+	}
+	models := make([]mongo.WriteModel, 0, len(addrs))
+	for _, addr := range addrs {
+		setAddressSearchNames(addr)
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(bson.M{"address": addr.Address}).
+			SetUpdate(bson.M{"$set": bson.M{"searchNames": addr.SearchNames}}))
+	}
+	if len(models) > 0 {
+		if _, err := m.wrapper.C(cAddresses).BulkWrite(models); err != nil {
+			return 0, err
+		}
+	}
+	updated += len(models)
+
+	cursor, err = m.wrapper.C(cContract).Find(bson.M{"searchNames": missing, "name": named}, options.Find().SetProjection(bson.M{"address": 1, "name": 1}))
+	if err != nil {
+		return updated, err
+	}
+	var contracts []*types.Contract
+	err = cursor.All(ctx, &contracts)
+	cursor.Close(ctx)
+	if err != nil {
+		return updated, err
+	}
+	models = models[:0]
+	for _, smc := range contracts {
+		setContractSearchNames(smc)
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(bson.M{"address": smc.Address}).
+			SetUpdate(bson.M{"$set": bson.M{"searchNames": smc.SearchNames}}))
+	}
+	if len(models) > 0 {
+		if _, err := m.wrapper.C(cContract).BulkWrite(models); err != nil {
+			return updated, err
+		}
+	}
+	return updated + len(models), nil
+}
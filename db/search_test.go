@@ -0,0 +1,28 @@
+// Package db
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func Test_searchTiers(t *testing.T) {
+	assert.Equal(t, []bson.M{
+		{"searchNames": "kai.swap"},
+		{"searchNames": primitive.Regex{Pattern: `^kai\.swap`}},
+		{"searchNames": primitive.Regex{Pattern: `kai\.swap`}},
+	}, searchTiers(" Kai.Swap "))
+	// short text is not searched in the middle of names
+	assert.Equal(t, []bson.M{
+		{"searchNames": "ka"},
+		{"searchNames": primitive.Regex{Pattern: `^ka`}},
+	}, searchTiers("KA"))
+}
+
+func Test_searchNames(t *testing.T) {
+	assert.Equal(t, []string{"kaiswap", "kswap"}, searchNames(" KaiSwap", "kaiswap", "", "KSWAP"))
+	assert.Nil(t, searchNames("", " "))
+}
@@ -0,0 +1,108 @@
+// Package db
+package db
+
+import (
+	"context"
+	"math/big"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+var cSlashEvents = "SlashEvents"
+
+type ISlashEvents interface {
+	createSlashEventsCollectionIndexes() []mongo.IndexModel
+	InsertSlashEvents(ctx context.Context, events []*types.SlashEvent) error
+	SlashEvents(ctx context.Context, filter *types.SlashEventsFilter) ([]*types.SlashEvent, uint64, error)
+	SlashSummary(ctx context.Context, validatorAddress string) (*types.SlashSummary, error)
+}
+
+func (m *mongoDB) createSlashEventsCollectionIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.D{{Key: "validatorAddress", Value: 1}, {Key: "height", Value: -1}, {Key: "period", Value: 1}}, Options: options.Index().SetUnique(true).SetSparse(true)},
+		{Keys: bson.M{"height": -1}, Options: options.Index().SetSparse(true)},
+	}
+}
+
+// InsertSlashEvents only insert events we haven't seen before, delegators lost amount
+// is calculated from staked amount right before the slash height so it never changes
+func (m *mongoDB) InsertSlashEvents(ctx context.Context, events []*types.SlashEvent) error {
+	lgr := m.logger.With(zap.String("method", "InsertSlashEvents"))
+	var models []mongo.WriteModel
+	for _, e := range events {
+		models = append(models, mongo.NewUpdateOneModel().SetUpsert(true).
+			SetFilter(bson.M{"validatorAddress": e.ValidatorAddress, "height": e.Height, "period": e.Period}).
+			SetUpdate(bson.M{"$setOnInsert": e}))
+	}
+	if len(models) == 0 {
+		return nil
+	}
+	if _, err := m.wrapper.C(cSlashEvents).BulkUpsert(models); err != nil {
+		lgr.Warn("cannot write slash events", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (m *mongoDB) SlashEvents(ctx context.Context, filter *types.SlashEventsFilter) ([]*types.SlashEvent, uint64, error) {
+	var (
+		events []*types.SlashEvent
+		crit   = bson.M{}
+		opts   = []*options.FindOptions{
+			options.Find().SetSort(bson.M{"height": -1}),
+		}
+	)
+	critBytes, err := bson.Marshal(filter)
+	if err != nil {
+		m.logger.Warn("Cannot marshal slash events filter criteria", zap.Error(err))
+	}
+	err = bson.Unmarshal(critBytes, &crit)
+	if err != nil {
+		m.logger.Warn("Cannot unmarshal slash events filter criteria", zap.Error(err))
+	}
+	if filter.Pagination != nil {
+		filter.Pagination.Sanitize()
+		opts = append(opts, options.Find().SetSkip(int64(filter.Pagination.Skip)), options.Find().SetLimit(int64(filter.Pagination.Limit)))
+	}
+	cursor, err := m.wrapper.C(cSlashEvents).Find(crit, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := m.wrapper.C(cSlashEvents).Count(crit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, uint64(total), nil
+}
+
+func (m *mongoDB) SlashSummary(ctx context.Context, validatorAddress string) (*types.SlashSummary, error) {
+	events, total, err := m.SlashEvents(ctx, &types.SlashEventsFilter{ValidatorAddress: validatorAddress})
+	if err != nil {
+		return nil, err
+	}
+	summary := &types.SlashSummary{
+		TotalEvents: total,
+	}
+	totalSlashed := big.NewInt(0)
+	for _, e := range events {
+		amount, ok := new(big.Int).SetString(e.TotalSlashedAmount, 10)
+		if ok {
+			totalSlashed = new(big.Int).Add(totalSlashed, amount)
+		}
+		if e.Height > summary.LatestHeight {
+			summary.LatestHeight = e.Height
+		}
+	}
+	summary.TotalSlashedAmount = totalSlashed.String()
+	return summary, nil
+}
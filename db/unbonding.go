@@ -0,0 +1,87 @@
+// Package db
+package db
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+var cUnbondingEntries = "UnbondingEntries"
+
+type IUnbondingEntries interface {
+	createUnbondingEntriesCollectionIndexes() []mongo.IndexModel
+	ReplaceUnbondingEntries(ctx context.Context, validatorSMCAddress, delegatorAddress string, entries []*types.UnbondingEntry) error
+	UnbondingEntries(ctx context.Context, delegatorAddress string) ([]*types.UnbondingEntry, error)
+	UnbondingDelegators(ctx context.Context, validatorSMCAddress string, completedAfter int64) ([]string, error)
+}
+
+func (m *mongoDB) createUnbondingEntriesCollectionIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.D{{Key: "delegatorAddress", Value: 1}, {Key: "completionTime", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "validatorSMCAddress", Value: 1}, {Key: "delegatorAddress", Value: 1}}, Options: options.Index().SetSparse(true)},
+	}
+}
+
+// ReplaceUnbondingEntries replace all entries of a delegation, since entries have no identity on chain
+func (m *mongoDB) ReplaceUnbondingEntries(ctx context.Context, validatorSMCAddress, delegatorAddress string, entries []*types.UnbondingEntry) error {
+	lgr := m.logger.With(zap.String("method", "ReplaceUnbondingEntries"))
+	if _, err := m.wrapper.C(cUnbondingEntries).RemoveAll(bson.M{"validatorSMCAddress": validatorSMCAddress, "delegatorAddress": delegatorAddress}); err != nil {
+		lgr.Warn("cannot remove unbonding entries", zap.Error(err))
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	var models []mongo.WriteModel
+	for _, e := range entries {
+		models = append(models, mongo.NewInsertOneModel().SetDocument(e))
+	}
+	if _, err := m.wrapper.C(cUnbondingEntries).BulkInsert(models); err != nil {
+		lgr.Warn("cannot insert unbonding entries", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// UnbondingEntries return entries of delegator sorted by completion time, or all entries if delegator is empty
+func (m *mongoDB) UnbondingEntries(ctx context.Context, delegatorAddress string) ([]*types.UnbondingEntry, error) {
+	crit := bson.M{}
+	if delegatorAddress != "" {
+		crit["delegatorAddress"] = delegatorAddress
+	}
+	cursor, err := m.wrapper.C(cUnbondingEntries).Find(crit, options.Find().SetSort(bson.M{"completionTime": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var entries []*types.UnbondingEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// UnbondingDelegators return delegators of validator which have entries completing after time, they were still
+// delegated or unbonding at that time even if they have no delegation now
+func (m *mongoDB) UnbondingDelegators(ctx context.Context, validatorSMCAddress string, completedAfter int64) ([]string, error) {
+	values, err := m.wrapper.C(cUnbondingEntries).Distinct("delegatorAddress", bson.M{
+		"validatorSMCAddress": validatorSMCAddress,
+		"completionTime":      bson.M{"$gt": completedAfter},
+	})
+	if err != nil {
+		return nil, err
+	}
+	delegators := make([]string, 0, len(values))
+	for _, v := range values {
+		if address, ok := v.(string); ok {
+			delegators = append(delegators, address)
+		}
+	}
+	return delegators, nil
+}
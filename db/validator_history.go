@@ -0,0 +1,166 @@
+// Package db
+package db
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+var cValidatorHistory = "ValidatorHistory"
+
+const fieldCommissionRate = "commissionRate"
+
+type IValidatorHistory interface {
+	createValidatorHistoryCollectionIndexes() []mongo.IndexModel
+	RecordValidatorChanges(ctx context.Context, validators []*types.Validator, blockHeight uint64) error
+	ValidatorHistory(ctx context.Context, filter *types.ValidatorHistoryFilter) ([]*types.ValidatorHistory, uint64, error)
+	CommissionIncreases(ctx context.Context, since time.Time) ([]*types.ValidatorHistory, error)
+}
+
+func (m *mongoDB) createValidatorHistoryCollectionIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.D{{Key: "validatorAddress", Value: 1}, {Key: "version", Value: -1}}, Options: options.Index().SetUnique(true).SetSparse(true)},
+		{Keys: bson.D{{Key: "changes.field", Value: 1}, {Key: "time", Value: -1}}, Options: options.Index().SetSparse(true)},
+	}
+}
+
+// RecordValidatorChanges compare validators with their latest recorded version and
+// insert a new version for validators which changed. We compare with history instead
+// of Validators collection since it may be cleared while reloading boot data
+func (m *mongoDB) RecordValidatorChanges(ctx context.Context, validators []*types.Validator, blockHeight uint64) error {
+	lgr := m.logger.With(zap.String("method", "RecordValidatorChanges"))
+	var models []mongo.WriteModel
+	now := time.Now()
+	for _, v := range validators {
+		var latest *types.ValidatorHistory
+		err := m.wrapper.C(cValidatorHistory).FindOne(bson.M{"validatorAddress": v.Address}, options.FindOne().SetSort(bson.M{"version": -1})).Decode(&latest)
+		if err != nil && err != mongo.ErrNoDocuments {
+			lgr.Warn("cannot get latest validator version", zap.String("validator", v.Address), zap.Error(err))
+			continue
+		}
+		version := uint64(1)
+		var changes []*types.ValidatorFieldChange
+		if latest != nil {
+			changes = diffValidator(latest, v)
+			if len(changes) == 0 {
+				continue
+			}
+			version = latest.Version + 1
+		}
+		models = append(models, mongo.NewInsertOneModel().SetDocument(&types.ValidatorHistory{
+			ValidatorAddress:    v.Address,
+			ValidatorSMCAddress: v.SmcAddress,
+			Version:             version,
+			BlockHeight:         blockHeight,
+			Name:                v.Name,
+			CommissionRate:      v.CommissionRate,
+			MaxRate:             v.MaxRate,
+			MaxChangeRate:       v.MaxChangeRate,
+			Status:              v.Status,
+			Role:                v.Role,
+			Jailed:              v.Jailed,
+			Changes:             changes,
+			Time:                now,
+		}))
+	}
+	if len(models) == 0 {
+		return nil
+	}
+	if _, err := m.wrapper.C(cValidatorHistory).BulkWrite(models); err != nil {
+		lgr.Warn("cannot write validator history", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (m *mongoDB) ValidatorHistory(ctx context.Context, filter *types.ValidatorHistoryFilter) ([]*types.ValidatorHistory, uint64, error) {
+	var (
+		history []*types.ValidatorHistory
+		crit    = bson.M{}
+		opts    = []*options.FindOptions{
+			options.Find().SetSort(bson.M{"version": -1}),
+		}
+	)
+	critBytes, err := bson.Marshal(filter)
+	if err != nil {
+		m.logger.Warn("Cannot marshal validator history filter criteria", zap.Error(err))
+	}
+	err = bson.Unmarshal(critBytes, &crit)
+	if err != nil {
+		m.logger.Warn("Cannot unmarshal validator history filter criteria", zap.Error(err))
+	}
+	if filter.Pagination != nil {
+		filter.Pagination.Sanitize()
+		opts = append(opts, options.Find().SetSkip(int64(filter.Pagination.Skip)), options.Find().SetLimit(int64(filter.Pagination.Limit)))
+	}
+	cursor, err := m.wrapper.C(cValidatorHistory).Find(crit, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &history); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := m.wrapper.C(cValidatorHistory).Count(crit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return history, uint64(total), nil
+}
+
+// CommissionIncreases return versions which raised commission rate since given time
+func (m *mongoDB) CommissionIncreases(ctx context.Context, since time.Time) ([]*types.ValidatorHistory, error) {
+	cursor, err := m.wrapper.C(cValidatorHistory).Find(bson.M{"changes.field": fieldCommissionRate, "time": bson.M{"$gte": since}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var history []*types.ValidatorHistory
+	if err := cursor.All(ctx, &history); err != nil {
+		return nil, err
+	}
+
+	var increases []*types.ValidatorHistory
+	for _, h := range history {
+		for _, c := range h.Changes {
+			if c.Field != fieldCommissionRate {
+				continue
+			}
+			from, _ := strconv.ParseFloat(c.From, 64)
+			to, _ := strconv.ParseFloat(c.To, 64)
+			if to > from {
+				increases = append(increases, h)
+			}
+		}
+	}
+	return increases, nil
+}
+
+func diffValidator(prev *types.ValidatorHistory, v *types.Validator) []*types.ValidatorFieldChange {
+	var changes []*types.ValidatorFieldChange
+	if prev.Name != v.Name {
+		changes = append(changes, &types.ValidatorFieldChange{Field: "name", From: prev.Name, To: v.Name})
+	}
+	if prev.CommissionRate != v.CommissionRate {
+		changes = append(changes, &types.ValidatorFieldChange{Field: fieldCommissionRate, From: prev.CommissionRate, To: v.CommissionRate})
+	}
+	if prev.Status != v.Status {
+		changes = append(changes, &types.ValidatorFieldChange{Field: "status", From: strconv.Itoa(int(prev.Status)), To: strconv.Itoa(int(v.Status))})
+	}
+	if prev.Role != v.Role {
+		changes = append(changes, &types.ValidatorFieldChange{Field: "role", From: strconv.Itoa(prev.Role), To: strconv.Itoa(v.Role)})
+	}
+	if prev.Jailed != v.Jailed {
+		changes = append(changes, &types.ValidatorFieldChange{Field: "jailed", From: strconv.FormatBool(prev.Jailed), To: strconv.FormatBool(v.Jailed)})
+	}
+	return changes
+}
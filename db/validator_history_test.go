@@ -0,0 +1,22 @@
+// Package db
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_diffValidator(t *testing.T) {
+	prev := &types.ValidatorHistory{Name: "val", CommissionRate: "5", Role: 2, Status: 2}
+	v := &types.Validator{Name: "val", CommissionRate: "10", Role: 2, Status: 2, Jailed: true}
+
+	changes := diffValidator(prev, v)
+	assert.Equal(t, 2, len(changes))
+	assert.Equal(t, &types.ValidatorFieldChange{Field: "commissionRate", From: "5", To: "10"}, changes[0])
+	assert.Equal(t, &types.ValidatorFieldChange{Field: "jailed", From: "false", To: "true"}, changes[1])
+
+	assert.Empty(t, diffValidator(prev, &types.Validator{Name: "val", CommissionRate: "5", Role: 2, Status: 2}))
+}
@@ -0,0 +1,99 @@
+// Package db
+package db
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+var cValidatorSets = "ValidatorSets"
+
+type IValidatorSets interface {
+	createValidatorSetsCollectionIndexes() []mongo.IndexModel
+	UpsertValidatorSetChange(ctx context.Context, change *types.ValidatorSetChange) error
+	ValidatorSetAt(ctx context.Context, height uint64) (*types.ValidatorSetChange, error)
+	NextValidatorSetChange(ctx context.Context, height uint64) (*types.ValidatorSetChange, error)
+	ValidatorSetChanges(ctx context.Context, filter *types.ValidatorSetChangesFilter) ([]*types.ValidatorSetChange, uint64, error)
+}
+
+func (m *mongoDB) createValidatorSetsCollectionIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.D{{Key: "height", Value: -1}}, Options: options.Index().SetUnique(true).SetSparse(true)},
+		{Keys: bson.D{{Key: "joined.address", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "left.address", Value: 1}}, Options: options.Index().SetSparse(true)},
+	}
+}
+
+func (m *mongoDB) UpsertValidatorSetChange(ctx context.Context, change *types.ValidatorSetChange) error {
+	model := mongo.NewUpdateOneModel().SetUpsert(true).SetFilter(bson.M{"height": change.Height}).SetUpdate(bson.M{"$set": change})
+	if _, err := m.wrapper.C(cValidatorSets).BulkUpsert([]mongo.WriteModel{model}); err != nil {
+		m.logger.Warn("cannot upsert validator set change", zap.Uint64("height", change.Height), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ValidatorSetAt return the latest set change which is active at given height, nil if there is none
+func (m *mongoDB) ValidatorSetAt(ctx context.Context, height uint64) (*types.ValidatorSetChange, error) {
+	return m.findValidatorSetChange(bson.M{"height": bson.M{"$lte": height}}, -1)
+}
+
+// NextValidatorSetChange return the first set change after given height, nil if there is none
+func (m *mongoDB) NextValidatorSetChange(ctx context.Context, height uint64) (*types.ValidatorSetChange, error) {
+	return m.findValidatorSetChange(bson.M{"height": bson.M{"$gt": height}}, 1)
+}
+
+func (m *mongoDB) findValidatorSetChange(crit bson.M, order int) (*types.ValidatorSetChange, error) {
+	var change *types.ValidatorSetChange
+	err := m.wrapper.C(cValidatorSets).FindOne(crit, options.FindOne().SetSort(bson.M{"height": order})).Decode(&change)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+func (m *mongoDB) ValidatorSetChanges(ctx context.Context, filter *types.ValidatorSetChangesFilter) ([]*types.ValidatorSetChange, uint64, error) {
+	var (
+		changes []*types.ValidatorSetChange
+		crit    = bson.M{}
+		opts    = []*options.FindOptions{
+			options.Find().SetSort(bson.M{"height": -1}),
+			// full set is heavy, client should query set at height for it
+			options.Find().SetProjection(bson.M{"validators": 0}),
+		}
+	)
+	if filter.Address != "" {
+		crit["$or"] = bson.A{
+			bson.M{"joined.address": filter.Address},
+			bson.M{"left.address": filter.Address},
+			bson.M{"powerChanges.address": filter.Address},
+		}
+	}
+	if filter.Pagination != nil {
+		filter.Pagination.Sanitize()
+		opts = append(opts, options.Find().SetSkip(int64(filter.Pagination.Skip)), options.Find().SetLimit(int64(filter.Pagination.Limit)))
+	}
+	cursor, err := m.wrapper.C(cValidatorSets).Find(crit, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := m.wrapper.C(cValidatorSets).Count(crit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return changes, uint64(total), nil
+}
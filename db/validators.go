@@ -55,6 +55,8 @@ func (m *mongoDB) UpsertValidators(ctx context.Context, validators []*types.Vali
 		contractInfo.Type = cfg.SMCTypeValidator
 		contractInfo.Name = v.Name
 		contractInfo.Address = v.SmcAddress
+		setAddressSearchNames(addressInfo)
+		setContractSearchNames(contractInfo)
 
 		addressModels = append(addressModels, mongo.NewUpdateOneModel().SetUpsert(true).SetFilter(bson.M{"address": addressInfo.Address}).SetUpdate(bson.M{"$set": addressInfo}))
 		contractModels = append(contractModels, mongo.NewUpdateOneModel().SetUpsert(true).SetFilter(bson.M{"address": contractInfo.Address}).SetUpdate(bson.M{"$set": contractInfo}))
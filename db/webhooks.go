@@ -0,0 +1,225 @@
+// Package db
+package db
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+var (
+	cWebhookWatches    = "WebhookWatches"
+	cWebhookDeliveries = "WebhookDeliveries"
+)
+
+type IWebhooks interface {
+	createWebhookWatchesCollectionIndexes() []mongo.IndexModel
+	createWebhookDeliveriesCollectionIndexes() []mongo.IndexModel
+
+	InsertWebhookWatch(ctx context.Context, watch *types.WebhookWatch) error
+	WebhookWatch(ctx context.Context, id string) (*types.WebhookWatch, error)
+	WebhookWatches(ctx context.Context, owner string) ([]*types.WebhookWatch, error)
+	RemoveWebhookWatch(ctx context.Context, owner, id string) (bool, error)
+	ReassignWebhookOwner(ctx context.Context, from, to string) (int64, error)
+
+	InsertWebhookDeliveries(ctx context.Context, deliveries []*types.WebhookDelivery) error
+	ClaimWebhookDelivery(ctx context.Context, now time.Time, lease time.Duration) (*types.WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery *types.WebhookDelivery) error
+	RetryWebhookDelivery(ctx context.Context, owner, id string) (bool, error)
+	WebhookDeliveries(ctx context.Context, filter *types.WebhookDeliveriesFilter) ([]*types.WebhookDelivery, uint64, error)
+	WebhookDeliveriesByHeight(ctx context.Context, height uint64) ([]*types.WebhookDelivery, error)
+}
+
+func (m *mongoDB) createWebhookWatchesCollectionIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.M{"owner": 1}, Options: options.Index().SetSparse(true)},
+	}
+}
+
+func (m *mongoDB) createWebhookDeliveriesCollectionIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{Keys: bson.M{"eventId": 1}, Options: options.Index().SetUnique(true).SetSparse(true)},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "nextAttemptAt", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "owner", Value: 1}, {Key: "createdAt", Value: -1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.M{"event.blockHeight": -1}, Options: options.Index().SetSparse(true)},
+	}
+}
+
+func (m *mongoDB) InsertWebhookWatch(ctx context.Context, watch *types.WebhookWatch) error {
+	watch.ID = primitive.NewObjectID()
+	_, err := m.wrapper.C(cWebhookWatches).Insert(watch)
+	return err
+}
+
+func (m *mongoDB) WebhookWatch(ctx context.Context, id string) (*types.WebhookWatch, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	var watch *types.WebhookWatch
+	if err := m.wrapper.C(cWebhookWatches).FindOne(bson.M{"_id": objectID}).Decode(&watch); err != nil {
+		return nil, err
+	}
+	return watch, nil
+}
+
+// WebhookWatches return watches of owner, or all watches if owner is empty
+func (m *mongoDB) WebhookWatches(ctx context.Context, owner string) ([]*types.WebhookWatch, error) {
+	crit := bson.M{}
+	if owner != "" {
+		crit["owner"] = owner
+	}
+	cursor, err := m.wrapper.C(cWebhookWatches).Find(crit, options.Find().SetSort(bson.M{"createdAt": -1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var watches []*types.WebhookWatch
+	if err := cursor.All(ctx, &watches); err != nil {
+		return nil, err
+	}
+	return watches, nil
+}
+
+// ReassignWebhookOwner move watches and deliveries of owner `from` to owner `to`, return number of moved watches
+func (m *mongoDB) ReassignWebhookOwner(ctx context.Context, from, to string) (int64, error) {
+	result, err := m.wrapper.C(cWebhookWatches).UpdateMany(bson.M{"owner": from}, bson.M{"$set": bson.M{"owner": to}})
+	if err != nil {
+		return 0, err
+	}
+	if _, err := m.wrapper.C(cWebhookDeliveries).UpdateMany(bson.M{"owner": from}, bson.M{"$set": bson.M{"owner": to}}); err != nil {
+		return result.ModifiedCount, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (m *mongoDB) RemoveWebhookWatch(ctx context.Context, owner, id string) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, nil
+	}
+	result, err := m.wrapper.C(cWebhookWatches).Remove(bson.M{"_id": objectID, "owner": owner})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// InsertWebhookDeliveries skip events which are already enqueued, so re-importing a block doesn't deliver twice
+func (m *mongoDB) InsertWebhookDeliveries(ctx context.Context, deliveries []*types.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	models := make([]mongo.WriteModel, len(deliveries))
+	for i, d := range deliveries {
+		models[i] = mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"eventId": d.EventID}).
+			SetUpdate(bson.M{"$setOnInsert": d}).
+			SetUpsert(true)
+	}
+	if _, err := m.wrapper.C(cWebhookDeliveries).BulkUpsert(models); err != nil {
+		m.logger.Warn("cannot insert webhook deliveries", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ClaimWebhookDelivery pick a due delivery and postpone it by lease, so other workers won't send it meanwhile
+func (m *mongoDB) ClaimWebhookDelivery(ctx context.Context, now time.Time, lease time.Duration) (*types.WebhookDelivery, error) {
+	var delivery *types.WebhookDelivery
+	err := m.wrapper.C(cWebhookDeliveries).FindOneAndUpdate(
+		bson.M{"status": types.WebhookStatusPending, "nextAttemptAt": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"nextAttemptAt": now.Add(lease)}},
+		options.FindOneAndUpdate().SetSort(bson.M{"nextAttemptAt": 1}).SetReturnDocument(options.After),
+	).Decode(&delivery)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (m *mongoDB) UpdateWebhookDelivery(ctx context.Context, delivery *types.WebhookDelivery) error {
+	_, err := m.wrapper.C(cWebhookDeliveries).Update(bson.M{"_id": delivery.ID}, bson.M{"$set": bson.M{
+		"status":         delivery.Status,
+		"attempts":       delivery.Attempts,
+		"nextAttemptAt":  delivery.NextAttemptAt,
+		"lastStatusCode": delivery.LastStatusCode,
+		"lastError":      delivery.LastError,
+		"updatedAt":      delivery.UpdatedAt,
+	}})
+	return err
+}
+
+// RetryWebhookDelivery move a failed delivery of owner back to pending, used for dead letters
+func (m *mongoDB) RetryWebhookDelivery(ctx context.Context, owner, id string) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, nil
+	}
+	now := time.Now()
+	// delivered ones are not sent again
+	crit := bson.M{"_id": objectID, "owner": owner, "status": bson.M{"$ne": types.WebhookStatusDelivered}}
+	result, err := m.wrapper.C(cWebhookDeliveries).Update(crit, bson.M{"$set": bson.M{
+		"status":        types.WebhookStatusPending,
+		"attempts":      0,
+		"nextAttemptAt": now,
+		"updatedAt":     now,
+	}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (m *mongoDB) WebhookDeliveries(ctx context.Context, filter *types.WebhookDeliveriesFilter) ([]*types.WebhookDelivery, uint64, error) {
+	var crit bson.M
+	critBytes, err := bson.Marshal(filter)
+	if err != nil {
+		m.logger.Warn("Cannot marshal webhook deliveries filter criteria", zap.Error(err))
+	}
+	if err := bson.Unmarshal(critBytes, &crit); err != nil {
+		m.logger.Warn("Cannot unmarshal webhook deliveries filter criteria", zap.Error(err))
+	}
+	opts := []*options.FindOptions{options.Find().SetSort(bson.M{"createdAt": -1})}
+	if filter.Pagination != nil {
+		opts = append(opts, options.Find().SetSkip(int64(filter.Pagination.Skip)), options.Find().SetLimit(int64(filter.Pagination.Limit)))
+	}
+	cursor, err := m.wrapper.C(cWebhookDeliveries).Find(crit, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+	var deliveries []*types.WebhookDelivery
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, 0, err
+	}
+	total, err := m.wrapper.C(cWebhookDeliveries).Count(crit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return deliveries, uint64(total), nil
+}
+
+// WebhookDeliveriesByHeight return deliveries of events at height, which are not removal notices
+func (m *mongoDB) WebhookDeliveriesByHeight(ctx context.Context, height uint64) ([]*types.WebhookDelivery, error) {
+	cursor, err := m.wrapper.C(cWebhookDeliveries).Find(bson.M{"event.blockHeight": height, "event.removed": false})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var deliveries []*types.WebhookDelivery
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
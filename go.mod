@@ -4,6 +4,8 @@ go 1.14
 
 require (
 	github.com/go-redis/redis/v8 v8.2.3
+	github.com/gorilla/websocket v1.4.2
+	github.com/graphql-go/graphql v0.8.0
 	github.com/joho/godotenv v1.3.0
 	github.com/kardiachain/go-kaiclient v0.0.0-20210317114326-32548175c496
 	github.com/kardiachain/go-kardia v0.11.0
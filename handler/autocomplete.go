@@ -0,0 +1,80 @@
+// Package handler
+package handler
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/db"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const autocompleteBatchSize = 500
+
+type IAutocompleteHandler interface {
+	ReindexAutocomplete(ctx context.Context) error
+}
+
+type autocompleteRecords struct {
+	addr *types.Address
+	smc  *types.Contract
+	val  *types.Validator
+}
+
+// ReindexAutocomplete rebuild autocomplete entries of all named addresses, contracts and validators. API updates entries
+// when admins change names, this picks up tokens found by grabber and refreshes popularity
+func (h *handler) ReindexAutocomplete(ctx context.Context) error {
+	lgr := h.logger.With(zap.String("method", "ReindexAutocomplete"))
+	records := make(map[string]*autocompleteRecords)
+	get := func(address string) *autocompleteRecords {
+		if records[address] == nil {
+			records[address] = &autocompleteRecords{}
+		}
+		return records[address]
+	}
+	addrs, err := h.db.NamedAddresses(ctx)
+	if err != nil {
+		lgr.Error("cannot load named addresses", zap.Error(err))
+		return err
+	}
+	for _, addr := range addrs {
+		get(addr.Address).addr = addr
+	}
+	contracts, _, err := h.db.Contracts(ctx, &types.ContractsFilter{})
+	if err != nil {
+		lgr.Error("cannot load contracts", zap.Error(err))
+		return err
+	}
+	for _, smc := range contracts {
+		get(smc.Address).smc = smc
+	}
+	validators, err := h.db.Validators(ctx, db.ValidatorsFilter{})
+	if err != nil {
+		lgr.Error("cannot load validators", zap.Error(err))
+		return err
+	}
+	for _, v := range validators {
+		get(v.SmcAddress).val = v
+	}
+
+	var batch []*types.AutocompleteEntry
+	for _, r := range records {
+		if e := types.NewAutocompleteEntry(r.addr, r.smc, r.val); e != nil {
+			batch = append(batch, e)
+		}
+		if len(batch) == autocompleteBatchSize {
+			if err := h.cache.UpsertAutocompleteEntries(ctx, batch); err != nil {
+				lgr.Error("cannot update autocomplete entries", zap.Error(err))
+				return err
+			}
+			batch = nil
+		}
+	}
+	if err := h.cache.UpsertAutocompleteEntries(ctx, batch); err != nil {
+		lgr.Error("cannot update autocomplete entries", zap.Error(err))
+		return err
+	}
+	lgr.Info("Reindexed autocomplete", zap.Int("addresses", len(records)))
+	return nil
+}
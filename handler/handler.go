@@ -7,6 +7,7 @@ import (
 	"github.com/kardiachain/kardia-explorer-backend/cache"
 	"github.com/kardiachain/kardia-explorer-backend/db"
 	"github.com/kardiachain/kardia-explorer-backend/kardia"
+	"github.com/kardiachain/kardia-explorer-backend/price"
 )
 
 type Config struct {
@@ -23,19 +24,42 @@ type Config struct {
 	CacheURL     string
 	CacheDB      int
 
+	// Exchanges are addresses tagged as exchange in rich list
+	Exchanges []string
+
+	PriceProviders   []string
+	CoinMarketAPIKey string
+	PriceStaticFile  string
+	PriceStubURL     string
+
 	Logger *zap.Logger
 }
 
 type Handler interface {
 	IStakingHandler
+	ISlashHandler
+	IRewardHandler
+	IUnbondingHandler
+	IWebhookHandler
+	IAutocompleteHandler
+	INetworkStatsHandler
+	IRichListHandler
+	IPriceHandler
 }
 
 type handler struct {
 	// Internal
-	w      *kardia.Wrapper
-	db     db.Client
-	cache  cache.Client
-	logger *zap.Logger
+	w         *kardia.Wrapper
+	kaiClient kardia.ClientInterface
+	db        db.Client
+	cache     cache.Client
+	price     price.Provider
+	logger    *zap.Logger
+
+	exchanges []string
+
+	// unbondingSeeded is set once unbonding entries of all delegations are loaded
+	unbondingSeeded bool
 }
 
 func New(cfg Config) (Handler, error) {
@@ -50,6 +74,12 @@ func New(cfg Config) (Handler, error) {
 		return nil, err
 	}
 
+	kaiClientCfg := kardia.NewConfig(cfg.PublicNodes, cfg.TrustedNodes, cfg.Logger)
+	kaiClient, err := kardia.NewKaiClient(kaiClientCfg)
+	if err != nil {
+		return nil, err
+	}
+
 	dbConfig := db.Config{
 		DbAdapter: cfg.StorageAdapter,
 		DbName:    cfg.StorageDB,
@@ -74,10 +104,24 @@ func New(cfg Config) (Handler, error) {
 		return nil, err
 	}
 
+	priceProvider, err := price.New(price.Config{
+		Providers:        cfg.PriceProviders,
+		CoinMarketAPIKey: cfg.CoinMarketAPIKey,
+		StaticFile:       cfg.PriceStaticFile,
+		StubURL:          cfg.PriceStubURL,
+		Logger:           cfg.Logger,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return &handler{
-		w:      kardiaWrapper,
-		logger: cfg.Logger,
-		db:     dbClient,
-		cache:  cacheClient,
+		w:         kardiaWrapper,
+		kaiClient: kaiClient,
+		logger:    cfg.Logger,
+		db:        dbClient,
+		cache:     cacheClient,
+		price:     priceProvider,
+		exchanges: cfg.Exchanges,
 	}, nil
 }
@@ -0,0 +1,120 @@
+// Package handler
+package handler
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const networkStatsBatchSize = 1000
+
+type INetworkStatsHandler interface {
+	RebuildNetworkStats(ctx context.Context) error
+}
+
+// RebuildNetworkStats recompute network charts from stored blocks and txs into new collections, which replace
+// current charts when done. Importers leave blocks imported meanwhile for it, so it can run while grabber is importing
+func (h *handler) RebuildNetworkStats(ctx context.Context) error {
+	lgr := h.logger.With(zap.String("method", "RebuildNetworkStats"))
+	if err := h.db.StartNetworkStatsRebuild(ctx); err != nil {
+		lgr.Error("cannot start rebuilding network stats", zap.Error(err))
+		return err
+	}
+	latest, err := h.rebuildNetworkStats(ctx)
+	if err != nil {
+		if err := h.db.CancelNetworkStatsRebuild(ctx); err != nil {
+			lgr.Error("cannot cancel rebuilding network stats", zap.Error(err))
+		}
+	} else if err = h.db.FinishNetworkStatsRebuild(ctx, latest); err != nil {
+		lgr.Error("cannot swap rebuilt network stats", zap.Error(err))
+	} else {
+		lgr.Info("Rebuilt network stats", zap.Uint64("latest", latest))
+	}
+	// importers count blocks again from now, blocks they have left are counted here either way
+	if err := h.countPendingNetworkStats(ctx); err != nil {
+		lgr.Error("cannot count blocks imported while rebuilding", zap.Error(err))
+		return err
+	}
+	return err
+}
+
+// rebuildNetworkStats count blocks up to the latest height when importers have stopped counting, so every block is
+// counted either here or by them
+func (h *handler) rebuildNetworkStats(ctx context.Context) (uint64, error) {
+	lgr := h.logger.With(zap.String("method", "RebuildNetworkStats"))
+	latest, err := h.db.LatestBlockHeight(ctx)
+	if err != nil {
+		lgr.Error("cannot get latest block height", zap.Error(err))
+		return 0, err
+	}
+
+	for from := uint64(0); from <= latest; from += networkStatsBatchSize {
+		to := from + networkStatsBatchSize - 1
+		if to > latest {
+			to = latest
+		}
+		heights := make([]uint64, 0, to-from+1)
+		for height := from; height <= to; height++ {
+			heights = append(heights, height)
+		}
+		blocks, err := h.db.BlocksByHeights(ctx, heights)
+		if err != nil {
+			lgr.Error("cannot load blocks", zap.Uint64("from", from), zap.Error(err))
+			return 0, err
+		}
+		txs, err := h.db.TxsInBlockRange(ctx, from, to)
+		if err != nil {
+			lgr.Error("cannot load txs", zap.Uint64("from", from), zap.Error(err))
+			return 0, err
+		}
+		byHeight := make(map[uint64]*types.Block, len(blocks))
+		for _, block := range blocks {
+			block.Txs = nil
+			byHeight[block.Height] = block
+		}
+		for _, tx := range txs {
+			if block := byHeight[tx.BlockNumber]; block != nil {
+				block.Txs = append(block.Txs, tx)
+			}
+		}
+		if err := h.db.IncRebuiltNetworkStats(ctx, blocks); err != nil {
+			lgr.Error("cannot update network stats", zap.Uint64("from", from), zap.Error(err))
+			return 0, err
+		}
+		if (to+1)%(100*networkStatsBatchSize) == 0 {
+			lgr.Info("Rebuilding network stats", zap.Uint64("height", to), zap.Uint64("latest", latest))
+		}
+	}
+	return latest, nil
+}
+
+// countPendingNetworkStats count blocks which importers left while network stats were rebuilt. Blocks are claimed
+// first since importers may be counting the same blocks
+func (h *handler) countPendingNetworkStats(ctx context.Context) error {
+	blocks, err := h.db.BlocksPendingStats(ctx, types.BlockStatsNetwork)
+	if err != nil {
+		return err
+	}
+	for _, block := range blocks {
+		claimed, err := h.db.ClaimBlockStats(ctx, block.Height, types.BlockStatsNetwork)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			continue
+		}
+		if block.Txs, _, err = h.db.TxsByBlockHeight(ctx, block.Height, nil); err == nil {
+			err = h.db.IncNetworkStats(ctx, []*types.Block{block})
+		}
+		if err != nil {
+			if err := h.db.ReleaseBlockStats(ctx, block.Height, types.BlockStatsNetwork); err != nil {
+				h.logger.Warn("Cannot mark block pending in network stats", zap.Uint64("height", block.Height), zap.Error(err))
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Package handler
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/db"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+type networkStatsFakeDB struct {
+	db.Client
+	latest   uint64
+	failInc  bool
+	pending  map[uint64]bool
+	rebuilt  []uint64
+	counted  []uint64
+	finished bool
+	canceled bool
+}
+
+func (d *networkStatsFakeDB) StartNetworkStatsRebuild(ctx context.Context) error {
+	return nil
+}
+
+func (d *networkStatsFakeDB) LatestBlockHeight(ctx context.Context) (uint64, error) {
+	return d.latest, nil
+}
+
+func (d *networkStatsFakeDB) BlocksByHeights(ctx context.Context, heights []uint64) ([]*types.Block, error) {
+	blocks := make([]*types.Block, len(heights))
+	for i, height := range heights {
+		blocks[i] = &types.Block{Height: height}
+	}
+	return blocks, nil
+}
+
+func (d *networkStatsFakeDB) TxsInBlockRange(ctx context.Context, from, to uint64) ([]*types.Transaction, error) {
+	return nil, nil
+}
+
+func (d *networkStatsFakeDB) TxsByBlockHeight(ctx context.Context, blockNumber uint64, pagination *types.Pagination) ([]*types.Transaction, uint64, error) {
+	return nil, 0, nil
+}
+
+func (d *networkStatsFakeDB) IncRebuiltNetworkStats(ctx context.Context, blocks []*types.Block) error {
+	if d.failInc {
+		return errors.New("write failed")
+	}
+	for _, block := range blocks {
+		d.rebuilt = append(d.rebuilt, block.Height)
+	}
+	return nil
+}
+
+func (d *networkStatsFakeDB) FinishNetworkStatsRebuild(ctx context.Context, latest uint64) error {
+	d.finished = true
+	return nil
+}
+
+func (d *networkStatsFakeDB) CancelNetworkStatsRebuild(ctx context.Context) error {
+	d.canceled = true
+	return nil
+}
+
+func (d *networkStatsFakeDB) BlocksPendingStats(ctx context.Context, stats string) ([]*types.Block, error) {
+	// one of them is claimed by importer in between
+	return []*types.Block{{Height: 5}, {Height: 6}, {Height: 7}}, nil
+}
+
+func (d *networkStatsFakeDB) ClaimBlockStats(ctx context.Context, blockHeight uint64, stats string) (bool, error) {
+	claimed := d.pending[blockHeight]
+	delete(d.pending, blockHeight)
+	return claimed, nil
+}
+
+func (d *networkStatsFakeDB) IncNetworkStats(ctx context.Context, blocks []*types.Block) error {
+	for _, block := range blocks {
+		d.counted = append(d.counted, block.Height)
+	}
+	return nil
+}
+
+func Test_RebuildNetworkStats(t *testing.T) {
+	fakeDB := &networkStatsFakeDB{latest: 4, pending: map[uint64]bool{5: true, 7: true}}
+	h := &handler{db: fakeDB, logger: zap.NewNop()}
+	assert.NoError(t, h.RebuildNetworkStats(context.Background()))
+	assert.Equal(t, []uint64{0, 1, 2, 3, 4}, fakeDB.rebuilt)
+	assert.True(t, fakeDB.finished)
+	assert.Equal(t, []uint64{5, 7}, fakeDB.counted)
+
+	// failed rebuild keeps current stats, blocks left by importers are still counted
+	fakeDB = &networkStatsFakeDB{latest: 4, failInc: true, pending: map[uint64]bool{5: true}}
+	h = &handler{db: fakeDB, logger: zap.NewNop()}
+	assert.Error(t, h.RebuildNetworkStats(context.Background()))
+	assert.True(t, fakeDB.canceled)
+	assert.False(t, fakeDB.finished)
+	assert.Equal(t, []uint64{5}, fakeDB.counted)
+}
@@ -0,0 +1,70 @@
+// Package handler
+package handler
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/price"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const (
+	// price history is hourly, a longer gap since the latest point is filled from providers
+	priceHistoryGap = 2 * time.Hour
+	// providers return hourly history of at most 90 days
+	priceBackfillRange = 90 * 24 * time.Hour
+)
+
+type IPriceHandler interface {
+	SnapshotPrice(ctx context.Context) error
+}
+
+// SnapshotPrice record current KAI price and refresh cached market info, so price history doesn't depend on API
+// traffic. Gaps since the latest recorded price, e.g. downtime or a fresh database, are backfilled
+func (h *handler) SnapshotPrice(ctx context.Context) error {
+	lgr := h.logger.With(zap.String("method", "SnapshotPrice"))
+	tokenInfo, err := h.price.TokenInfo(ctx)
+	if err != nil {
+		lgr.Error("cannot get token info from price providers", zap.Error(err))
+		return err
+	}
+	if err := h.cache.UpdateTokenInfo(ctx, tokenInfo); err != nil {
+		lgr.Warn("cannot update token info in cache", zap.Error(err))
+	}
+
+	now := time.Now()
+	latest, err := h.db.LatestPricePoint(ctx, price.Symbol, price.Currency)
+	if err != nil {
+		lgr.Warn("cannot get latest price point", zap.Error(err))
+	} else if latest == nil || now.Sub(latest.Time) > priceHistoryGap {
+		from := now.Add(-priceBackfillRange)
+		if latest != nil && latest.Time.After(from) {
+			from = latest.Time
+		}
+		h.backfillPrices(ctx, from, now)
+	}
+
+	point := &types.PricePoint{Symbol: price.Symbol, Currency: price.Currency, Price: tokenInfo.Price, Time: now}
+	if err := h.db.InsertPricePoint(ctx, point); err != nil {
+		lgr.Error("cannot insert price point", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (h *handler) backfillPrices(ctx context.Context, from, to time.Time) {
+	lgr := h.logger.With(zap.String("method", "backfillPrices"))
+	points, err := h.price.PriceHistory(ctx, from, to)
+	if err != nil {
+		lgr.Warn("cannot get price history from price providers", zap.Error(err))
+		return
+	}
+	if err := h.db.UpsertPricePoints(ctx, points); err != nil {
+		lgr.Warn("cannot upsert price points", zap.Error(err))
+		return
+	}
+	lgr.Info("Backfilled price history", zap.Time("from", from), zap.Int("points", len(points)))
+}
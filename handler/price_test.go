@@ -0,0 +1,91 @@
+// Package handler
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/cache"
+	"github.com/kardiachain/kardia-explorer-backend/db"
+	"github.com/kardiachain/kardia-explorer-backend/price"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+type priceFakeDB struct {
+	db.Client
+	latest   *types.PricePoint
+	inserted []*types.PricePoint
+	upserted []*types.PricePoint
+}
+
+func (d *priceFakeDB) LatestPricePoint(ctx context.Context, symbol, currency string) (*types.PricePoint, error) {
+	return d.latest, nil
+}
+
+func (d *priceFakeDB) InsertPricePoint(ctx context.Context, point *types.PricePoint) error {
+	d.inserted = append(d.inserted, point)
+	return nil
+}
+
+func (d *priceFakeDB) UpsertPricePoints(ctx context.Context, points []*types.PricePoint) error {
+	d.upserted = append(d.upserted, points...)
+	return nil
+}
+
+type priceFakeCache struct {
+	cache.Client
+	info *types.TokenInfo
+}
+
+func (c *priceFakeCache) UpdateTokenInfo(ctx context.Context, tokenInfo *types.TokenInfo) error {
+	c.info = tokenInfo
+	return nil
+}
+
+func Test_SnapshotPrice(t *testing.T) {
+	now := time.Now()
+	history := []*types.PricePoint{
+		{Symbol: price.Symbol, Currency: price.Currency, Price: 0.04, Time: now.Add(-3 * time.Hour)},
+		{Symbol: price.Symbol, Currency: price.Currency, Price: 0.045, Time: now.Add(-2 * time.Hour)},
+	}
+	srv := price.NewStubServer(&types.TokenInfo{Symbol: "KAI", Price: 0.05}, history)
+	defer srv.Close()
+	down := price.NewStubServer(nil, nil)
+	defer down.Close()
+
+	tests := []struct {
+		name       string
+		url        string
+		latest     *types.PricePoint
+		wantErr    bool
+		backfilled int
+	}{
+		{name: "empty history is backfilled", url: srv.URL, backfilled: len(history)},
+		{name: "gap is backfilled", url: srv.URL, latest: &types.PricePoint{Time: now.Add(-priceHistoryGap - time.Minute)}, backfilled: len(history)},
+		{name: "recent price is not backfilled", url: srv.URL, latest: &types.PricePoint{Time: now.Add(-time.Hour)}},
+		{name: "providers are down", url: down.URL, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB, fakeCache := &priceFakeDB{latest: tt.latest}, &priceFakeCache{}
+			h := &handler{db: fakeDB, cache: fakeCache, price: price.NewStub(tt.url), logger: zap.NewNop()}
+			err := h.SnapshotPrice(context.Background())
+			assert.Len(t, fakeDB.upserted, tt.backfilled)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, fakeDB.inserted)
+				assert.Nil(t, fakeCache.info)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, fakeDB.inserted, 1)
+			assert.Equal(t, 0.05, fakeDB.inserted[0].Price)
+			assert.Equal(t, price.Symbol, fakeDB.inserted[0].Symbol)
+			assert.Equal(t, 0.05, fakeCache.info.Price)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Package handler
+package handler
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/db"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+type IRewardHandler interface {
+	SnapshotRewards(ctx context.Context) error
+}
+
+// SnapshotRewards take a snapshot of staked amount and claimable reward of all delegations
+func (h *handler) SnapshotRewards(ctx context.Context) error {
+	lgr := h.logger.With(zap.String("method", "SnapshotRewards"))
+	validators, err := h.db.Validators(ctx, db.ValidatorsFilter{})
+	if err != nil {
+		lgr.Error("cannot load validators from storage", zap.Error(err))
+		return err
+	}
+	snapshotTime := time.Now()
+	for _, v := range validators {
+		if err := h.snapshotValidatorRewards(ctx, v.SmcAddress, snapshotTime); err != nil {
+			lgr.Warn("cannot snapshot rewards", zap.String("validator", v.SmcAddress), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (h *handler) snapshotValidatorRewards(ctx context.Context, validatorSMCAddress string, snapshotTime time.Time) error {
+	delegators, err := h.w.DelegatorsWithWorker(ctx, validatorSMCAddress)
+	if err != nil {
+		return err
+	}
+	prevSnapshots, err := h.db.LatestRewardSnapshots(ctx, validatorSMCAddress)
+	if err != nil {
+		return err
+	}
+	prevRewards := make(map[string]*big.Int)
+	prevTime := time.Unix(0, 0)
+	for _, s := range prevSnapshots {
+		reward, ok := new(big.Int).SetString(s.Reward, 10)
+		if !ok {
+			continue
+		}
+		prevRewards[common.HexToAddress(s.DelegatorAddress).Hex()] = reward
+		prevTime = s.Time
+	}
+	withdrawn, err := h.db.WithdrawnRewards(ctx, validatorSMCAddress, prevTime, snapshotTime)
+	if err != nil {
+		return err
+	}
+
+	var snapshots []*types.RewardSnapshot
+	for _, d := range delegators {
+		address := common.HexToAddress(d.Address).Hex()
+		reward, ok := new(big.Int).SetString(d.Reward, 10)
+		if !ok {
+			reward = big.NewInt(0)
+		}
+		withdrawnReward, ok := withdrawn[address]
+		if !ok {
+			withdrawnReward = big.NewInt(0)
+		}
+		// first snapshot of delegation has nothing to compare with
+		earned := big.NewInt(0)
+		if prevReward, ok := prevRewards[address]; ok {
+			earned = new(big.Int).Sub(new(big.Int).Add(reward, withdrawnReward), prevReward)
+			if earned.Sign() < 0 {
+				earned = big.NewInt(0)
+			}
+		}
+		snapshots = append(snapshots, &types.RewardSnapshot{
+			ValidatorSMCAddress: validatorSMCAddress,
+			DelegatorAddress:    address,
+			StakedAmount:        d.StakedAmount,
+			Reward:              reward.String(),
+			WithdrawnReward:     withdrawnReward.String(),
+			EarnedReward:        earned.String(),
+			Time:                snapshotTime,
+		})
+	}
+	return h.db.InsertRewardSnapshots(ctx, snapshots)
+}
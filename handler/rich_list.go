@@ -0,0 +1,182 @@
+// Package handler
+package handler
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/cfg"
+	"github.com/kardiachain/kardia-explorer-backend/db"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+	"github.com/kardiachain/kardia-explorer-backend/utils"
+)
+
+const (
+	richListSize = 1000
+	// balances in storage are only updated when addresses are active, more candidates are refreshed
+	// so stale ones right below the list can move in
+	richListCandidates = 1200
+	richListRetention  = 30 * 24 * time.Hour
+)
+
+type IRichListHandler interface {
+	RefreshRichList(ctx context.Context) error
+}
+
+// RefreshRichList refresh balances of richest addresses from RPC, then rank them and compare with daily snapshots
+func (h *handler) RefreshRichList(ctx context.Context) error {
+	lgr := h.logger.With(zap.String("method", "RefreshRichList"))
+	addrs, err := h.db.GetListAddresses(ctx, -1, &types.Pagination{Limit: richListCandidates})
+	if err != nil {
+		lgr.Error("cannot load addresses", zap.Error(err))
+		return err
+	}
+	var changed []*types.Address
+	for _, addr := range addrs {
+		balance, err := h.kaiClient.GetBalance(ctx, addr.Address)
+		if err != nil {
+			lgr.Warn("cannot get balance from RPC", zap.String("address", addr.Address), zap.Error(err))
+			continue
+		}
+		if balance != addr.BalanceString {
+			addr.BalanceString = balance
+			changed = append(changed, addr)
+		}
+		addr.BalanceFloat = utils.BalanceToFloat(balance)
+	}
+	if err := h.db.UpdateAddresses(ctx, changed); err != nil {
+		lgr.Warn("cannot update balances", zap.Error(err))
+	}
+
+	var supply float64
+	if cirSup, err := h.kaiClient.GetCirculatingSupply(ctx); err == nil {
+		supply = utils.BalanceToFloat(cirSup.String())
+	} else {
+		lgr.Warn("cannot get circulating supply from RPC", zap.Error(err))
+	}
+	tags, names := h.knownEntities(ctx)
+
+	now := time.Now().UTC()
+	day := 24 * time.Hour
+	today := now.Truncate(day)
+	prev24h, err := h.richListSnapshots(ctx, today.Add(-day))
+	if err != nil {
+		lgr.Error("cannot load rich list snapshots", zap.Error(err))
+		return err
+	}
+	prev7d, err := h.richListSnapshots(ctx, today.Add(-7*day))
+	if err != nil {
+		lgr.Error("cannot load rich list snapshots", zap.Error(err))
+		return err
+	}
+
+	entries := rankRichList(addrs, supply, tags, names, prev24h, prev7d, now)
+	if err := h.db.UpsertRichList(ctx, entries); err != nil {
+		lgr.Error("cannot update rich list", zap.Error(err))
+		return err
+	}
+	snapshots := make([]*types.RichListSnapshot, len(entries))
+	for i, e := range entries {
+		snapshots[i] = &types.RichListSnapshot{
+			Day:          today.Unix(),
+			Address:      e.Address,
+			Rank:         e.Rank,
+			BalanceFloat: e.BalanceFloat,
+			ExpireAt:     today.Add(richListRetention),
+		}
+	}
+	if err := h.db.UpsertRichListSnapshots(ctx, snapshots); err != nil {
+		lgr.Error("cannot update rich list snapshots", zap.Error(err))
+		return err
+	}
+	lgr.Info("Refreshed rich list", zap.Int("size", len(entries)), zap.Int("balancesChanged", len(changed)))
+	return nil
+}
+
+func (h *handler) richListSnapshots(ctx context.Context, day time.Time) (map[string]*types.RichListSnapshot, error) {
+	snapshots, err := h.db.RichListSnapshots(ctx, day.Unix())
+	if err != nil {
+		return nil, err
+	}
+	byAddress := make(map[string]*types.RichListSnapshot, len(snapshots))
+	for _, s := range snapshots {
+		byAddress[s.Address] = s
+	}
+	return byAddress, nil
+}
+
+// knownEntities return tags of system contracts, validators and configured exchanges, with names of validators
+func (h *handler) knownEntities(ctx context.Context) (map[string]string, map[string]string) {
+	tags := map[string]string{
+		common.HexToAddress(cfg.StakingContractAddr).String():  types.AddressTagStaking,
+		common.HexToAddress(cfg.TreasuryContractAddr).String(): types.AddressTagTreasury,
+	}
+	names := make(map[string]string)
+	for _, addr := range h.exchanges {
+		tags[common.HexToAddress(addr).String()] = types.AddressTagExchange
+	}
+	validators, err := h.db.Validators(ctx, db.ValidatorsFilter{})
+	if err != nil {
+		h.logger.Warn("cannot load validators", zap.Error(err))
+		return tags, names
+	}
+	for _, v := range validators {
+		for _, addr := range []string{v.SmcAddress, v.Address} {
+			addr = common.HexToAddress(addr).String()
+			tags[addr] = types.AddressTagValidator
+			names[addr] = v.Name
+		}
+	}
+	return tags, names
+}
+
+// rankRichList sort addresses by refreshed balance and keep the richest ones. Names set by admins take
+// precedence over names of validators
+func rankRichList(addrs []*types.Address, supply float64, tags, names map[string]string,
+	prev24h, prev7d map[string]*types.RichListSnapshot, now time.Time) []*types.RichListEntry {
+	sorted := make([]*types.Address, len(addrs))
+	copy(sorted, addrs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].BalanceFloat > sorted[j].BalanceFloat
+	})
+	if len(sorted) > richListSize {
+		sorted = sorted[:richListSize]
+	}
+
+	entries := make([]*types.RichListEntry, len(sorted))
+	for i, addr := range sorted {
+		e := &types.RichListEntry{
+			Rank:          uint64(i + 1),
+			Address:       addr.Address,
+			Name:          addr.Name,
+			IsContract:    addr.IsContract,
+			Tag:           tags[addr.Address],
+			BalanceString: addr.BalanceString,
+			BalanceFloat:  addr.BalanceFloat,
+			UpdatedAt:     now,
+		}
+		if e.Name == "" {
+			e.Name = names[addr.Address]
+		}
+		if supply > 0 {
+			e.SupplyPercentage = addr.BalanceFloat / supply * 100
+		}
+		e.RankChange24h, e.BalanceChange24h = richListChange(e, prev24h[addr.Address])
+		e.RankChange7d, e.BalanceChange7d = richListChange(e, prev7d[addr.Address])
+		entries[i] = e
+	}
+	return entries
+}
+
+func richListChange(e *types.RichListEntry, prev *types.RichListSnapshot) (*int64, *float64) {
+	if prev == nil {
+		return nil, nil
+	}
+	rank := int64(prev.Rank) - int64(e.Rank)
+	balance := e.BalanceFloat - prev.BalanceFloat
+	return &rank, &balance
+}
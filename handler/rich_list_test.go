@@ -0,0 +1,115 @@
+// Package handler
+package handler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_rankRichList(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	addrs := []*types.Address{
+		{Address: "0x1", BalanceFloat: 10},
+		{Address: "0x2", BalanceFloat: 30, Name: "Admin name"},
+		{Address: "0x3", BalanceFloat: 20},
+	}
+	many := make([]*types.Address, richListSize+5)
+	for i := range many {
+		many[i] = &types.Address{Address: fmt.Sprintf("0x%d", i), BalanceFloat: float64(i)}
+	}
+	tests := []struct {
+		name      string
+		addrs     []*types.Address
+		supply    float64
+		wantOrder []string
+		wantShare float64 // of first entry
+		wantSize  int
+	}{
+		{
+			name:      "sorted by balance",
+			addrs:     addrs,
+			supply:    120,
+			wantOrder: []string{"0x2", "0x3", "0x1"},
+			wantShare: 25,
+			wantSize:  3,
+		},
+		{
+			name:      "zero supply has no share",
+			addrs:     addrs,
+			wantOrder: []string{"0x2", "0x3", "0x1"},
+			wantSize:  3,
+		},
+		{
+			name:      "cut at rich list size",
+			addrs:     many,
+			supply:    1,
+			wantOrder: []string{fmt.Sprintf("0x%d", len(many)-1)},
+			wantShare: float64(len(many)-1) * 100,
+			wantSize:  richListSize,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names := map[string]string{"0x2": "Validator name", "0x3": "Validator"}
+			tags := map[string]string{"0x3": types.AddressTagValidator}
+			entries := rankRichList(tt.addrs, tt.supply, tags, names, nil, nil, now)
+			assert.Len(t, entries, tt.wantSize)
+			for i, address := range tt.wantOrder {
+				assert.Equal(t, address, entries[i].Address)
+				assert.Equal(t, uint64(i+1), entries[i].Rank)
+			}
+			assert.Equal(t, tt.wantShare, entries[0].SupplyPercentage)
+			assert.Equal(t, now, entries[0].UpdatedAt)
+		})
+	}
+
+	entries := rankRichList(addrs, 120, map[string]string{"0x3": types.AddressTagValidator},
+		map[string]string{"0x2": "Validator name", "0x3": "Validator"}, nil, nil, now)
+	assert.Equal(t, "Admin name", entries[0].Name)
+	assert.Equal(t, "Validator", entries[1].Name)
+	assert.Equal(t, types.AddressTagValidator, entries[1].Tag)
+	assert.Nil(t, entries[0].RankChange24h)
+	assert.Nil(t, entries[0].BalanceChange7d)
+
+	prev := map[string]*types.RichListSnapshot{"0x1": {Address: "0x1", Rank: 1, BalanceFloat: 40}}
+	entries = rankRichList(addrs, 120, nil, nil, prev, nil, now)
+	assert.Equal(t, int64(-2), *entries[2].RankChange24h)
+	assert.Equal(t, float64(-30), *entries[2].BalanceChange24h)
+	assert.Nil(t, entries[2].RankChange7d)
+	assert.Nil(t, entries[0].RankChange24h)
+}
+
+func Test_richListChange(t *testing.T) {
+	e := &types.RichListEntry{Rank: 3, BalanceFloat: 50}
+	tests := []struct {
+		name        string
+		prev        *types.RichListSnapshot
+		wantRank    *int64
+		wantBalance *float64
+	}{
+		{name: "no previous snapshot"},
+		{name: "moved up", prev: &types.RichListSnapshot{Rank: 5, BalanceFloat: 20}, wantRank: int64Ptr(2), wantBalance: float64Ptr(30)},
+		{name: "moved down", prev: &types.RichListSnapshot{Rank: 1, BalanceFloat: 60}, wantRank: int64Ptr(-2), wantBalance: float64Ptr(-10)},
+		{name: "unchanged", prev: &types.RichListSnapshot{Rank: 3, BalanceFloat: 50}, wantRank: int64Ptr(0), wantBalance: float64Ptr(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rank, balance := richListChange(e, tt.prev)
+			assert.Equal(t, tt.wantRank, rank)
+			assert.Equal(t, tt.wantBalance, balance)
+		})
+	}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,196 @@
+// Package handler
+package handler
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/kardiachain/go-kaiclient/kardia"
+	"github.com/kardiachain/go-kardia/lib/common"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/cfg"
+	"github.com/kardiachain/kardia-explorer-backend/db"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+	"github.com/kardiachain/kardia-explorer-backend/utils"
+)
+
+type ISlashHandler interface {
+	SyncSlashEvents(ctx context.Context) error
+	SubscribeSlashEvent(ctx context.Context) error
+}
+
+// SyncSlashEvents reload slash events of all validators
+func (h *handler) SyncSlashEvents(ctx context.Context) error {
+	lgr := h.logger.With(zap.String("method", "SyncSlashEvents"))
+	validators, err := h.db.Validators(ctx, db.ValidatorsFilter{})
+	if err != nil {
+		lgr.Error("cannot load validators from storage", zap.Error(err))
+		return err
+	}
+	for _, v := range validators {
+		if err := h.syncValidatorSlashEvents(ctx, v); err != nil {
+			lgr.Warn("cannot sync slash events", zap.String("validator", v.Address), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// SubscribeSlashEvent listen to jail and slash logs from validator SMCs
+func (h *handler) SubscribeSlashEvent(ctx context.Context) error {
+	lgr := h.logger.With(zap.String("method", "SubscribeSlashEvent"))
+	validators, err := h.db.Validators(ctx, db.ValidatorsFilter{})
+	if err != nil {
+		lgr.Error("cannot load validators from storage", zap.Error(err))
+		return err
+	}
+	validatorMap := make(map[string]*types.Validator)
+	var validatorSMCAddresses []string
+	for _, v := range validators {
+		smcAddress := common.HexToAddress(v.SmcAddress).Hex()
+		validatorMap[smcAddress] = v
+		validatorSMCAddresses = append(validatorSMCAddresses, smcAddress)
+	}
+
+	args := kardia.FilterArgs{Address: validatorSMCAddresses}
+	eventLogCh := make(chan *kardia.FilterLogs)
+	sub, err := h.w.WSNode().KaiSubscribe(ctx, eventLogCh, "logs", args)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-sub.Err():
+			lgr.Error("Subscribe error", zap.Error(err))
+		case l := <-eventLogCh:
+			if len(l.Topics) == 0 {
+				continue
+			}
+			if l.Topics[0] != cfg.ValidatorSlashedTopic && l.Topics[0] != cfg.ValidatorLivenessTopic {
+				continue
+			}
+			v, ok := validatorMap[common.HexToAddress(l.Address).Hex()]
+			if !ok {
+				continue
+			}
+			lgr.Info("Validator slashed", zap.String("validator", v.Address), zap.String("topic", l.Topics[0]))
+			h.reloadValidator(ctx, v.SmcAddress)
+			if err := h.syncValidatorSlashEvents(ctx, v); err != nil {
+				lgr.Warn("cannot sync slash events", zap.String("validator", v.Address), zap.Error(err))
+			}
+		}
+	}
+}
+
+func (h *handler) syncValidatorSlashEvents(ctx context.Context, v *types.Validator) error {
+	events, err := h.kaiClient.GetSlashEvents(ctx, common.HexToAddress(v.Address))
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	delegators, err := h.db.Delegators(ctx, db.DelegatorFilter{ValidatorSMCAddress: v.SmcAddress})
+	if err != nil {
+		return err
+	}
+	stored, _, err := h.db.SlashEvents(ctx, &types.SlashEventsFilter{ValidatorAddress: v.Address})
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(stored))
+	for _, e := range stored {
+		known[fmt.Sprintf("%d-%s", e.Height, e.Period)] = true
+	}
+
+	now := time.Now().Unix()
+	var slashEvents []*types.SlashEvent
+	for _, e := range events {
+		height := utils.StrToUint64(e.Height)
+		if known[fmt.Sprintf("%d-%s", height, e.Period)] || height == 0 {
+			continue
+		}
+		// delegators lose a fraction of what they staked right before the slash, not of their current stake
+		totalSlashed := big.NewInt(0)
+		var (
+			slashedDelegators []*types.SlashedDelegator
+			stakeErr          error
+		)
+		candidates, err := h.slashCandidates(ctx, v, height, delegators)
+		if err != nil {
+			h.logger.Warn("cannot get unbonding delegators", zap.String("validator", v.Address), zap.Error(err))
+			continue
+		}
+		for _, address := range candidates {
+			stake, err := h.kaiClient.GetDelegatorStakedAmountAt(ctx, common.HexToAddress(v.SmcAddress), common.HexToAddress(address), height-1)
+			if err != nil {
+				stakeErr = err
+				break
+			}
+			if stake == nil || stake.Sign() == 0 {
+				continue
+			}
+			amountLost, err := utils.CalculateSlashedAmount(stake.String(), e.Fraction)
+			if err != nil {
+				continue
+			}
+			lost, _ := new(big.Int).SetString(amountLost, 10)
+			totalSlashed = new(big.Int).Add(totalSlashed, lost)
+			slashedDelegators = append(slashedDelegators, &types.SlashedDelegator{
+				Address:      address,
+				StakedAmount: stake.String(),
+				AmountLost:   amountLost,
+			})
+		}
+		if stakeErr != nil {
+			// try again on next sync instead of storing wrong amounts, events are only inserted once
+			h.logger.Warn("cannot get stake at slash height", zap.String("validator", v.Address),
+				zap.Uint64("height", height), zap.Error(stakeErr))
+			continue
+		}
+		slashEvents = append(slashEvents, &types.SlashEvent{
+			ValidatorAddress:    v.Address,
+			ValidatorSMCAddress: v.SmcAddress,
+			ValidatorName:       v.Name,
+			Period:              e.Period,
+			Fraction:            e.Fraction,
+			Height:              height,
+			TotalSlashedAmount:  totalSlashed.String(),
+			Delegators:          slashedDelegators,
+			UpdateTime:          now,
+		})
+	}
+	return h.db.InsertSlashEvents(ctx, slashEvents)
+}
+
+// slashCandidates return current delegators and delegators which undelegated since the slash, they are still
+// unbonding at that time. Delegators without stake at slash height are skipped by caller
+func (h *handler) slashCandidates(ctx context.Context, v *types.Validator, height uint64, delegators []*types.Delegator) ([]string, error) {
+	var slashTime int64
+	if block, err := h.db.BlockByHeight(ctx, height); err == nil {
+		slashTime = block.Time.Unix()
+	}
+	unbonding, err := h.db.UnbondingDelegators(ctx, v.SmcAddress, slashTime)
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]string, 0, len(delegators)+len(unbonding))
+	for _, d := range delegators {
+		addresses = append(addresses, d.Address)
+	}
+	seen := make(map[string]bool, len(addresses))
+	var candidates []string
+	for _, address := range append(addresses, unbonding...) {
+		address = common.HexToAddress(address).Hex()
+		if !seen[address] {
+			seen[address] = true
+			candidates = append(candidates, address)
+		}
+	}
+	return candidates, nil
+}
@@ -125,6 +125,7 @@ func (h *handler) onInteractWithValidators(ctx context.Context, tx *kardia.Trans
 	lgr := h.logger.With(zap.String("method", "onInteractWithValidators"))
 	h.reloadValidator(ctx, tx.To)
 	h.reloadDelegator(ctx, tx.To, tx.From)
+	h.reloadUnbondingEntries(ctx, tx.To, tx.From)
 
 	nProposerAddresses, err := h.w.TrustedNode().ValidatorSets(ctx)
 	if err != nil {
@@ -140,6 +141,12 @@ func (h *handler) onInteractWithValidators(ctx context.Context, tx *kardia.Trans
 		lgr.Error("cannot update proposers list", zap.Error(err))
 		return
 	}
+	validators, err := h.db.Validators(ctx, db.ValidatorsFilter{})
+	if err != nil {
+		lgr.Error("cannot load validators from storage", zap.Error(err))
+		return
+	}
+	h.recordValidatorChanges(ctx, validators)
 }
 
 func (h *handler) reloadProposer(ctx context.Context, proposerAddress string) error {
@@ -187,12 +194,44 @@ func (h *handler) reloadValidator(ctx context.Context, validatorSMCAddress strin
 		if err := h.db.RemoveValidator(ctx, v.SmcAddress); err != nil {
 			lgr.Error("cannot remove validator", zap.Error(err))
 		}
+		h.refreshAutocompleteEntry(ctx, v.SmcAddress, nil)
 		return
 	} else {
 		if err := h.db.UpsertValidator(ctx, v); err != nil {
 			lgr.Error("cannot upsert validator", zap.Error(err))
 			return
 		}
+		h.recordValidatorChanges(ctx, []*types.Validator{v})
+		h.refreshAutocompleteEntry(ctx, v.SmcAddress, v)
+	}
+}
+
+// refreshAutocompleteEntry rebuild entry of validator SMC address with its address and contract info,
+// so names merged into the same entry are kept
+func (h *handler) refreshAutocompleteEntry(ctx context.Context, address string, val *types.Validator) {
+	addr, _ := h.db.AddressByHash(ctx, address)
+	smc, _, _ := h.db.Contract(ctx, address)
+	var err error
+	if e := types.NewAutocompleteEntry(addr, smc, val); e != nil {
+		err = h.cache.UpsertAutocompleteEntries(ctx, []*types.AutocompleteEntry{e})
+	} else {
+		err = h.cache.RemoveAutocompleteEntry(ctx, address)
+	}
+	if err != nil {
+		h.logger.Warn("Cannot refresh autocomplete entry", zap.String("address", address), zap.Error(err))
+	}
+}
+
+// recordValidatorChanges keep track of validators info changes, latest block height is close enough
+// since watcher process new block right after it's created
+func (h *handler) recordValidatorChanges(ctx context.Context, validators []*types.Validator) {
+	lgr := h.logger.With(zap.String("method", "recordValidatorChanges"))
+	height, err := h.kaiClient.LatestBlockNumber(ctx)
+	if err != nil {
+		lgr.Warn("cannot get latest block number", zap.Error(err))
+	}
+	if err := h.db.RecordValidatorChanges(ctx, validators, height); err != nil {
+		lgr.Error("cannot record validator changes", zap.Error(err))
 	}
 }
 
@@ -0,0 +1,93 @@
+// Package handler
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/db"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+type IUnbondingHandler interface {
+	SyncUnbondingEntries(ctx context.Context) error
+}
+
+const unbondingSeedBatchSize = 1000
+
+// SyncUnbondingEntries refresh delegations which have unbonding entries in storage, to pick up
+// withdrawals and slashing. New entries are added when delegator interact with validator SMC
+func (h *handler) SyncUnbondingEntries(ctx context.Context) error {
+	lgr := h.logger.With(zap.String("method", "SyncUnbondingEntries"))
+	entries, err := h.db.UnbondingEntries(ctx, "")
+	if err != nil {
+		lgr.Error("cannot load unbonding entries from storage", zap.Error(err))
+		return err
+	}
+	if len(entries) == 0 && !h.unbondingSeeded {
+		return h.seedUnbondingEntries(ctx)
+	}
+	synced := make(map[string]bool)
+	for _, e := range entries {
+		key := e.ValidatorSMCAddress + e.DelegatorAddress
+		if synced[key] {
+			continue
+		}
+		synced[key] = true
+		h.reloadUnbondingEntries(ctx, e.ValidatorSMCAddress, e.DelegatorAddress)
+	}
+	return nil
+}
+
+// seedUnbondingEntries read entries of every known delegation, so undelegations made before
+// the watcher started are stored too. It only runs once, when storage is empty
+func (h *handler) seedUnbondingEntries(ctx context.Context) error {
+	lgr := h.logger.With(zap.String("method", "seedUnbondingEntries"))
+	for skip := int64(0); ; skip += unbondingSeedBatchSize {
+		delegators, err := h.db.Delegators(ctx, db.DelegatorFilter{Skip: skip, Limit: unbondingSeedBatchSize})
+		if err != nil {
+			lgr.Error("cannot load delegators from storage", zap.Error(err))
+			return err
+		}
+		for _, d := range delegators {
+			h.reloadUnbondingEntries(ctx, d.ValidatorSMCAddress, d.Address)
+		}
+		if len(delegators) < unbondingSeedBatchSize {
+			break
+		}
+	}
+	h.unbondingSeeded = true
+	lgr.Info("Seeded unbonding entries from delegations")
+	return nil
+}
+
+func (h *handler) reloadUnbondingEntries(ctx context.Context, validatorSMCAddress, delegatorAddress string) {
+	lgr := h.logger.With(zap.String("method", "reloadUnbondingEntries"))
+	validatorSMCAddress = common.HexToAddress(validatorSMCAddress).Hex()
+	delegatorAddress = common.HexToAddress(delegatorAddress).Hex()
+	records, err := h.kaiClient.GetUDBEntries(ctx, common.HexToAddress(validatorSMCAddress), common.HexToAddress(delegatorAddress))
+	if err != nil {
+		lgr.Warn("cannot get unbonding entries", zap.String("validator", validatorSMCAddress), zap.String("delegator", delegatorAddress), zap.Error(err))
+		return
+	}
+	now := time.Now().Unix()
+	var entries []*types.UnbondingEntry
+	for _, r := range records {
+		if r.Balance == nil || r.Balance.Sign() == 0 {
+			continue
+		}
+		entries = append(entries, &types.UnbondingEntry{
+			DelegatorAddress:    delegatorAddress,
+			ValidatorSMCAddress: validatorSMCAddress,
+			Amount:              r.Balance.String(),
+			CompletionTime:      r.CompletionTime.Int64(),
+			UpdateTime:          now,
+		})
+	}
+	if err := h.db.ReplaceUnbondingEntries(ctx, validatorSMCAddress, delegatorAddress, entries); err != nil {
+		lgr.Error("cannot update unbonding entries", zap.Error(err))
+	}
+}
@@ -0,0 +1,189 @@
+// Package handler
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+	"github.com/kardiachain/kardia-explorer-backend/utils"
+)
+
+const (
+	webhookMaxAttempts   = 10
+	webhookBaseBackoff   = 30 * time.Second
+	webhookMaxBackoff    = 6 * time.Hour
+	webhookClaimLease    = time.Minute
+	webhookBatchSize     = 100
+	webhookWorkers       = 8
+	webhookClientTimeout = 10 * time.Second
+
+	headerWebhookID        = "X-Webhook-Id"
+	headerWebhookTimestamp = "X-Webhook-Timestamp"
+	headerWebhookSignature = "X-Webhook-Signature"
+)
+
+type IWebhookHandler interface {
+	DeliverWebhooks(ctx context.Context) error
+}
+
+// webhookClient only dials public addresses, targets are checked when watch is created but DNS may change
+// or redirect to internal services later
+var webhookClient = &http.Client{
+	Timeout: webhookClientTimeout,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: webhookClientTimeout,
+			Control: webhookDialControl,
+		}).DialContext,
+		TLSHandshakeTimeout: webhookClientTimeout,
+		MaxIdleConnsPerHost: webhookWorkers,
+	},
+}
+
+func webhookDialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if !utils.IsPublicIP(net.ParseIP(host)) {
+		return fmt.Errorf("webhook target %s is not a public address", host)
+	}
+	return nil
+}
+
+// DeliverWebhooks send due deliveries by a pool of workers, so a slow target doesn't hold up the others.
+// Failed ones are retried with exponential backoff until they're dead
+func (h *handler) DeliverWebhooks(ctx context.Context) error {
+	var (
+		wg       sync.WaitGroup
+		claimed  int64
+		errOnce  sync.Once
+		firstErr error
+	)
+	for i := 0; i < webhookWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := h.deliverWebhookBatch(ctx, &claimed); err != nil {
+				errOnce.Do(func() { firstErr = err })
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// deliverWebhookBatch claim and send deliveries until there is none due or workers claimed a batch in total
+func (h *handler) deliverWebhookBatch(ctx context.Context, claimed *int64) error {
+	lgr := h.logger.With(zap.String("method", "DeliverWebhooks"))
+	watches := make(map[string]*types.WebhookWatch)
+	for atomic.AddInt64(claimed, 1) <= webhookBatchSize {
+		delivery, err := h.db.ClaimWebhookDelivery(ctx, time.Now(), webhookClaimLease)
+		if err != nil {
+			lgr.Error("cannot claim webhook delivery", zap.Error(err))
+			return err
+		}
+		if delivery == nil {
+			return nil
+		}
+		watch, ok := watches[delivery.WatchID]
+		if !ok {
+			watch, err = h.db.WebhookWatch(ctx, delivery.WatchID)
+			if err != nil && err != mongo.ErrNoDocuments {
+				// delivery is claimed again after lease
+				lgr.Warn("cannot get webhook watch", zap.String("watchId", delivery.WatchID), zap.Error(err))
+				continue
+			}
+			watches[delivery.WatchID] = watch
+		}
+		if watch == nil {
+			delivery.Status = types.WebhookStatusDead
+			delivery.LastError = "watch is removed"
+		} else {
+			h.sendWebhook(ctx, watch, delivery)
+		}
+		delivery.UpdatedAt = time.Now()
+		if err := h.db.UpdateWebhookDelivery(ctx, delivery); err != nil {
+			lgr.Error("cannot update webhook delivery", zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (h *handler) sendWebhook(ctx context.Context, watch *types.WebhookWatch, delivery *types.WebhookDelivery) {
+	delivery.Attempts++
+	statusCode, err := postWebhook(ctx, watch, delivery)
+	delivery.LastStatusCode = statusCode
+	if err == nil {
+		delivery.Status = types.WebhookStatusDelivered
+		delivery.LastError = ""
+		return
+	}
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= webhookMaxAttempts {
+		delivery.Status = types.WebhookStatusDead
+		h.logger.Warn("Webhook delivery is dead", zap.String("id", delivery.ID.Hex()), zap.String("url", watch.URL), zap.Error(err))
+		return
+	}
+	delivery.NextAttemptAt = time.Now().Add(webhookBackoff(delivery.Attempts))
+}
+
+func postWebhook(ctx context.Context, watch *types.WebhookWatch, delivery *types.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, watch.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(headerWebhookID, delivery.ID.Hex())
+	req.Header.Set(headerWebhookTimestamp, timestamp)
+	req.Header.Set(headerWebhookSignature, "sha256="+signWebhook(watch.Secret, timestamp, body))
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// signWebhook is HMAC-SHA256 of "timestamp.body", receivers should also reject old timestamps
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookBaseBackoff
+	for i := 1; i < attempts && backoff < webhookMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > webhookMaxBackoff {
+		backoff = webhookMaxBackoff
+	}
+	return backoff
+}
@@ -20,6 +20,7 @@ package kardia
 
 import (
 	"context"
+	"encoding/json"
 	"math/big"
 
 	kai "github.com/kardiachain/go-kardia"
@@ -47,9 +48,12 @@ type ClientInterface interface {
 	GetValidatorsByDelegator(ctx context.Context, delAddr common.Address) ([]*types.ValidatorsByDelegator, error)
 	GetTotalSlashedToken(ctx context.Context) (*big.Int, error)
 	GetCirculatingSupply(ctx context.Context) (*big.Int, error)
+	GetValidatorSetsAtHeight(ctx context.Context, height uint64) ([]common.Address, []*big.Int, error)
 
 	// validator related methods
 	GetSlashEvents(ctx context.Context, valAddr common.Address) ([]*types.SlashEvents, error)
+	GetUDBEntries(ctx context.Context, valSmcAddr common.Address, delegatorAddr common.Address) ([]*UnbondedRecord, error)
+	GetDelegatorStakedAmountAt(ctx context.Context, valSmcAddr common.Address, delegatorAddr common.Address, height uint64) (*big.Int, error)
 
 	// params related methods
 	GetMaxProposers(ctx context.Context) (int64, error)
@@ -63,6 +67,7 @@ type ClientInterface interface {
 	KardiaCall(ctx context.Context, args types.CallArgsJSON) (common.Bytes, error)
 	DecodeInputWithABI(to string, input string, smcABI *abi.ABI) (*types.FunctionCall, error)
 	UnpackLog(log *types.Log, a *abi.ABI) (*types.Log, error)
+	Forward(ctx context.Context, method string, params []json.RawMessage, trusted bool) (json.RawMessage, error)
 
 	// KRC-related methods
 	GetKRC20TokenInfo(ctx context.Context, a *abi.ABI, krcTokenAddr common.Address) (*types.KRCTokenInfo, error)
@@ -20,6 +20,7 @@ package kardia
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"math/big"
 	"os"
@@ -265,6 +266,24 @@ func (ec *Client) SendRawTransaction(ctx context.Context, tx string) error {
 	return ec.chooseClient().c.CallContext(ctx, nil, "tx_sendRawTransaction", tx)
 }
 
+// Forward call method with raw params and return raw result, used by JSON-RPC proxy. Trusted calls
+// are sent to our trusted node
+func (ec *Client) Forward(ctx context.Context, method string, params []json.RawMessage, trusted bool) (json.RawMessage, error) {
+	client := ec.chooseClient()
+	if trusted {
+		client = ec.defaultClient
+	}
+	args := make([]interface{}, len(params))
+	for i := range params {
+		args[i] = params[i]
+	}
+	var result json.RawMessage
+	if err := client.c.CallContext(ctx, &result, method, args...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (ec *Client) KardiaCall(ctx context.Context, args types.CallArgsJSON) (common.Bytes, error) {
 	var result common.Bytes
 	err := ec.chooseClient().c.CallContext(ctx, &result, "kai_kardiaCall", args, "latest")
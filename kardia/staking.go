@@ -206,6 +206,34 @@ func (ec *Client) GetValidatorSets(ctx context.Context) ([]common.Address, error
 	return result.ValAddrs, nil
 }
 
+// GetValidatorSetsAtHeight returns proposers set and their voting power at given block height
+func (ec *Client) GetValidatorSetsAtHeight(ctx context.Context, height uint64) ([]common.Address, []*big.Int, error) {
+	payload, err := ec.stakingUtil.Abi.Pack("getValidatorSets")
+	if err != nil {
+		ec.lgr.Error("Error packing proposers list payload: ", zap.Error(err))
+		return nil, nil, err
+	}
+	var res common.Bytes
+	err = ec.chooseClient().c.CallContext(ctx, &res, "kai_kardiaCall", constructCallArgs(ec.stakingUtil.ContractAddress.Hex(), payload), height)
+	if err != nil {
+		ec.lgr.Error("GetValidatorSetsAtHeight KardiaCall error: ", zap.Uint64("height", height), zap.Error(err))
+		return nil, nil, err
+	}
+	if len(res) == 0 {
+		return nil, nil, ErrEmptyList
+	}
+	var result struct {
+		ValAddrs []common.Address
+		Powers   []*big.Int
+	}
+	err = ec.stakingUtil.Abi.UnpackIntoInterface(&result, "getValidatorSets", res)
+	if err != nil {
+		ec.lgr.Error("Error unpacking proposers list error: ", zap.Error(err))
+		return nil, nil, err
+	}
+	return result.ValAddrs, result.Powers, nil
+}
+
 // GetAllValsLength returns number of validators
 func (ec *Client) GetAllValsLength(ctx context.Context) (*big.Int, error) {
 	payload, err := ec.stakingUtil.Abi.Pack("allValsLength")
@@ -208,6 +208,35 @@ func (ec *Client) GetDelegatorStakedAmount(ctx context.Context, valSmcAddr commo
 	return result.Stake, nil
 }
 
+// GetDelegatorStakedAmountAt returns staked amount of a delegator to current validator at given block height
+func (ec *Client) GetDelegatorStakedAmountAt(ctx context.Context, valSmcAddr common.Address, delegatorAddr common.Address, height uint64) (*big.Int, error) {
+	payload, err := ec.validatorUtil.Abi.Pack("delegationByAddr", delegatorAddr)
+	if err != nil {
+		ec.lgr.Error("Error packing delegator staked amount payload: ", zap.Error(err))
+		return nil, err
+	}
+	var res common.Bytes
+	err = ec.chooseClient().c.CallContext(ctx, &res, "kai_kardiaCall", constructCallArgs(valSmcAddr.Hex(), payload), height)
+	if err != nil {
+		ec.lgr.Error("GetDelegatorStakedAmountAt KardiaCall error: ", zap.Uint64("height", height), zap.Error(err))
+		return nil, err
+	}
+
+	var result struct {
+		Stake          *big.Int
+		PreviousPeriod *big.Int
+		Height         *big.Int
+		Shares         *big.Int
+		Owner          common.Address
+	}
+	err = ec.validatorUtil.Abi.UnpackIntoInterface(&result, "delegationByAddr", res)
+	if err != nil {
+		ec.lgr.Error("Error unpacking delegator's staked amount: ", zap.Error(err))
+		return nil, err
+	}
+	return result.Stake, nil
+}
+
 // GetUDBEntry returns unbonded amount and withdrawable amount of a delegation
 func (ec *Client) GetUDBEntries(ctx context.Context, valSmcAddr common.Address, delegatorAddr common.Address) ([]*UnbondedRecord, error) {
 	payload, err := ec.validatorUtil.Abi.Pack("getUBDEntries", delegatorAddr)
@@ -114,3 +114,15 @@ func (p *Provider) GetInvalidBlocks() int64 {
 
 	return p.invalidBlocks
 }
+
+// GetRateLimitCounters return a copy of rate limit counters by budget name
+func (p *Provider) GetRateLimitCounters() map[string]RateLimitCounter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	counters := make(map[string]RateLimitCounter, len(p.rateLimits))
+	for budget, counter := range p.rateLimits {
+		counters[budget] = *counter
+	}
+	return counters
+}
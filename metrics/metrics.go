@@ -35,6 +35,14 @@ type Provider struct {
 	todoLength    int64
 	reorgedBlocks int64
 	invalidBlocks int64
+
+	rateLimits map[string]*RateLimitCounter // by budget name
+}
+
+type RateLimitCounter struct {
+	Allowed int64 `json:"allowed"`
+	Limited int64 `json:"limited"`
+	Errors  int64 `json:"errors"` // requests let through because bucket could not be checked
 }
 
 func New() *Provider {
@@ -51,6 +59,7 @@ func (p *Provider) Reset() {
 	p.todoLength = 0
 	p.reorgedBlocks = 0
 	p.invalidBlocks = 0
+	p.rateLimits = nil
 }
 
 func (p *Provider) RecordInsertBlockTime(duration time.Duration) {
@@ -115,3 +124,33 @@ func (p *Provider) RecordInvalidBlock() {
 
 	p.invalidBlocks++
 }
+
+func (p *Provider) rateLimitCounter(budget string) *RateLimitCounter {
+	if p.rateLimits == nil {
+		p.rateLimits = make(map[string]*RateLimitCounter)
+	}
+	counter, ok := p.rateLimits[budget]
+	if !ok {
+		counter = &RateLimitCounter{}
+		p.rateLimits[budget] = counter
+	}
+	return counter
+}
+
+func (p *Provider) RecordRateLimit(budget string, allowed bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if allowed {
+		p.rateLimitCounter(budget).Allowed++
+	} else {
+		p.rateLimitCounter(budget).Limited++
+	}
+}
+
+func (p *Provider) RecordRateLimitError(budget string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.rateLimitCounter(budget).Errors++
+}
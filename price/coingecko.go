@@ -0,0 +1,91 @@
+// Package price
+package price
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const (
+	coinGeckoURL = "https://api.coingecko.com"
+	coinGeckoID  = "kardiachain"
+)
+
+type cgMarket struct {
+	Name              string  `json:"name"`
+	Symbol            string  `json:"symbol"`
+	CurrentPrice      float64 `json:"current_price"`
+	MarketCap         float64 `json:"market_cap"`
+	TotalVolume       float64 `json:"total_volume"`
+	CirculatingSupply float64 `json:"circulating_supply"`
+	TotalSupply       float64 `json:"total_supply"`
+	Change1h          float64 `json:"price_change_percentage_1h_in_currency"`
+	Change24h         float64 `json:"price_change_percentage_24h_in_currency"`
+	Change7d          float64 `json:"price_change_percentage_7d_in_currency"`
+}
+
+// cgMarketChart prices are pairs of unix milliseconds and price
+type cgMarketChart struct {
+	Prices [][2]float64 `json:"prices"`
+}
+
+type coinGecko struct {
+	url string
+}
+
+// NewCoinGecko create a provider of CoinGecko public API at url
+func NewCoinGecko(url string) Provider {
+	return &coinGecko{url: url}
+}
+
+func (p *coinGecko) Name() string { return string(CoinGeckoAdapter) }
+
+func (p *coinGecko) TokenInfo(ctx context.Context) (*types.TokenInfo, error) {
+	var markets []cgMarket
+	if err := getJSON(ctx, p.url+"/api/v3/coins/markets?vs_currency=usd&ids="+coinGeckoID+"&price_change_percentage=1h,24h,7d",
+		nil, &markets); err != nil {
+		return nil, err
+	}
+	if len(markets) == 0 {
+		return nil, fmt.Errorf("coingecko has no market of coin %s", coinGeckoID)
+	}
+	m := markets[0]
+	return &types.TokenInfo{
+		Name:                   m.Name,
+		Symbol:                 strings.ToUpper(m.Symbol), // coingecko symbols are lowercase
+		Decimal:                18,
+		TotalSupply:            int64(m.TotalSupply),
+		ERC20CirculatingSupply: int64(m.CirculatingSupply),
+		Price:                  m.CurrentPrice,
+		Volume24h:              m.TotalVolume,
+		Change1h:               m.Change1h,
+		Change24h:              m.Change24h,
+		Change7d:               m.Change7d,
+		MarketCap:              m.MarketCap,
+	}, nil
+}
+
+// PriceHistory return prices in range ascending by time, CoinGecko returns hourly prices for ranges up to 90 days
+func (p *coinGecko) PriceHistory(ctx context.Context, from, to time.Time) ([]*types.PricePoint, error) {
+	var chart cgMarketChart
+	url := fmt.Sprintf("%s/api/v3/coins/%s/market_chart/range?vs_currency=usd&from=%s&to=%s", p.url, coinGeckoID,
+		strconv.FormatInt(from.Unix(), 10), strconv.FormatInt(to.Unix(), 10))
+	if err := getJSON(ctx, url, nil, &chart); err != nil {
+		return nil, err
+	}
+	points := make([]*types.PricePoint, len(chart.Prices))
+	for i, price := range chart.Prices {
+		points[i] = &types.PricePoint{
+			Symbol:   Symbol,
+			Currency: Currency,
+			Price:    price[1],
+			Time:     time.Unix(0, int64(price[0])*int64(time.Millisecond)),
+		}
+	}
+	return points, nil
+}
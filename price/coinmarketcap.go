@@ -0,0 +1,88 @@
+// Package price
+package price
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const (
+	coinMarketCapURL = "https://pro-api.coinmarketcap.com"
+	coinMarketCapID  = "5453"
+)
+
+type cmQuote struct {
+	Price            float64 `json:"price"`
+	Volume24h        float64 `json:"volume_24h"`
+	PercentChange1h  float64 `json:"percent_change_1h"`
+	PercentChange24h float64 `json:"percent_change_24h"`
+	PercentChange7d  float64 `json:"percent_change_7d"`
+	MarketCap        float64 `json:"market_cap"`
+}
+
+type cmTokenInfo struct {
+	Name              string             `json:"name"`
+	Symbol            string             `json:"symbol"`
+	CirculatingSupply float64            `json:"circulating_supply"`
+	TotalSupply       float64            `json:"total_supply"`
+	Quote             map[string]cmQuote `json:"quote"`
+}
+
+type cmResponse struct {
+	Status struct {
+		ErrorCode    int    `json:"error_code"`
+		ErrorMessage string `json:"error_message"`
+	} `json:"status"`
+	Data map[string]cmTokenInfo `json:"data"`
+}
+
+type coinMarketCap struct {
+	url    string
+	apiKey string
+}
+
+// NewCoinMarketCap create a provider of CoinMarketCap API at url, history needs a paid plan so it isn't supported
+func NewCoinMarketCap(url, apiKey string) Provider {
+	return &coinMarketCap{url: url, apiKey: apiKey}
+}
+
+func (p *coinMarketCap) Name() string { return string(CoinMarketCapAdapter) }
+
+func (p *coinMarketCap) TokenInfo(ctx context.Context) (*types.TokenInfo, error) {
+	var resp cmResponse
+	if err := getJSON(ctx, p.url+"/v1/cryptocurrency/quotes/latest?id="+coinMarketCapID,
+		map[string]string{"X-CMC_PRO_API_KEY": p.apiKey}, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Status.ErrorCode != 0 {
+		return nil, fmt.Errorf("coinmarketcap error %d: %s", resp.Status.ErrorCode, resp.Status.ErrorMessage)
+	}
+	data, ok := resp.Data[coinMarketCapID]
+	if !ok {
+		return nil, fmt.Errorf("coinmarketcap has no data of coin %s", coinMarketCapID)
+	}
+	quote, ok := data.Quote[Currency]
+	if !ok {
+		return nil, fmt.Errorf("coinmarketcap has no %s quote", Currency)
+	}
+	return &types.TokenInfo{
+		Name:                   data.Name,
+		Symbol:                 data.Symbol,
+		Decimal:                18,
+		TotalSupply:            int64(data.TotalSupply),
+		ERC20CirculatingSupply: int64(data.CirculatingSupply),
+		Price:                  quote.Price,
+		Volume24h:              quote.Volume24h,
+		Change1h:               quote.PercentChange1h,
+		Change24h:              quote.PercentChange24h,
+		Change7d:               quote.PercentChange7d,
+		MarketCap:              quote.MarketCap,
+	}, nil
+}
+
+func (p *coinMarketCap) PriceHistory(ctx context.Context, from, to time.Time) ([]*types.PricePoint, error) {
+	return nil, ErrNoHistory
+}
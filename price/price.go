@@ -0,0 +1,153 @@
+// Package price
+package price
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+type Adapter string
+
+const (
+	CoinMarketCapAdapter Adapter = "coinmarketcap"
+	CoinGeckoAdapter     Adapter = "coingecko"
+	StaticAdapter        Adapter = "static"
+	StubAdapter          Adapter = "stub"
+)
+
+// Symbol and Currency of price points returned by providers
+const (
+	Symbol   = "KAI"
+	Currency = "USD"
+)
+
+var (
+	ErrNoProvider = errors.New("no price provider")
+	ErrNoHistory  = errors.New("price history is not supported")
+)
+
+type Config struct {
+	Providers []string // adapters tried in order until one succeeds
+
+	CoinMarketAPIKey string
+	StaticFile       string // JSON of types.TokenInfo
+	StubURL          string
+
+	Logger *zap.Logger
+}
+
+// Provider return market info of KAI, providers without history return ErrNoHistory from PriceHistory
+type Provider interface {
+	Name() string
+	TokenInfo(ctx context.Context) (*types.TokenInfo, error)
+	PriceHistory(ctx context.Context, from, to time.Time) ([]*types.PricePoint, error)
+}
+
+// New create a provider which fails over configured providers in order. CoinMarketCap is skipped without an API key
+func New(cfg Config) (Provider, error) {
+	var providers []Provider
+	for _, adapter := range cfg.Providers {
+		switch Adapter(strings.TrimSpace(adapter)) {
+		case CoinMarketCapAdapter:
+			if cfg.CoinMarketAPIKey == "" {
+				cfg.Logger.Warn("CoinMarketCap API key is not set, skip provider")
+				continue
+			}
+			providers = append(providers, NewCoinMarketCap(coinMarketCapURL, cfg.CoinMarketAPIKey))
+		case CoinGeckoAdapter:
+			providers = append(providers, NewCoinGecko(coinGeckoURL))
+		case StaticAdapter:
+			providers = append(providers, NewStatic(cfg.StaticFile))
+		case StubAdapter:
+			providers = append(providers, NewStub(cfg.StubURL))
+		default:
+			return nil, fmt.Errorf("unknown price provider %s", adapter)
+		}
+	}
+	return NewFailover(cfg.Logger, providers...), nil
+}
+
+type failover struct {
+	providers []Provider
+	logger    *zap.Logger
+}
+
+// NewFailover create a provider which asks providers in order and return the first success
+func NewFailover(logger *zap.Logger, providers ...Provider) Provider {
+	return &failover{providers: providers, logger: logger}
+}
+
+func (f *failover) Name() string {
+	names := make([]string, len(f.providers))
+	for i, p := range f.providers {
+		names[i] = p.Name()
+	}
+	return strings.Join(names, ",")
+}
+
+func (f *failover) TokenInfo(ctx context.Context) (*types.TokenInfo, error) {
+	err := ErrNoProvider
+	for _, p := range f.providers {
+		var info *types.TokenInfo
+		if info, err = p.TokenInfo(ctx); err == nil {
+			return info, nil
+		}
+		f.logger.Warn("Cannot get token info from price provider", zap.String("provider", p.Name()), zap.Error(err))
+	}
+	return nil, err
+}
+
+func (f *failover) PriceHistory(ctx context.Context, from, to time.Time) ([]*types.PricePoint, error) {
+	err := ErrNoHistory
+	for _, p := range f.providers {
+		points, pErr := p.PriceHistory(ctx, from, to)
+		if pErr == nil {
+			return points, nil
+		}
+		if pErr != ErrNoHistory {
+			f.logger.Warn("Cannot get price history from price provider", zap.String("provider", p.Name()), zap.Error(pErr))
+			err = pErr
+		}
+	}
+	return nil, err
+}
+
+var httpClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		Dial: (&net.Dialer{
+			Timeout: 5 * time.Second,
+		}).Dial,
+		TLSHandshakeTimeout: 5 * time.Second,
+	},
+}
+
+// getJSON decode body of a successful GET into out
+func getJSON(ctx context.Context, url string, header map[string]string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,80 @@
+// Package price
+package price
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+var stubInfo = &types.TokenInfo{
+	Name:                   "KardiaChain",
+	Symbol:                 "KAI",
+	Decimal:                18,
+	TotalSupply:            5000000000,
+	ERC20CirculatingSupply: 1000000000,
+	Price:                  0.05,
+	Volume24h:              120000,
+	Change24h:              -1.5,
+	MarketCap:              50000000,
+}
+
+func Test_providers(t *testing.T) {
+	srv := NewStubServer(stubInfo, nil)
+	defer srv.Close()
+	for _, p := range []Provider{NewStub(srv.URL), NewCoinMarketCap(srv.URL, "key"), NewCoinGecko(srv.URL)} {
+		t.Run(p.Name(), func(t *testing.T) {
+			info, err := p.TokenInfo(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, stubInfo, info)
+		})
+	}
+
+	_, err := NewCoinMarketCap(srv.URL, "").TokenInfo(context.Background())
+	assert.EqualError(t, err, "coinmarketcap error 1002: API key missing.")
+}
+
+func Test_failover(t *testing.T) {
+	down := NewStubServer(nil, nil)
+	defer down.Close()
+	now := time.Unix(1614556800, 0)
+	history := []*types.PricePoint{
+		{Symbol: Symbol, Currency: Currency, Price: 0.04, Time: now.Add(-time.Hour)},
+		{Symbol: Symbol, Currency: Currency, Price: 0.05, Time: now},
+	}
+	up := NewStubServer(stubInfo, history)
+	defer up.Close()
+
+	p := NewFailover(zap.NewNop(), NewStub(down.URL), NewCoinMarketCap(up.URL, "key"), NewCoinGecko(up.URL))
+	info, err := p.TokenInfo(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, stubInfo.Price, info.Price)
+
+	// CoinMarketCap has no history, CoinGecko is asked after failed stub
+	points, err := p.PriceHistory(context.Background(), now.Add(-time.Hour), now)
+	assert.NoError(t, err)
+	assert.Len(t, points, 2)
+	for i := range history {
+		assert.True(t, history[i].Time.Equal(points[i].Time))
+		assert.Equal(t, history[i].Price, points[i].Price)
+	}
+
+	_, err = NewFailover(zap.NewNop()).TokenInfo(context.Background())
+	assert.Equal(t, ErrNoProvider, err)
+	_, err = NewFailover(zap.NewNop(), NewStatic("")).PriceHistory(context.Background(), now, now)
+	assert.Equal(t, ErrNoHistory, err)
+}
+
+func Test_New(t *testing.T) {
+	p, err := New(Config{Providers: []string{"coinmarketcap", " coingecko"}, Logger: zap.NewNop()})
+	assert.NoError(t, err)
+	assert.Equal(t, "coingecko", p.Name())
+
+	_, err = New(Config{Providers: []string{"binance"}, Logger: zap.NewNop()})
+	assert.Error(t, err)
+}
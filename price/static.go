@@ -0,0 +1,39 @@
+// Package price
+package price
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"time"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+type static struct {
+	file string
+}
+
+// NewStatic create a provider which read token info from a JSON file, for networks without market data.
+// File is read on every call so it can be edited without restart
+func NewStatic(file string) Provider {
+	return &static{file: file}
+}
+
+func (p *static) Name() string { return string(StaticAdapter) }
+
+func (p *static) TokenInfo(ctx context.Context) (*types.TokenInfo, error) {
+	data, err := ioutil.ReadFile(p.file)
+	if err != nil {
+		return nil, err
+	}
+	var info types.TokenInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func (p *static) PriceHistory(ctx context.Context, from, to time.Time) ([]*types.PricePoint, error) {
+	return nil, ErrNoHistory
+}
@@ -0,0 +1,110 @@
+// Package price
+package price
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+type stub struct {
+	url string
+}
+
+// NewStub create a provider which read token info and history as plain JSON from url, e.g. a server of NewStubServer
+func NewStub(url string) Provider {
+	return &stub{url: url}
+}
+
+func (p *stub) Name() string { return string(StubAdapter) }
+
+func (p *stub) TokenInfo(ctx context.Context) (*types.TokenInfo, error) {
+	var info types.TokenInfo
+	if err := getJSON(ctx, p.url+"/token", nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func (p *stub) PriceHistory(ctx context.Context, from, to time.Time) ([]*types.PricePoint, error) {
+	var points []*types.PricePoint
+	if err := getJSON(ctx, fmt.Sprintf("%s/history?from=%d&to=%d", p.url, from.Unix(), to.Unix()), nil, &points); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+// NewStubServer start a server which serve info and history for stub provider, and in shapes of CoinMarketCap
+// and CoinGecko APIs so their providers can be pointed at it. Nil info makes every request fail, close it after use
+func NewStubServer(info *types.TokenInfo, history []*types.PricePoint) *httptest.Server {
+	write := func(w http.ResponseWriter, v interface{}) {
+		if info == nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		write(w, info)
+	})
+	mux.HandleFunc("/history", func(w http.ResponseWriter, r *http.Request) {
+		write(w, history)
+	})
+	mux.HandleFunc("/v1/cryptocurrency/quotes/latest", func(w http.ResponseWriter, r *http.Request) {
+		if info == nil {
+			write(w, nil)
+			return
+		}
+		resp := cmResponse{Data: map[string]cmTokenInfo{coinMarketCapID: {
+			Name:              info.Name,
+			Symbol:            info.Symbol,
+			CirculatingSupply: float64(info.ERC20CirculatingSupply),
+			TotalSupply:       float64(info.TotalSupply),
+			Quote: map[string]cmQuote{Currency: {
+				Price:            info.Price,
+				Volume24h:        info.Volume24h,
+				PercentChange1h:  info.Change1h,
+				PercentChange24h: info.Change24h,
+				PercentChange7d:  info.Change7d,
+				MarketCap:        info.MarketCap,
+			}},
+		}}}
+		if r.Header.Get("X-CMC_PRO_API_KEY") == "" {
+			resp.Status.ErrorCode, resp.Status.ErrorMessage = 1002, "API key missing."
+		}
+		write(w, resp)
+	})
+	mux.HandleFunc("/api/v3/coins/markets", func(w http.ResponseWriter, r *http.Request) {
+		if info == nil {
+			write(w, nil)
+			return
+		}
+		write(w, []cgMarket{{
+			Name:              info.Name,
+			Symbol:            strings.ToLower(info.Symbol),
+			CurrentPrice:      info.Price,
+			MarketCap:         info.MarketCap,
+			TotalVolume:       info.Volume24h,
+			CirculatingSupply: float64(info.ERC20CirculatingSupply),
+			TotalSupply:       float64(info.TotalSupply),
+			Change1h:          info.Change1h,
+			Change24h:         info.Change24h,
+			Change7d:          info.Change7d,
+		}})
+	})
+	mux.HandleFunc("/api/v3/coins/"+coinGeckoID+"/market_chart/range", func(w http.ResponseWriter, r *http.Request) {
+		var chart cgMarketChart
+		for _, p := range history {
+			chart.Prices = append(chart.Prices, [2]float64{float64(p.Time.UnixNano() / int64(time.Millisecond)), p.Price})
+		}
+		write(w, chart)
+	})
+	return httptest.NewServer(mux)
+}
@@ -0,0 +1,78 @@
+// Package server
+package server
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/api"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+// RecordAudit store a mutating admin call, it only logs on failure so the call itself isn't affected
+func (s *Server) RecordAudit(ctx context.Context, log *types.AuditLog) {
+	if err := s.dbClient.InsertAuditLog(ctx, log); err != nil {
+		s.logger.Error("Cannot insert audit log", zap.String("operator", log.Operator), zap.String("method", log.Method),
+			zap.String("path", log.Path), zap.Error(err))
+	}
+}
+
+// AdminTokenRevoked is checked on every admin call, admin calls are rare enough to hit db each time
+func (s *Server) AdminTokenRevoked(ctx context.Context, id string) (bool, error) {
+	revoked, err := s.dbClient.AdminTokenRevoked(ctx, id)
+	if err != nil {
+		s.logger.Warn("Cannot check admin token", zap.String("jti", id), zap.Error(err))
+	}
+	return revoked, err
+}
+
+func (s *Server) AuditLogs(c echo.Context) error {
+	ctx := context.Background()
+	pagination, page, limit := getPagingOption(c)
+	filter := &types.AuditLogsFilter{
+		Pagination: pagination,
+		Operator:   c.QueryParam("operator"),
+		Route:      c.QueryParam("route"),
+	}
+	if ts, err := strconv.ParseInt(c.QueryParam("from"), 10, 64); err == nil {
+		filter.From = time.Unix(ts, 0)
+	}
+	if ts, err := strconv.ParseInt(c.QueryParam("to"), 10, 64); err == nil {
+		filter.To = time.Unix(ts, 0)
+	}
+	logs, total, err := s.dbClient.AuditLogs(ctx, filter)
+	if err != nil {
+		s.logger.Warn("Cannot get audit logs", zap.Error(err))
+		return api.Invalid.Build(c)
+	}
+	return api.OK.SetData(PagingResponse{
+		Page:  page,
+		Limit: limit,
+		Total: total,
+		Data:  logs,
+	}).Build(c)
+}
+
+// AdminOperator show who the admin token of caller is issued to
+func (s *Server) AdminOperator(c echo.Context) error {
+	return api.OK.SetData(api.RequestOperator(c)).Build(c)
+}
+
+// RevokeAdminToken reject the token of jti from now on, even if it's not expired
+func (s *Server) RevokeAdminToken(c echo.Context) error {
+	ctx := context.Background()
+	id := c.Param("jti")
+	if id == "" {
+		return api.Invalid.Build(c)
+	}
+	token := &types.RevokedAdminToken{ID: id, RevokedBy: api.RequestOperator(c).Name, RevokedAt: time.Now()}
+	if err := s.dbClient.RevokeAdminToken(ctx, token); err != nil {
+		s.logger.Warn("Cannot revoke admin token", zap.String("jti", id), zap.Error(err))
+		return api.InternalServer.Build(c)
+	}
+	return api.OK.Build(c)
+}
@@ -0,0 +1,57 @@
+// Package server
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/api"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_adminToken(t *testing.T) {
+	now := time.Now()
+	operator := &types.Operator{
+		ID:        "0123456789abcdef",
+		Name:      "alice",
+		Roles:     []string{types.AdminRoleLabels},
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(time.Hour).Unix(),
+	}
+	token, err := api.SignAdminToken("secret", operator)
+	assert.Nil(t, err)
+
+	parsed, err := api.ParseAdminToken("secret", token, now)
+	assert.Nil(t, err)
+	assert.Equal(t, operator, parsed)
+	assert.True(t, parsed.HasRole(types.AdminRoleOps, types.AdminRoleLabels))
+	assert.False(t, parsed.HasRole(types.AdminRoleContracts))
+
+	_, err = api.ParseAdminToken("other", token, now)
+	assert.Equal(t, api.ErrInvalidAdminToken, err)
+	_, err = api.ParseAdminToken("", token, now)
+	assert.Equal(t, api.ErrInvalidAdminToken, err)
+	_, err = api.ParseAdminToken("secret", token, now.Add(2*time.Hour))
+	assert.Equal(t, api.ErrExpiredAdminToken, err)
+
+	// roles cannot be changed without the secret
+	forged := &types.Operator{Name: "alice", Roles: types.AdminRoles, ExpiresAt: operator.ExpiresAt}
+	forgedToken, err := api.SignAdminToken("guess", forged)
+	assert.Nil(t, err)
+	_, err = api.ParseAdminToken("secret", forgedToken[:strings.Index(forgedToken, ".")]+token[strings.Index(token, "."):], now)
+	assert.Equal(t, api.ErrInvalidAdminToken, err)
+
+	// tokens without jti cannot be revoked
+	noID := *operator
+	noID.ID = ""
+	noIDToken, err := api.SignAdminToken("secret", &noID)
+	assert.Nil(t, err)
+	_, err = api.ParseAdminToken("secret", noIDToken, now)
+	assert.Equal(t, api.ErrInvalidAdminToken, err)
+
+	_, err = api.SignAdminToken("", operator)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,463 @@
+// Package server
+package server
+
+import (
+	"container/list"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/api"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const (
+	apiKeyPrefix       = "kai_"
+	apiKeyLength       = 24 // random bytes
+	apiKeyShownLength  = 12
+	apiKeyCacheTTL     = 30 * time.Second
+	apiKeyCacheSize    = 10000
+	apiKeyMissSize     = 1000 // unknown keys, so a flood of random keys doesn't evict known ones
+	apiKeyUsageTimeout = 5 * time.Second
+	apiKeyUsageDays    = 30
+	apiKeyDayLayout    = "2006-01-02"
+
+	apiKeyUsageFlushInterval = 30 * time.Second
+)
+
+type apiKeyRequest struct {
+	Owner       string `json:"owner"`
+	Email       string `json:"email"`
+	Description string `json:"description"`
+	Tier        string `json:"tier"`
+}
+
+type ownAPIKeyResponse struct {
+	Key   *types.APIKey        `json:"key"`
+	Tier  *types.APIKeyTier    `json:"tier"`
+	Usage []*types.APIKeyUsage `json:"usage"`
+}
+
+// apiKeyCache keep resolved keys for a while, so every request doesn't hit db. Known and unknown keys are kept
+// in separate LRU lists, unknown ones as nil. Revocation and rotation on another API instance take effect after
+// apiKeyCacheTTL
+type apiKeyCache struct {
+	mu     sync.Mutex
+	keys   *apiKeyLRU
+	misses *apiKeyLRU
+}
+
+// apiKeyLRU drop least recently used entries above its size, entries are keyed by key hash
+type apiKeyLRU struct {
+	size    int
+	order   *list.List // of *apiKeyCacheEntry, most recently used first
+	entries map[string]*list.Element
+}
+
+type apiKeyCacheEntry struct {
+	hash      string
+	key       *types.APIKey
+	expiresAt time.Time
+}
+
+func newAPIKeyCache() *apiKeyCache {
+	return &apiKeyCache{keys: newAPIKeyLRU(apiKeyCacheSize), misses: newAPIKeyLRU(apiKeyMissSize)}
+}
+
+func newAPIKeyLRU(size int) *apiKeyLRU {
+	return &apiKeyLRU{size: size, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+func (l *apiKeyLRU) get(hash string, now time.Time) (*types.APIKey, bool) {
+	elem, ok := l.entries[hash]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*apiKeyCacheEntry)
+	if now.After(entry.expiresAt) {
+		l.remove(hash)
+		return nil, false
+	}
+	l.order.MoveToFront(elem)
+	return entry.key, true
+}
+
+func (l *apiKeyLRU) set(hash string, key *types.APIKey, expiresAt time.Time) {
+	if elem, ok := l.entries[hash]; ok {
+		elem.Value = &apiKeyCacheEntry{hash: hash, key: key, expiresAt: expiresAt}
+		l.order.MoveToFront(elem)
+		return
+	}
+	l.entries[hash] = l.order.PushFront(&apiKeyCacheEntry{hash: hash, key: key, expiresAt: expiresAt})
+	for l.order.Len() > l.size {
+		l.remove(l.order.Back().Value.(*apiKeyCacheEntry).hash)
+	}
+}
+
+func (l *apiKeyLRU) remove(hash string) {
+	if elem, ok := l.entries[hash]; ok {
+		l.order.Remove(elem)
+		delete(l.entries, hash)
+	}
+}
+
+func (c *apiKeyCache) get(hash string, now time.Time) (*types.APIKey, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if key, ok := c.keys.get(hash, now); ok {
+		return key, true
+	}
+	return c.misses.get(hash, now)
+}
+
+func (c *apiKeyCache) set(hash string, key *types.APIKey, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if key == nil {
+		c.keys.remove(hash)
+		c.misses.set(hash, nil, now.Add(apiKeyCacheTTL))
+		return
+	}
+	c.misses.remove(hash)
+	c.keys.set(hash, key, now.Add(apiKeyCacheTTL))
+}
+
+func (c *apiKeyCache) remove(hash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.keys.remove(hash)
+	c.misses.remove(hash)
+}
+
+// apiKeyUsageBuffer sum usage of keys in memory until it's flushed to db, so requests don't cost a db write
+// each. Usage of the last apiKeyUsageFlushInterval is lost if API stops
+type apiKeyUsageBuffer struct {
+	mu    sync.Mutex
+	usage map[string]*types.APIKeyUsage // by key ID and day
+}
+
+func newAPIKeyUsageBuffer() *apiKeyUsageBuffer {
+	return &apiKeyUsageBuffer{usage: make(map[string]*types.APIKeyUsage)}
+}
+
+// entry return usage of key in day, b.mu must be held
+func (b *apiKeyUsageBuffer) entry(keyID, day string) *types.APIKeyUsage {
+	usage, ok := b.usage[keyID+"#"+day]
+	if !ok {
+		usage = &types.APIKeyUsage{KeyID: keyID, Day: day, Routes: make(map[string]int64)}
+		b.usage[keyID+"#"+day] = usage
+	}
+	return usage
+}
+
+func (b *apiKeyUsageBuffer) add(keyID, day, route string, limited bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	usage := b.entry(keyID, day)
+	usage.Requests++
+	usage.Routes[route]++
+	if limited {
+		usage.Limited++
+	}
+}
+
+// take return buffered usage and empty the buffer
+func (b *apiKeyUsageBuffer) take() []*types.APIKeyUsage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	usage := make([]*types.APIKeyUsage, 0, len(b.usage))
+	for _, u := range b.usage {
+		usage = append(usage, u)
+	}
+	b.usage = make(map[string]*types.APIKeyUsage)
+	return usage
+}
+
+// putBack add usage which cannot be flushed back to the buffer, so it's retried next time
+func (b *apiKeyUsageBuffer) putBack(usage []*types.APIKeyUsage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, u := range usage {
+		entry := b.entry(u.KeyID, u.Day)
+		entry.Requests += u.Requests
+		entry.Limited += u.Limited
+		for route, count := range u.Routes {
+			entry.Routes[route] += count
+		}
+	}
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func newAPIKey() (string, error) {
+	b := make([]byte, apiKeyLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + hex.EncodeToString(b), nil
+}
+
+func apiKeyDay(t time.Time) string {
+	return t.UTC().Format(apiKeyDayLayout)
+}
+
+// ResolveAPIKey return nil if key is unknown or revoked
+func (s *Server) ResolveAPIKey(ctx context.Context, raw string) (*types.APIKey, error) {
+	hash := hashAPIKey(raw)
+	now := time.Now()
+	if key, ok := s.apiKeys.get(hash, now); ok {
+		return key, nil
+	}
+	key, err := s.dbClient.APIKeyByHash(ctx, hash)
+	if err != nil {
+		s.logger.Warn("Cannot get API key", zap.Error(err))
+		return nil, err
+	}
+	if key != nil && key.Revoked {
+		key = nil
+	}
+	s.apiKeys.set(hash, key, now)
+	return key, nil
+}
+
+// CountAPIKeyRequest count a request of key and return requests of key today
+func (s *Server) CountAPIKeyRequest(ctx context.Context, key *types.APIKey) (int64, error) {
+	return s.cacheClient.IncrAPIKeyRequests(ctx, key.ID.Hex(), apiKeyDay(time.Now()))
+}
+
+// RecordAPIKeyUsage buffer daily usage of key, analytics should never slow requests down
+func (s *Server) RecordAPIKeyUsage(key *types.APIKey, route string, limited bool) {
+	s.apiKeyUsage.add(key.ID.Hex(), apiKeyDay(time.Now()), route, limited)
+}
+
+// flushAPIKeyUsage write buffered usage to db every apiKeyUsageFlushInterval
+func (s *Server) flushAPIKeyUsage(ctx context.Context) {
+	ticker := time.NewTicker(apiKeyUsageFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		usage := s.apiKeyUsage.take()
+		if len(usage) == 0 {
+			continue
+		}
+		flushCtx, cancel := context.WithTimeout(ctx, apiKeyUsageTimeout)
+		err := s.dbClient.IncrAPIKeyUsage(flushCtx, usage)
+		cancel()
+		if err != nil {
+			s.logger.Warn("Cannot record API key usage", zap.Int("keys", len(usage)), zap.Error(err))
+			s.apiKeyUsage.putBack(usage)
+		}
+	}
+}
+
+func (s *Server) CreateAPIKey(c echo.Context) error {
+	ctx := context.Background()
+	var req *apiKeyRequest
+	if err := c.Bind(&req); err != nil || req == nil || req.Owner == "" {
+		return api.Invalid.Build(c)
+	}
+	if req.Tier == "" {
+		req.Tier = types.APIKeyTierFree
+	}
+	if _, ok := types.APIKeyTiers[req.Tier]; !ok {
+		return api.Invalid.Build(c)
+	}
+	raw, err := newAPIKey()
+	if err != nil {
+		s.logger.Warn("Cannot generate API key", zap.Error(err))
+		return api.InternalServer.Build(c)
+	}
+	key := &types.APIKey{
+		Hash:        hashAPIKey(raw),
+		Prefix:      raw[:apiKeyShownLength],
+		Owner:       req.Owner,
+		Email:       req.Email,
+		Description: req.Description,
+		Tier:        req.Tier,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.dbClient.InsertAPIKey(ctx, key); err != nil {
+		s.logger.Warn("Cannot insert API key", zap.Error(err))
+		return api.InternalServer.Build(c)
+	}
+	key.Key = raw
+	return api.OK.SetData(key).Build(c)
+}
+
+func (s *Server) APIKeys(c echo.Context) error {
+	ctx := context.Background()
+	pagination, page, limit := getPagingOption(c)
+	filter := &types.APIKeysFilter{
+		Pagination: pagination,
+		Owner:      c.QueryParam("owner"),
+		Tier:       c.QueryParam("tier"),
+	}
+	if revoked, err := strconv.ParseBool(c.QueryParam("revoked")); err == nil {
+		filter.Revoked = &revoked
+	}
+	keys, total, err := s.dbClient.APIKeys(ctx, filter)
+	if err != nil {
+		s.logger.Warn("Cannot get API keys", zap.Error(err))
+		return api.Invalid.Build(c)
+	}
+	return api.OK.SetData(PagingResponse{
+		Page:  page,
+		Limit: limit,
+		Total: total,
+		Data:  keys,
+	}).Build(c)
+}
+
+func (s *Server) APIKeyInfo(c echo.Context) error {
+	ctx := context.Background()
+	key, err := s.dbClient.APIKey(ctx, c.Param("id"))
+	if err != nil || key == nil {
+		return api.Invalid.Build(c)
+	}
+	return api.OK.SetData(key).Build(c)
+}
+
+// UpdateAPIKey change owner metadata or tier of key, empty fields are kept
+func (s *Server) UpdateAPIKey(c echo.Context) error {
+	ctx := context.Background()
+	var req *apiKeyRequest
+	if err := c.Bind(&req); err != nil || req == nil {
+		return api.Invalid.Build(c)
+	}
+	if _, ok := types.APIKeyTiers[req.Tier]; req.Tier != "" && !ok {
+		return api.Invalid.Build(c)
+	}
+	key, err := s.dbClient.APIKey(ctx, c.Param("id"))
+	if err != nil || key == nil {
+		return api.Invalid.Build(c)
+	}
+	before := *key
+	api.AuditBefore(c, &before)
+	if req.Owner != "" {
+		key.Owner = req.Owner
+	}
+	if req.Email != "" {
+		key.Email = req.Email
+	}
+	if req.Description != "" {
+		key.Description = req.Description
+	}
+	if req.Tier != "" {
+		key.Tier = req.Tier
+	}
+	if err := s.dbClient.UpdateAPIKey(ctx, key); err != nil {
+		s.logger.Warn("Cannot update API key", zap.Error(err))
+		return api.InternalServer.Build(c)
+	}
+	s.apiKeys.remove(key.Hash)
+	return api.OK.SetData(key).Build(c)
+}
+
+// RotateAPIKey issue a new key for the same ID, so usage history and webhook watches are kept
+func (s *Server) RotateAPIKey(c echo.Context) error {
+	ctx := context.Background()
+	old, err := s.dbClient.APIKey(ctx, c.Param("id"))
+	if err != nil || old == nil || old.Revoked {
+		return api.Invalid.Build(c)
+	}
+	raw, err := newAPIKey()
+	if err != nil {
+		s.logger.Warn("Cannot generate API key", zap.Error(err))
+		return api.InternalServer.Build(c)
+	}
+	key, err := s.dbClient.RotateAPIKey(ctx, c.Param("id"), hashAPIKey(raw), raw[:apiKeyShownLength])
+	if err != nil {
+		s.logger.Warn("Cannot rotate API key", zap.Error(err))
+		return api.InternalServer.Build(c)
+	}
+	if key == nil {
+		return api.Invalid.Build(c)
+	}
+	s.apiKeys.remove(old.Hash)
+	key.Key = raw
+	return api.OK.SetData(key).Build(c)
+}
+
+func (s *Server) RevokeAPIKey(c echo.Context) error {
+	ctx := context.Background()
+	key, err := s.dbClient.RevokeAPIKey(ctx, c.Param("id"))
+	if err != nil {
+		s.logger.Warn("Cannot revoke API key", zap.Error(err))
+		return api.InternalServer.Build(c)
+	}
+	if key == nil {
+		return api.Invalid.Build(c)
+	}
+	s.apiKeys.remove(key.Hash)
+	return api.OK.Build(c)
+}
+
+// APIKeyUsage return daily usage of key between `from` and `to` unix timestamps, last 30 days by default
+func (s *Server) APIKeyUsage(c echo.Context) error {
+	ctx := context.Background()
+	key, err := s.dbClient.APIKey(ctx, c.Param("id"))
+	if err != nil || key == nil {
+		return api.Invalid.Build(c)
+	}
+	from, to := getAPIKeyUsageRange(c, time.Now())
+	usage, err := s.dbClient.APIKeyUsage(ctx, key.ID.Hex(), from, to)
+	if err != nil {
+		s.logger.Warn("Cannot get API key usage", zap.Error(err))
+		return api.InternalServer.Build(c)
+	}
+	return api.OK.SetData(usage).Build(c)
+}
+
+func getAPIKeyUsageRange(c echo.Context, now time.Time) (string, string) {
+	to := now
+	if ts, err := strconv.ParseInt(c.QueryParam("to"), 10, 64); err == nil {
+		to = time.Unix(ts, 0)
+	}
+	from := to.AddDate(0, 0, -(apiKeyUsageDays - 1))
+	if ts, err := strconv.ParseInt(c.QueryParam("from"), 10, 64); err == nil {
+		from = time.Unix(ts, 0)
+	}
+	return apiKeyDay(from), apiKeyDay(to)
+}
+
+func (s *Server) APIKeyTiers(c echo.Context) error {
+	tiers := make([]*types.APIKeyTier, 0, len(types.APIKeyTiers))
+	for _, name := range []string{types.APIKeyTierFree, types.APIKeyTierStandard, types.APIKeyTierPartner, types.APIKeyTierInternal} {
+		tiers = append(tiers, types.APIKeyTiers[name])
+	}
+	return api.OK.SetData(tiers).Build(c)
+}
+
+// OwnAPIKey show key of caller with its tier and recent usage
+func (s *Server) OwnAPIKey(c echo.Context) error {
+	ctx := context.Background()
+	key := api.RequestAPIKey(c)
+	if key == nil {
+		return api.Unauthorized.Build(c)
+	}
+	from, to := getAPIKeyUsageRange(c, time.Now())
+	usage, err := s.dbClient.APIKeyUsage(ctx, key.ID.Hex(), from, to)
+	if err != nil {
+		s.logger.Warn("Cannot get API key usage", zap.Error(err))
+		return api.InternalServer.Build(c)
+	}
+	return api.OK.SetData(ownAPIKeyResponse{
+		Key:   key,
+		Tier:  types.APIKeyTierByName(key.Tier),
+		Usage: usage,
+	}).Build(c)
+}
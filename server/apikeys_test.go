@@ -0,0 +1,117 @@
+// Package server
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_apiKeyCache(t *testing.T) {
+	cache := newAPIKeyCache()
+	now := time.Now()
+	key := &types.APIKey{Tier: types.APIKeyTierFree}
+
+	_, ok := cache.get("a", now)
+	assert.False(t, ok)
+
+	cache.set("a", key, now)
+	cache.set("b", nil, now)
+	got, ok := cache.get("a", now.Add(time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, key, got)
+	got, ok = cache.get("b", now)
+	assert.True(t, ok)
+	assert.Nil(t, got)
+
+	_, ok = cache.get("a", now.Add(apiKeyCacheTTL+time.Second))
+	assert.False(t, ok)
+	cache.remove("b")
+	_, ok = cache.get("b", now)
+	assert.False(t, ok)
+
+	// misses are bounded separately and don't evict known keys
+	cache.set("a", key, now)
+	for i := 0; i < apiKeyMissSize+1; i++ {
+		cache.set(fmt.Sprintf("miss%d", i), nil, now)
+	}
+	_, ok = cache.get("miss0", now)
+	assert.False(t, ok)
+	_, ok = cache.get(fmt.Sprintf("miss%d", apiKeyMissSize), now)
+	assert.True(t, ok)
+	got, ok = cache.get("a", now)
+	assert.True(t, ok)
+	assert.Equal(t, key, got)
+
+	// least recently used key is evicted
+	for i := 0; i < apiKeyCacheSize; i++ {
+		cache.set(fmt.Sprintf("key%d", i), key, now)
+		if i == 0 {
+			cache.get("a", now)
+		}
+	}
+	_, ok = cache.get("a", now)
+	assert.True(t, ok)
+	_, ok = cache.get("key0", now)
+	assert.False(t, ok)
+	assert.Equal(t, apiKeyCacheSize, cache.keys.order.Len())
+}
+
+func Test_apiKeyUsageBuffer(t *testing.T) {
+	buffer := newAPIKeyUsageBuffer()
+	buffer.add("a", "2021-03-01", "GET /blocks", false)
+	buffer.add("a", "2021-03-01", "GET /blocks", true)
+	buffer.add("a", "2021-03-02", "GET /txs", false)
+
+	usage := buffer.take()
+	assert.Len(t, usage, 2)
+	assert.Empty(t, buffer.take())
+	for _, u := range usage {
+		if u.Day == "2021-03-01" {
+			assert.Equal(t, &types.APIKeyUsage{KeyID: "a", Day: "2021-03-01", Requests: 2, Limited: 1, Routes: map[string]int64{"GET /blocks": 2}}, u)
+		}
+	}
+
+	buffer.add("a", "2021-03-02", "GET /txs", false)
+	buffer.putBack(usage)
+	for _, u := range buffer.take() {
+		if u.Day == "2021-03-02" {
+			assert.Equal(t, &types.APIKeyUsage{KeyID: "a", Day: "2021-03-02", Requests: 2, Routes: map[string]int64{"GET /txs": 2}}, u)
+		}
+	}
+}
+
+func Test_newAPIKey(t *testing.T) {
+	a, err := newAPIKey()
+	assert.Nil(t, err)
+	b, err := newAPIKey()
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(a, apiKeyPrefix))
+	assert.Len(t, a, len(apiKeyPrefix)+2*apiKeyLength)
+	assert.NotEqual(t, a, b)
+	assert.NotEqual(t, hashAPIKey(a), hashAPIKey(b))
+	assert.Len(t, hashAPIKey(a), 64)
+}
+
+func Test_getAPIKeyUsageRange(t *testing.T) {
+	now := time.Date(2021, 3, 31, 12, 0, 0, 0, time.UTC)
+	e := echo.New()
+
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	from, to := getAPIKeyUsageRange(c, now)
+	assert.Equal(t, "2021-03-02", from)
+	assert.Equal(t, "2021-03-31", to)
+
+	c = e.NewContext(httptest.NewRequest(http.MethodGet, "/?from=1614556800&to=1614816000", nil), httptest.NewRecorder())
+	from, to = getAPIKeyUsageRange(c, now)
+	assert.Equal(t, "2021-03-01", from)
+	assert.Equal(t, "2021-03-04", to)
+}
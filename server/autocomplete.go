@@ -0,0 +1,64 @@
+// Package server
+package server
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/api"
+	"github.com/kardiachain/kardia-explorer-backend/db"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const (
+	autocompleteDefaultLimit = 10
+	autocompleteMaxLimit     = 25
+)
+
+// Autocomplete is the typeahead of search box, it only reads prefix index in cache
+func (s *Server) Autocomplete(c echo.Context) error {
+	ctx := c.Request().Context()
+	limit, err := strconv.ParseInt(c.QueryParam("limit"), 10, 64)
+	if err != nil || limit <= 0 {
+		limit = autocompleteDefaultLimit
+	}
+	if limit > autocompleteMaxLimit {
+		limit = autocompleteMaxLimit
+	}
+	entries, err := s.cacheClient.Autocomplete(ctx, c.QueryParam("q"), limit)
+	if err != nil {
+		s.logger.Warn("Cannot get autocomplete entries", zap.String("q", c.QueryParam("q")), zap.Error(err))
+		return api.InternalServer.Build(c)
+	}
+	if entries == nil {
+		entries = []*types.AutocompleteEntry{}
+	}
+	return api.OK.SetData(entries).Build(c)
+}
+
+// refreshAutocomplete rebuild autocomplete entry of address after its name or contract changes
+func (s *Server) refreshAutocomplete(ctx context.Context, address string) {
+	addr, _ := s.dbClient.AddressByHash(ctx, address)
+	smc, _, _ := s.dbClient.Contract(ctx, address)
+	var val *types.Validator
+	if validators, err := s.dbClient.Validators(ctx, db.ValidatorsFilter{}); err == nil {
+		for _, v := range validators {
+			if v.SmcAddress == address {
+				val = v
+				break
+			}
+		}
+	}
+	var err error
+	if e := types.NewAutocompleteEntry(addr, smc, val); e != nil {
+		err = s.cacheClient.UpsertAutocompleteEntries(ctx, []*types.AutocompleteEntry{e})
+	} else {
+		err = s.cacheClient.RemoveAutocompleteEntry(ctx, address)
+	}
+	if err != nil {
+		s.logger.Warn("Cannot refresh autocomplete entry", zap.String("address", address), zap.Error(err))
+	}
+}
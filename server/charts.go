@@ -0,0 +1,93 @@
+// Package server
+package server
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/api"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const (
+	defaultChartDays  = 30
+	defaultChartHours = 48
+	maxChartPoints    = 1000
+)
+
+// NetworkChart return a metric of network activity by day or hour, buckets without blocks are zero
+func (s *Server) NetworkChart(c echo.Context) error {
+	ctx := context.Background()
+	metric := c.QueryParam("metric")
+	if !types.IsChartMetric(metric) {
+		return api.Invalid.Build(c)
+	}
+	interval := c.QueryParam("interval")
+	if interval == "" {
+		interval = types.NetworkBucketDay
+	}
+	from, to, ok := chartRange(c, interval, time.Now())
+	if !ok {
+		return api.Invalid.Build(c)
+	}
+	stats, err := s.dbClient.NetworkStats(ctx, &types.NetworkStatsFilter{
+		BucketType: interval,
+		FromBucket: from,
+		ToBucket:   to,
+	})
+	if err != nil {
+		s.logger.Warn("Cannot get network stats from db", zap.Error(err))
+		return api.Invalid.Build(c)
+	}
+	return api.OK.SetData(&types.Chart{
+		Metric:   metric,
+		Interval: interval,
+		Points:   chartSeries(stats, metric, interval, from, to),
+	}).Build(c)
+}
+
+// chartRange return first and last buckets of `from` and `to` query params, default to last 30 days or 48 hours.
+// It's not ok when range is reversed or has too many points
+func chartRange(c echo.Context, interval string, now time.Time) (int64, int64, bool) {
+	bucket := types.NetworkBucketDuration(interval)
+	if bucket == 0 {
+		return 0, 0, false
+	}
+	to := now.UTC().Truncate(bucket)
+	if ts, err := strconv.ParseInt(c.QueryParam("to"), 10, 64); err == nil {
+		to = time.Unix(ts, 0).UTC().Truncate(bucket)
+	}
+	from := to.Add(-(defaultChartDays - 1) * bucket)
+	if interval == types.NetworkBucketHour {
+		from = to.Add(-(defaultChartHours - 1) * bucket)
+	}
+	if ts, err := strconv.ParseInt(c.QueryParam("from"), 10, 64); err == nil {
+		from = time.Unix(ts, 0).UTC().Truncate(bucket)
+	}
+	if from.After(to) || to.Sub(from)/bucket >= maxChartPoints {
+		return 0, 0, false
+	}
+	return from.Unix(), to.Unix(), true
+}
+
+// chartSeries return one point per bucket from `from` to `to`, stats must be of the same interval
+func chartSeries(stats []*types.NetworkStats, metric, interval string, from, to int64) []*types.ChartPoint {
+	byBucket := make(map[int64]*types.NetworkStats, len(stats))
+	for _, stat := range stats {
+		byBucket[stat.Bucket] = stat
+	}
+	step := int64(types.NetworkBucketDuration(interval) / time.Second)
+	points := make([]*types.ChartPoint, 0, (to-from)/step+1)
+	for bucket := from; bucket <= to; bucket += step {
+		point := &types.ChartPoint{Time: bucket}
+		if stat, ok := byBucket[bucket]; ok {
+			point.Value = stat.Metric(metric)
+		}
+		points = append(points, point)
+	}
+	return points
+}
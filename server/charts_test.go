@@ -0,0 +1,63 @@
+// Package server
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_chartSeries(t *testing.T) {
+	hour := int64(3600)
+	stats := []*types.NetworkStats{
+		{Bucket: 0, Txs: 10, Blocks: 2, GasUsed: 300},
+		{Bucket: 2 * hour, Txs: 4, Blocks: 1, GasUsed: 100},
+	}
+	points := chartSeries(stats, types.ChartMetricTxs, types.NetworkBucketHour, 0, 3*hour)
+	assert.Equal(t, []*types.ChartPoint{
+		{Time: 0, Value: 10},
+		{Time: hour, Value: 0},
+		{Time: 2 * hour, Value: 4},
+		{Time: 3 * hour, Value: 0},
+	}, points)
+
+	points = chartSeries(stats, types.ChartMetricAvgGasUsed, types.NetworkBucketHour, 0, 0)
+	assert.Equal(t, []*types.ChartPoint{{Time: 0, Value: 150}}, points)
+}
+
+func Test_chartRange(t *testing.T) {
+	now := time.Date(2021, 3, 10, 15, 30, 0, 0, time.UTC)
+	day := time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)
+	hour := time.Date(2021, 3, 10, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		query    string
+		interval string
+		from, to int64
+		ok       bool
+	}{
+		{"default days", "", types.NetworkBucketDay, day.AddDate(0, 0, -29).Unix(), day.Unix(), true},
+		{"default hours", "", types.NetworkBucketHour, hour.Add(-47 * time.Hour).Unix(), hour.Unix(), true},
+		{"truncated range", "?from=1614600000&to=1614700000", types.NetworkBucketDay, 1614556800, 1614643200, true},
+		{"reversed", "?from=1614700000&to=1614600000", types.NetworkBucketDay, 0, 0, false},
+		{"too many points", "?from=0", types.NetworkBucketHour, 0, 0, false},
+		{"unknown interval", "", "week", 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/dashboard/charts"+tt.query, nil), httptest.NewRecorder())
+			from, to, ok := chartRange(c, tt.interval, now)
+			assert.Equal(t, tt.ok, ok)
+			if ok {
+				assert.Equal(t, tt.from, from)
+				assert.Equal(t, tt.to, to)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Package server
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/labstack/echo"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const defaultCursorLimit = 25
+
+// getCursorPagingOption read opaque `cursor` query param on top of page/limit. Cursor take
+// precedence over page, so deep lists are queried by range on sort key instead of skipping
+func getCursorPagingOption(c echo.Context) (*types.Pagination, int, int, error) {
+	pagination, page, limit := getPagingOption(c)
+	cursorStr := c.QueryParam("cursor")
+	if cursorStr == "" {
+		return pagination, page, limit, nil
+	}
+	cursor, err := decodeCursor(cursorStr)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if pagination == nil {
+		pagination = &types.Pagination{Limit: defaultCursorLimit}
+	}
+	pagination.Skip = 0
+	pagination.Cursor = cursor
+	return pagination, 0, pagination.Limit, nil
+}
+
+func encodeCursor(cursor *types.Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (*types.Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var cursor *types.Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	return cursor, nil
+}
+
+// pageCursors return next and prev cursors of a page from sort keys of its first and last items
+func pageCursors(pagination *types.Pagination, count int, first, last *types.Cursor) (next, prev string) {
+	if pagination == nil || count == 0 {
+		return "", ""
+	}
+	cursor := pagination.Cursor
+	backward := cursor != nil && cursor.Prev
+	if count >= pagination.Limit || backward {
+		next = encodeCursor(last)
+	}
+	if pagination.Skip > 0 || (cursor != nil && !backward) || (backward && count >= pagination.Limit) {
+		first.Prev = true
+		prev = encodeCursor(first)
+	}
+	return next, prev
+}
+
+func timeCursor(t time.Time, key string) *types.Cursor {
+	return &types.Cursor{Time: t.UnixNano() / int64(time.Millisecond), Key: key}
+}
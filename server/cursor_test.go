@@ -0,0 +1,29 @@
+// Package server
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_pageCursors(t *testing.T) {
+	// first page by page number only has next cursor
+	next, prev := pageCursors(&types.Pagination{Limit: 2}, 2, &types.Cursor{Height: 10}, &types.Cursor{Height: 9})
+	assert.Equal(t, "", prev)
+	cursor, err := decodeCursor(next)
+	assert.Nil(t, err)
+	assert.Equal(t, &types.Cursor{Height: 9}, cursor)
+
+	// last page of next cursor has only prev cursor
+	next, prev = pageCursors(&types.Pagination{Limit: 2, Cursor: cursor}, 1, &types.Cursor{Height: 8}, &types.Cursor{Height: 8})
+	assert.Equal(t, "", next)
+	cursor, err = decodeCursor(prev)
+	assert.Nil(t, err)
+	assert.Equal(t, &types.Cursor{Height: 8, Prev: true}, cursor)
+
+	_, err = decodeCursor("not a cursor")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,513 @@
+// Package server
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/cfg"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const (
+	etherscanStatusOK     = "1"
+	etherscanStatusNotOK  = "0"
+	etherscanMaxAddresses = 20
+	etherscanNotVerified  = "Contract source code not verified"
+)
+
+type etherscanAction func(s *Server, c echo.Context) error
+
+// etherscanActions map module and action of Etherscan's `/api?module=..&action=..` dialect to handlers
+var etherscanActions = map[string]map[string]etherscanAction{
+	"account": {
+		"balance":      (*Server).etherscanBalance,
+		"balancemulti": (*Server).etherscanBalanceMulti,
+		"txlist":       (*Server).etherscanTxList,
+		"tokentx":      (*Server).etherscanTokenTx,
+		"tokennfttx":   (*Server).etherscanTokenNFTTx,
+	},
+	"contract": {
+		"getabi":        (*Server).etherscanGetABI,
+		"getsourcecode": (*Server).etherscanGetSourceCode,
+	},
+	"transaction": {
+		"getstatus":          (*Server).etherscanTxStatus,
+		"gettxreceiptstatus": (*Server).etherscanTxReceiptStatus,
+	},
+	"block": {
+		"getblocknobytime": (*Server).etherscanBlockNoByTime,
+	},
+	"logs": {
+		"getLogs": (*Server).etherscanGetLogs,
+	},
+	"stats": {
+		"ethsupply": (*Server).etherscanSupply,
+	},
+}
+
+// EtherscanAPI serve Etherscan compatible API for existing tooling. Like Etherscan, errors are
+// returned with status "0" and HTTP 200
+func (s *Server) EtherscanAPI(c echo.Context) error {
+	actions, ok := etherscanActions[c.QueryParam("module")]
+	if !ok {
+		return etherscanError(c, "Missing Or invalid Module name")
+	}
+	action, ok := actions[c.QueryParam("action")]
+	if !ok {
+		return etherscanError(c, "Missing Or invalid Action name")
+	}
+	return action(s, c)
+}
+
+func etherscanOK(c echo.Context, result interface{}) error {
+	return c.JSON(http.StatusOK, &types.EtherscanResponse{Status: etherscanStatusOK, Message: "OK", Result: result})
+}
+
+func etherscanError(c echo.Context, msg string) error {
+	return c.JSON(http.StatusOK, &types.EtherscanResponse{Status: etherscanStatusNotOK, Message: "NOTOK", Result: "Error! " + msg})
+}
+
+// etherscanEmpty is returned for lists without any item, Etherscan consider it a failed request
+func etherscanEmpty(c echo.Context, msg string) error {
+	return c.JSON(http.StatusOK, &types.EtherscanResponse{Status: etherscanStatusNotOK, Message: msg, Result: []interface{}{}})
+}
+
+func etherscanAddress(address string) (string, bool) {
+	if !common.IsHexAddress(address) {
+		return "", false
+	}
+	return common.HexToAddress(address).String(), true
+}
+
+// getEtherscanPagination read Etherscan's `page` and `offset` params, offset is capped by our max page size
+func getEtherscanPagination(c echo.Context) *types.Pagination {
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	offset, err := strconv.Atoi(c.QueryParam("offset"))
+	if err != nil || offset <= 0 || offset > types.MaximumLimit {
+		offset = types.MaximumLimit
+	}
+	return &types.Pagination{Skip: (page - 1) * offset, Limit: offset}
+}
+
+// getEtherscanBlockParam parse block number param, `latest` or missing value return 0 which means unbounded
+func getEtherscanBlockParam(c echo.Context, name string) (uint64, error) {
+	value := c.QueryParam(name)
+	if value == "" || value == "latest" {
+		return 0, nil
+	}
+	if strings.HasPrefix(value, "0x") {
+		return strconv.ParseUint(value[2:], 16, 64)
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+func (s *Server) etherscanBalance(c echo.Context) error {
+	ctx := context.Background()
+	address, ok := etherscanAddress(c.QueryParam("address"))
+	if !ok {
+		return etherscanError(c, "Invalid address format")
+	}
+	balance, err := s.kaiClient.GetBalance(ctx, address)
+	if err != nil {
+		s.logger.Warn("Cannot get balance from RPC", zap.String("address", address), zap.Error(err))
+		return etherscanError(c, "Cannot get balance")
+	}
+	return etherscanOK(c, balance)
+}
+
+func (s *Server) etherscanBalanceMulti(c echo.Context) error {
+	ctx := context.Background()
+	addresses := strings.Split(c.QueryParam("address"), ",")
+	if len(addresses) > etherscanMaxAddresses {
+		return etherscanError(c, fmt.Sprintf("Maximum of %d addresses are allowed", etherscanMaxAddresses))
+	}
+	balances := make([]*types.EtherscanBalance, len(addresses))
+	for i, addr := range addresses {
+		address, ok := etherscanAddress(addr)
+		if !ok {
+			return etherscanError(c, "Invalid address format")
+		}
+		balance, err := s.kaiClient.GetBalance(ctx, address)
+		if err != nil {
+			s.logger.Warn("Cannot get balance from RPC", zap.String("address", address), zap.Error(err))
+			return etherscanError(c, "Cannot get balance")
+		}
+		balances[i] = &types.EtherscanBalance{Account: address, Balance: balance}
+	}
+	return etherscanOK(c, balances)
+}
+
+func (s *Server) etherscanTxList(c echo.Context) error {
+	ctx := context.Background()
+	address, ok := etherscanAddress(c.QueryParam("address"))
+	if !ok {
+		return etherscanError(c, "Invalid address format")
+	}
+	startBlock, err := getEtherscanBlockParam(c, "startblock")
+	if err != nil {
+		return etherscanError(c, "Invalid startblock")
+	}
+	endBlock, err := getEtherscanBlockParam(c, "endblock")
+	if err != nil {
+		return etherscanError(c, "Invalid endblock")
+	}
+	txs, err := s.dbClient.TxsByAddressInRange(ctx, &types.TxsByAddressFilter{
+		Pagination: getEtherscanPagination(c),
+		Address:    address,
+		FromBlock:  startBlock,
+		ToBlock:    endBlock,
+		Ascending:  c.QueryParam("sort") != "desc",
+	})
+	if err != nil {
+		s.logger.Warn("Cannot get txs of address from db", zap.String("address", address), zap.Error(err))
+		return etherscanError(c, "Cannot get transactions")
+	}
+	if len(txs) == 0 {
+		return etherscanEmpty(c, "No transactions found")
+	}
+	latestHeight := s.cacheClient.LatestBlockHeight(ctx)
+	result := make([]*types.EtherscanTx, len(txs))
+	for i, tx := range txs {
+		result[i] = toEtherscanTx(tx, latestHeight)
+	}
+	return etherscanOK(c, result)
+}
+
+func (s *Server) etherscanTokenTx(c echo.Context) error {
+	return s.etherscanTokenTransfers(c, false)
+}
+
+func (s *Server) etherscanTokenNFTTx(c echo.Context) error {
+	return s.etherscanTokenTransfers(c, true)
+}
+
+func (s *Server) etherscanTokenTransfers(c echo.Context, nft bool) error {
+	ctx := context.Background()
+	filter := &types.InternalTxsFilter{
+		Pagination: getEtherscanPagination(c),
+		Ascending:  c.QueryParam("sort") != "desc",
+	}
+	if addr := c.QueryParam("address"); addr != "" {
+		address, ok := etherscanAddress(addr)
+		if !ok {
+			return etherscanError(c, "Invalid address format")
+		}
+		filter.Address = address
+	}
+	if addr := c.QueryParam("contractaddress"); addr != "" {
+		contract, ok := etherscanAddress(addr)
+		if !ok {
+			return etherscanError(c, "Invalid contractaddress format")
+		}
+		filter.Contract = contract
+	}
+	if filter.Address == "" && filter.Contract == "" {
+		return etherscanError(c, "Missing address or contractaddress")
+	}
+	// transfers don't carry token type, so split them by KRC721 contracts
+	contracts, _, err := s.dbClient.Contracts(ctx, &types.ContractsFilter{Type: cfg.SMCTypeKRC721})
+	if err != nil {
+		s.logger.Warn("Cannot get KRC721 contracts from db", zap.Error(err))
+		return etherscanError(c, "Cannot get token transfers")
+	}
+	var nftContracts []string
+	for _, contract := range contracts {
+		nftContracts = append(nftContracts, contract.Address)
+	}
+	if nft {
+		if len(nftContracts) == 0 {
+			return etherscanEmpty(c, "No transactions found")
+		}
+		filter.Contracts = nftContracts
+	} else {
+		filter.ExcludeContracts = nftContracts
+	}
+	transfers, _, err := s.dbClient.GetListInternalTxs(ctx, filter)
+	if err != nil {
+		s.logger.Warn("Cannot get token transfers from db", zap.Error(err))
+		return etherscanError(c, "Cannot get token transfers")
+	}
+	if len(transfers) == 0 {
+		return etherscanEmpty(c, "No transactions found")
+	}
+
+	var txHashes, tokenAddresses []string
+	for _, transfer := range transfers {
+		txHashes = append(txHashes, transfer.TransactionHash)
+		tokenAddresses = append(tokenAddresses, transfer.Contract)
+	}
+	txs := s.etherscanTxsByHashes(ctx, txHashes)
+	tokens := make(map[string]*types.Address)
+	tokenInfos, err := s.dbClient.AddressesByHashes(ctx, tokenAddresses)
+	if err != nil {
+		s.logger.Warn("Cannot get token info from db", zap.Error(err))
+	}
+	for _, token := range tokenInfos {
+		tokens[token.Address] = token
+	}
+
+	latestHeight := s.cacheClient.LatestBlockHeight(ctx)
+	result := make([]*types.EtherscanTokenTransfer, len(transfers))
+	for i, transfer := range transfers {
+		result[i] = toEtherscanTokenTransfer(transfer, txs[transfer.TransactionHash], tokens[transfer.Contract], nft, latestHeight)
+	}
+	return etherscanOK(c, result)
+}
+
+// etherscanTxsByHashes return txs mapped by hash, missing txs are left out
+func (s *Server) etherscanTxsByHashes(ctx context.Context, hashes []string) map[string]*types.Transaction {
+	result := make(map[string]*types.Transaction)
+	txs, err := s.dbClient.TxsByHashes(ctx, hashes)
+	if err != nil {
+		s.logger.Warn("Cannot get txs by hashes from db", zap.Error(err))
+		return result
+	}
+	for _, tx := range txs {
+		result[tx.Hash] = tx
+	}
+	return result
+}
+
+func (s *Server) etherscanContractABI(ctx context.Context, address string) (*types.Contract, string) {
+	smc, _, err := s.dbClient.Contract(ctx, address)
+	if err != nil || smc == nil || smc.ABI == "" {
+		return smc, ""
+	}
+	// ABI is stored in base64
+	abiData, err := base64.StdEncoding.DecodeString(smc.ABI)
+	if err != nil {
+		return smc, smc.ABI
+	}
+	return smc, string(abiData)
+}
+
+func (s *Server) etherscanGetABI(c echo.Context) error {
+	address, ok := etherscanAddress(c.QueryParam("address"))
+	if !ok {
+		return etherscanError(c, "Invalid address format")
+	}
+	_, abiStr := s.etherscanContractABI(context.Background(), address)
+	if abiStr == "" {
+		return c.JSON(http.StatusOK, &types.EtherscanResponse{Status: etherscanStatusNotOK, Message: "NOTOK", Result: etherscanNotVerified})
+	}
+	return etherscanOK(c, abiStr)
+}
+
+// etherscanGetSourceCode return ABI and name only, source code is not stored by explorer
+func (s *Server) etherscanGetSourceCode(c echo.Context) error {
+	address, ok := etherscanAddress(c.QueryParam("address"))
+	if !ok {
+		return etherscanError(c, "Invalid address format")
+	}
+	smc, abiStr := s.etherscanContractABI(context.Background(), address)
+	source := &types.EtherscanSourceCode{ABI: etherscanNotVerified}
+	if smc != nil {
+		source.ContractName = smc.Name
+	}
+	if abiStr != "" {
+		source.ABI = abiStr
+	}
+	return etherscanOK(c, []*types.EtherscanSourceCode{source})
+}
+
+func (s *Server) etherscanTxStatus(c echo.Context) error {
+	tx, err := s.dbClient.TxByHash(context.Background(), c.QueryParam("txhash"))
+	if err != nil || tx == nil {
+		return etherscanError(c, "Invalid transaction hash")
+	}
+	status := &types.EtherscanTxStatus{IsError: "0"}
+	if tx.Status == 0 {
+		status.IsError = "1"
+		status.ErrDescription = "Reverted"
+	}
+	return etherscanOK(c, status)
+}
+
+func (s *Server) etherscanTxReceiptStatus(c echo.Context) error {
+	tx, err := s.dbClient.TxByHash(context.Background(), c.QueryParam("txhash"))
+	if err != nil || tx == nil {
+		return etherscanError(c, "Invalid transaction hash")
+	}
+	return etherscanOK(c, &types.EtherscanReceiptStatus{Status: strconv.FormatUint(uint64(tx.Status), 10)})
+}
+
+func (s *Server) etherscanBlockNoByTime(c echo.Context) error {
+	timestamp, err := strconv.ParseInt(c.QueryParam("timestamp"), 10, 64)
+	if err != nil {
+		return etherscanError(c, "Invalid timestamp")
+	}
+	closest := c.QueryParam("closest")
+	if closest != "before" && closest != "after" {
+		return etherscanError(c, "Invalid closest param, must be before or after")
+	}
+	block, err := s.dbClient.BlockByTime(context.Background(), time.Unix(timestamp, 0), closest == "before")
+	if err != nil {
+		return etherscanError(c, "No closest block found")
+	}
+	return etherscanOK(c, strconv.FormatUint(block.Height, 10))
+}
+
+func (s *Server) etherscanGetLogs(c echo.Context) error {
+	ctx := context.Background()
+	fromBlock, err := getEtherscanBlockParam(c, "fromBlock")
+	if err != nil {
+		return etherscanError(c, "Invalid fromBlock")
+	}
+	toBlock, err := getEtherscanBlockParam(c, "toBlock")
+	if err != nil {
+		return etherscanError(c, "Invalid toBlock")
+	}
+	filter := &types.EventsFilter{
+		Pagination: getEtherscanPagination(c),
+		FromBlock:  fromBlock,
+		ToBlock:    toBlock,
+		Ascending:  true,
+	}
+	if addr := c.QueryParam("address"); addr != "" {
+		address, ok := etherscanAddress(addr)
+		if !ok {
+			return etherscanError(c, "Invalid address format")
+		}
+		filter.ContractAddress = address
+	}
+	for i := 0; i < 4; i++ {
+		filter.Topics = append(filter.Topics, c.QueryParam(fmt.Sprintf("topic%d", i)))
+	}
+	// only `and` is supported between topics
+	for name, values := range c.QueryParams() {
+		if strings.HasSuffix(name, "_opr") && len(values) > 0 && values[0] != "and" {
+			return etherscanError(c, "Only and operator is supported between topics")
+		}
+	}
+
+	logs, _, err := s.dbClient.GetListEvents(ctx, filter)
+	if err != nil {
+		s.logger.Warn("Cannot get logs from db", zap.Error(err))
+		return etherscanError(c, "Cannot get logs")
+	}
+	if len(logs) == 0 {
+		return etherscanEmpty(c, "No records found")
+	}
+	var txHashes []string
+	for _, l := range logs {
+		txHashes = append(txHashes, l.TxHash)
+	}
+	txs := s.etherscanTxsByHashes(ctx, txHashes)
+	result := make([]*types.EtherscanLog, len(logs))
+	for i, l := range logs {
+		result[i] = toEtherscanLog(l, txs[l.TxHash])
+	}
+	return etherscanOK(c, result)
+}
+
+// etherscanSupply return circulating supply of KAI in wei
+func (s *Server) etherscanSupply(c echo.Context) error {
+	supply, err := s.kaiClient.GetCirculatingSupply(context.Background())
+	if err != nil {
+		s.logger.Warn("Cannot get circulating supply from RPC", zap.Error(err))
+		return etherscanError(c, "Cannot get supply")
+	}
+	return etherscanOK(c, supply.String())
+}
+
+func toEtherscanTx(tx *types.Transaction, latestHeight uint64) *types.EtherscanTx {
+	isError := "0"
+	if tx.Status == 0 {
+		isError = "1"
+	}
+	return &types.EtherscanTx{
+		BlockNumber:       strconv.FormatUint(tx.BlockNumber, 10),
+		TimeStamp:         strconv.FormatInt(tx.Time.Unix(), 10),
+		Hash:              tx.Hash,
+		Nonce:             strconv.FormatUint(tx.Nonce, 10),
+		BlockHash:         tx.BlockHash,
+		TransactionIndex:  strconv.FormatUint(uint64(tx.TransactionIndex), 10),
+		From:              tx.From,
+		To:                tx.To,
+		Value:             tx.Value,
+		Gas:               strconv.FormatUint(tx.GasLimit, 10),
+		GasPrice:          strconv.FormatUint(tx.GasPrice, 10),
+		IsError:           isError,
+		TxReceiptStatus:   strconv.FormatUint(uint64(tx.Status), 10),
+		Input:             tx.InputData,
+		ContractAddress:   tx.ContractAddress,
+		CumulativeGasUsed: strconv.FormatUint(tx.GasUsed, 10),
+		GasUsed:           strconv.FormatUint(tx.GasUsed, 10),
+		Confirmations:     etherscanConfirmations(tx.BlockNumber, latestHeight),
+	}
+}
+
+// toEtherscanTokenTransfer build transfer from internal tx, tx and token may be nil if they are not indexed yet
+func toEtherscanTokenTransfer(transfer *types.TokenTransfer, tx *types.Transaction, token *types.Address, nft bool, latestHeight uint64) *types.EtherscanTokenTransfer {
+	result := &types.EtherscanTokenTransfer{
+		TimeStamp:       strconv.FormatInt(transfer.Time.Unix(), 10),
+		Hash:            transfer.TransactionHash,
+		From:            transfer.From,
+		ContractAddress: transfer.Contract,
+		To:              transfer.To,
+		Value:           transfer.Value,
+	}
+	if nft {
+		result.Value = ""
+		result.TokenID = transfer.Value
+	}
+	if token != nil {
+		result.TokenName = token.TokenName
+		result.TokenSymbol = token.TokenSymbol
+		result.TokenDecimal = strconv.FormatInt(token.Decimals, 10)
+	}
+	if tx != nil {
+		result.BlockNumber = strconv.FormatUint(tx.BlockNumber, 10)
+		result.Nonce = strconv.FormatUint(tx.Nonce, 10)
+		result.BlockHash = tx.BlockHash
+		result.TransactionIndex = strconv.FormatUint(uint64(tx.TransactionIndex), 10)
+		result.Gas = strconv.FormatUint(tx.GasLimit, 10)
+		result.GasPrice = strconv.FormatUint(tx.GasPrice, 10)
+		result.GasUsed = strconv.FormatUint(tx.GasUsed, 10)
+		result.Input = "deprecated"
+		result.Confirmations = etherscanConfirmations(tx.BlockNumber, latestHeight)
+	}
+	return result
+}
+
+func toEtherscanLog(l *types.Log, tx *types.Transaction) *types.EtherscanLog {
+	result := &types.EtherscanLog{
+		Address:          l.Address,
+		Topics:           l.Topics,
+		Data:             l.Data,
+		BlockNumber:      hexUint(l.BlockHeight),
+		TimeStamp:        hexUint(uint64(l.Time.Unix())),
+		LogIndex:         hexUint(uint64(l.Index)),
+		TransactionHash:  l.TxHash,
+		TransactionIndex: hexUint(uint64(l.TxIndex)),
+	}
+	if tx != nil {
+		result.GasPrice = hexUint(tx.GasPrice)
+		result.GasUsed = hexUint(tx.GasUsed)
+	}
+	return result
+}
+
+func etherscanConfirmations(blockNumber, latestHeight uint64) string {
+	if latestHeight < blockNumber {
+		return "0"
+	}
+	return strconv.FormatUint(latestHeight-blockNumber+1, 10)
+}
+
+func hexUint(v uint64) string {
+	return "0x" + strconv.FormatUint(v, 16)
+}
@@ -0,0 +1,49 @@
+// Package server
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_toEtherscanTx(t *testing.T) {
+	tx := &types.Transaction{
+		BlockNumber: 100,
+		Hash:        "0xhash",
+		Status:      0,
+		GasLimit:    21000,
+		GasUsed:     20000,
+		Time:        time.Unix(1600000000, 0),
+	}
+	result := toEtherscanTx(tx, 109)
+	assert.Equal(t, "100", result.BlockNumber)
+	assert.Equal(t, "1600000000", result.TimeStamp)
+	assert.Equal(t, "1", result.IsError)
+	assert.Equal(t, "0", result.TxReceiptStatus)
+	assert.Equal(t, "21000", result.Gas)
+	assert.Equal(t, "10", result.Confirmations)
+
+	// block newer than cached latest height
+	assert.Equal(t, "0", toEtherscanTx(tx, 99).Confirmations)
+}
+
+func Test_toEtherscanTokenTransfer(t *testing.T) {
+	transfer := &types.TokenTransfer{TransactionHash: "0xhash", Contract: "0xtoken", Value: "7", Time: time.Unix(1600000000, 0)}
+	token := &types.Address{TokenName: "Token", TokenSymbol: "TKN", Decimals: 18}
+
+	// tx is not indexed yet
+	result := toEtherscanTokenTransfer(transfer, nil, token, false, 10)
+	assert.Equal(t, "7", result.Value)
+	assert.Equal(t, "18", result.TokenDecimal)
+	assert.Equal(t, "", result.BlockNumber)
+
+	result = toEtherscanTokenTransfer(transfer, &types.Transaction{BlockNumber: 5}, nil, true, 10)
+	assert.Equal(t, "", result.Value)
+	assert.Equal(t, "7", result.TokenID)
+	assert.Equal(t, "5", result.BlockNumber)
+	assert.Equal(t, "6", result.Confirmations)
+}
@@ -0,0 +1,524 @@
+// Package server
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/api"
+	"github.com/kardiachain/kardia-explorer-backend/price"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const (
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+	// exportFlushRows is number of rows buffered before flushing to client
+	exportFlushRows = 200
+	// exportErrorMarker starts the last row or line of an export which is cut short, status is already sent as 200
+	exportErrorMarker      = "#error"
+	exportInterruptedError = "export is interrupted, rows are incomplete"
+
+	kaiDecimals      = 18
+	priceSymbolKAI   = price.Symbol
+	priceCurrencyUSD = price.Currency
+	wrappedKAISymbol = "WKAI"
+)
+
+// exportWriter write rows of a fixed header, values which are not strings are written as JSON
+type exportWriter interface {
+	WriteRow(values []interface{}) error
+	WriteError(msg string) error
+	Flush() error
+}
+
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func newCSVExportWriter(w io.Writer, header []string) (*csvExportWriter, error) {
+	writer := &csvExportWriter{w: csv.NewWriter(w)}
+	if err := writer.w.Write(header); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (w *csvExportWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		switch v := v.(type) {
+		case string:
+			record[i] = escapeCSVFormula(v)
+		case nil:
+		default:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			record[i] = string(b)
+		}
+	}
+	return w.w.Write(record)
+}
+
+// escapeCSVFormula prefix cells which spreadsheets would run as formula, names and symbols
+// of tokens are set by anyone deploying a contract
+func escapeCSVFormula(v string) string {
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
+
+func (w *csvExportWriter) WriteError(msg string) error {
+	return w.w.Write([]string{exportErrorMarker, msg})
+}
+
+func (w *csvExportWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+type ndjsonExportWriter struct {
+	w      io.Writer
+	header []string
+	buf    bytes.Buffer
+}
+
+func newNDJSONExportWriter(w io.Writer, header []string) *ndjsonExportWriter {
+	return &ndjsonExportWriter{w: w, header: header}
+}
+
+// WriteRow keep keys in header order, so lines are easy to read and diff
+func (w *ndjsonExportWriter) WriteRow(values []interface{}) error {
+	w.buf.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			w.buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(w.header[i])
+		value, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		w.buf.Write(key)
+		w.buf.WriteByte(':')
+		w.buf.Write(value)
+	}
+	w.buf.WriteString("}\n")
+	return nil
+}
+
+func (w *ndjsonExportWriter) WriteError(msg string) error {
+	line, err := json.Marshal(map[string]string{exportErrorMarker: msg})
+	if err != nil {
+		return err
+	}
+	w.buf.Write(line)
+	w.buf.WriteByte('\n')
+	return nil
+}
+
+func (w *ndjsonExportWriter) Flush() error {
+	_, err := w.w.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+// exportStream write rows to response and flush it periodically, so large exports start downloading immediately
+type exportStream struct {
+	c      echo.Context
+	writer exportWriter
+	rows   int
+}
+
+func newExportStream(c echo.Context, format, filename string, header []string) (*exportStream, error) {
+	res := c.Response()
+	var (
+		writer exportWriter
+		err    error
+	)
+	switch format {
+	case exportFormatCSV:
+		res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+		writer, err = newCSVExportWriter(res, header)
+	case exportFormatNDJSON:
+		res.Header().Set(echo.HeaderContentType, "application/x-ndjson")
+		writer = newNDJSONExportWriter(res, header)
+	default:
+		return nil, fmt.Errorf("unknown export format %s", format)
+	}
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename+"."+format))
+	res.WriteHeader(http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return &exportStream{c: c, writer: writer}, nil
+}
+
+func (s *exportStream) Write(values ...interface{}) error {
+	if err := s.writer.WriteRow(values); err != nil {
+		return err
+	}
+	s.rows++
+	if s.rows%exportFlushRows == 0 {
+		return s.Flush()
+	}
+	return nil
+}
+
+// Close end the stream, an error row is written when rows are cut short so clients can tell it from a complete export
+func (s *exportStream) Close(err error) error {
+	if err != nil {
+		if err := s.writer.WriteError(exportInterruptedError); err != nil {
+			return err
+		}
+	}
+	return s.Flush()
+}
+
+func (s *exportStream) Flush() error {
+	if err := s.writer.Flush(); err != nil {
+		return err
+	}
+	s.c.Response().Flush()
+	return nil
+}
+
+type exportRequest struct {
+	format string
+	fiat   bool
+	filter *types.ExportFilter
+}
+
+// getExportRequest read `format` (csv or ndjson), unix `from`/`to` and `fiat`, range defaults to whole history
+func getExportRequest(c echo.Context) (*exportRequest, error) {
+	req := &exportRequest{
+		format: strings.ToLower(c.QueryParam("format")),
+		filter: &types.ExportFilter{From: time.Unix(0, 0), To: time.Now()},
+	}
+	if req.format == "" {
+		req.format = exportFormatCSV
+	}
+	if req.format != exportFormatCSV && req.format != exportFormatNDJSON {
+		return nil, fmt.Errorf("unknown export format %s", req.format)
+	}
+	if from := c.QueryParam("from"); from != "" {
+		ts, err := strconv.ParseInt(from, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		req.filter.From = time.Unix(ts, 0)
+	}
+	if to := c.QueryParam("to"); to != "" {
+		ts, err := strconv.ParseInt(to, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		req.filter.To = time.Unix(ts, 0)
+	}
+	if req.filter.From.After(req.filter.To) {
+		return nil, fmt.Errorf("from is after to")
+	}
+	req.fiat, _ = strconv.ParseBool(c.QueryParam("fiat"))
+	return req, nil
+}
+
+func exportAddressParam(c echo.Context, name string) (string, bool) {
+	address := c.Param(name)
+	if !common.IsHexAddress(address) {
+		return "", false
+	}
+	return common.HexToAddress(address).Hex(), true
+}
+
+// formatUnits apply decimals to a raw integer amount without losing precision, e.g. 1500000000000000000 with 18 decimals is 1.5
+func formatUnits(raw string, decimals int64) string {
+	amount, ok := new(big.Int).SetString(raw, 10)
+	if !ok {
+		return raw
+	}
+	if decimals <= 0 {
+		return amount.String()
+	}
+	sign := ""
+	if amount.Sign() < 0 {
+		sign = "-"
+		amount.Abs(amount)
+	}
+	digits := amount.String()
+	if int64(len(digits)) <= decimals {
+		digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+	}
+	point := int64(len(digits)) - decimals
+	integer, fraction := digits[:point], strings.TrimRight(digits[point:], "0")
+	if fraction == "" {
+		return sign + integer
+	}
+	return sign + integer + "." + fraction
+}
+
+// priceAt return the latest price recorded at or before t, points must be ascending by time
+func priceAt(points []*types.PricePoint, t time.Time) (float64, bool) {
+	i := sort.Search(len(points), func(i int) bool {
+		return points[i].Time.After(t)
+	})
+	if i == 0 {
+		return 0, false
+	}
+	return points[i-1].Price, true
+}
+
+// fiatValue return amount (with decimals applied) times price at t, empty if price is unknown
+func fiatValue(points []*types.PricePoint, amount string, t time.Time) (string, string) {
+	price, ok := priceAt(points, t)
+	if !ok {
+		return "", ""
+	}
+	value, ok := new(big.Float).SetString(amount)
+	if !ok {
+		return strconv.FormatFloat(price, 'f', -1, 64), ""
+	}
+	value.Mul(value, big.NewFloat(price))
+	return strconv.FormatFloat(price, 'f', -1, 64), value.Text('f', 6)
+}
+
+func exportDirection(address, from, to string) string {
+	switch {
+	case strings.EqualFold(from, address) && strings.EqualFold(to, address):
+		return "self"
+	case strings.EqualFold(from, address):
+		return "out"
+	default:
+		return "in"
+	}
+}
+
+func (s *Server) exportPrices(ctx context.Context, req *exportRequest) []*types.PricePoint {
+	if !req.fiat {
+		return nil
+	}
+	points, err := s.dbClient.PricePoints(ctx, priceSymbolKAI, priceCurrencyUSD, req.filter.From, req.filter.To)
+	if err != nil {
+		// export without fiat values rather than failing
+		s.logger.Warn("Cannot get price history for export", zap.Error(err))
+	}
+	return points
+}
+
+func exportFiatHeader(header []string, fiat bool) []string {
+	if !fiat {
+		return header
+	}
+	return append(header, "priceUSD", "valueUSD")
+}
+
+// ExportAddressTxs export KAI txs of an address, value and fee are in KAI
+func (s *Server) ExportAddressTxs(c echo.Context) error {
+	// request context is cancelled when client goes away, which stops the cursor
+	ctx := c.Request().Context()
+	address, ok := exportAddressParam(c, "address")
+	if !ok {
+		return api.Invalid.Build(c)
+	}
+	req, err := getExportRequest(c)
+	if err != nil {
+		return api.Invalid.Build(c)
+	}
+	req.filter.Address = address
+	prices := s.exportPrices(ctx, req)
+	header := exportFiatHeader([]string{"time", "blockNumber", "txHash", "from", "to", "direction", "value", "txFee", "status", "method"}, req.fiat)
+	stream, err := newExportStream(c, req.format, address+"-txs", header)
+	if err != nil {
+		return err
+	}
+	err = s.dbClient.ExportTxs(ctx, req.filter, func(tx *types.Transaction) error {
+		method := ""
+		if tx.DecodedInputData != nil {
+			method = tx.DecodedInputData.MethodName
+		}
+		value := formatUnits(tx.Value, kaiDecimals)
+		row := []interface{}{
+			tx.Time.UTC().Format(time.RFC3339), strconv.FormatUint(tx.BlockNumber, 10), tx.Hash, tx.From, tx.To,
+			exportDirection(address, tx.From, tx.To), value, formatUnits(tx.TxFee, kaiDecimals), strconv.FormatUint(uint64(tx.Status), 10), method,
+		}
+		if req.fiat {
+			price, fiat := fiatValue(prices, value, tx.Time)
+			row = append(row, price, fiat)
+		}
+		return stream.Write(row...)
+	})
+	if err != nil {
+		s.logger.Warn("Export address txs is interrupted", zap.String("address", address), zap.Error(err))
+	}
+	return stream.Close(err)
+}
+
+// isWrappedKAI is true for verified wrapped KAI, it's the only token which fiat value is known since price history
+// is only kept for KAI
+func isWrappedKAI(token *types.KRCTokenInfo) bool {
+	return token.IsVerified && strings.EqualFold(token.TokenSymbol, wrappedKAISymbol)
+}
+
+// ExportAddressTokenTransfers export KRC transfers of an address, values have token decimals applied. Fiat values
+// are only filled for wrapped KAI
+func (s *Server) ExportAddressTokenTransfers(c echo.Context) error {
+	ctx := c.Request().Context()
+	address, ok := exportAddressParam(c, "address")
+	if !ok {
+		return api.Invalid.Build(c)
+	}
+	req, err := getExportRequest(c)
+	if err != nil {
+		return api.Invalid.Build(c)
+	}
+	req.filter.Address = address
+	if contract := c.QueryParam("contract"); contract != "" {
+		req.filter.Contract = common.HexToAddress(contract).Hex()
+	}
+	prices := s.exportPrices(ctx, req)
+	header := exportFiatHeader([]string{"time", "txHash", "contract", "tokenSymbol", "from", "to", "direction", "value", "rawValue"}, req.fiat)
+	stream, err := newExportStream(c, req.format, address+"-token-transfers", header)
+	if err != nil {
+		return err
+	}
+	tokens := make(map[string]*types.KRCTokenInfo)
+	err = s.dbClient.ExportTokenTransfers(ctx, req.filter, func(transfer *types.TokenTransfer) error {
+		token, ok := tokens[transfer.Contract]
+		if !ok {
+			info, err := s.getKRCTokenInfo(ctx, transfer.Contract)
+			if err != nil {
+				// unknown token, keep raw value
+				info = &types.KRCTokenInfo{}
+			}
+			token = info
+			tokens[transfer.Contract] = token
+		}
+		value := formatUnits(transfer.Value, token.Decimals)
+		row := []interface{}{
+			transfer.Time.UTC().Format(time.RFC3339), transfer.TransactionHash, transfer.Contract, token.TokenSymbol, transfer.From, transfer.To,
+			exportDirection(address, transfer.From, transfer.To), value, transfer.Value,
+		}
+		if req.fiat {
+			price, fiat := "", ""
+			if isWrappedKAI(token) {
+				price, fiat = fiatValue(prices, value, transfer.Time)
+			}
+			row = append(row, price, fiat)
+		}
+		return stream.Write(row...)
+	})
+	if err != nil {
+		s.logger.Warn("Export token transfers is interrupted", zap.String("address", address), zap.Error(err))
+	}
+	return stream.Close(err)
+}
+
+// ExportAddressRewards export staking reward snapshots of a delegator, amounts are in KAI
+func (s *Server) ExportAddressRewards(c echo.Context) error {
+	ctx := c.Request().Context()
+	address, ok := exportAddressParam(c, "address")
+	if !ok {
+		return api.Invalid.Build(c)
+	}
+	req, err := getExportRequest(c)
+	if err != nil {
+		return api.Invalid.Build(c)
+	}
+	req.filter.Address = address
+	prices := s.exportPrices(ctx, req)
+	header := exportFiatHeader([]string{"time", "validatorSMCAddress", "stakedAmount", "reward", "withdrawnReward", "earnedReward"}, req.fiat)
+	stream, err := newExportStream(c, req.format, address+"-rewards", header)
+	if err != nil {
+		return err
+	}
+	err = s.dbClient.ExportRewardSnapshots(ctx, req.filter, func(snapshot *types.RewardSnapshot) error {
+		earned := formatUnits(snapshot.EarnedReward, kaiDecimals)
+		row := []interface{}{
+			snapshot.Time.UTC().Format(time.RFC3339), snapshot.ValidatorSMCAddress, formatUnits(snapshot.StakedAmount, kaiDecimals),
+			formatUnits(snapshot.Reward, kaiDecimals), formatUnits(snapshot.WithdrawnReward, kaiDecimals), earned,
+		}
+		if req.fiat {
+			price, fiat := fiatValue(prices, earned, snapshot.Time)
+			row = append(row, price, fiat)
+		}
+		return stream.Write(row...)
+	})
+	if err != nil {
+		s.logger.Warn("Export rewards is interrupted", zap.String("address", address), zap.Error(err))
+	}
+	return stream.Close(err)
+}
+
+// ExportTokenHolders export current holders of a token, ordered by balance
+func (s *Server) ExportTokenHolders(c echo.Context) error {
+	ctx := c.Request().Context()
+	contract, ok := exportAddressParam(c, "contract")
+	if !ok {
+		return api.Invalid.Build(c)
+	}
+	req, err := getExportRequest(c)
+	if err != nil {
+		return api.Invalid.Build(c)
+	}
+	req.filter.Contract = contract
+	header := []string{"holderAddress", "tokenSymbol", "balance", "rawBalance", "updatedAt"}
+	stream, err := newExportStream(c, req.format, contract+"-holders", header)
+	if err != nil {
+		return err
+	}
+	err = s.dbClient.ExportTokenHolders(ctx, req.filter, func(holder *types.TokenHolder) error {
+		return stream.Write(
+			holder.HolderAddress, holder.TokenSymbol, formatUnits(holder.BalanceString, holder.TokenDecimals), holder.BalanceString,
+			time.Unix(holder.UpdatedAt, 0).UTC().Format(time.RFC3339),
+		)
+	})
+	if err != nil {
+		s.logger.Warn("Export token holders is interrupted", zap.String("contract", contract), zap.Error(err))
+	}
+	return stream.Close(err)
+}
+
+// ExportContractEvents export decoded events of a contract, arguments are written as JSON
+func (s *Server) ExportContractEvents(c echo.Context) error {
+	ctx := c.Request().Context()
+	contract, ok := exportAddressParam(c, "contract")
+	if !ok {
+		return api.Invalid.Build(c)
+	}
+	req, err := getExportRequest(c)
+	if err != nil {
+		return api.Invalid.Build(c)
+	}
+	req.filter.Contract = contract
+	header := []string{"time", "blockHeight", "txHash", "logIndex", "methodName", "arguments", "topics", "data"}
+	stream, err := newExportStream(c, req.format, contract+"-events", header)
+	if err != nil {
+		return err
+	}
+	err = s.dbClient.ExportEvents(ctx, req.filter, func(event *types.Log) error {
+		return stream.Write(
+			event.Time.UTC().Format(time.RFC3339), strconv.FormatUint(event.BlockHeight, 10), event.TxHash, strconv.FormatUint(uint64(event.Index), 10),
+			event.MethodName, event.Arguments, event.Topics, event.Data,
+		)
+	})
+	if err != nil {
+		s.logger.Warn("Export contract events is interrupted", zap.String("contract", contract), zap.Error(err))
+	}
+	return stream.Close(err)
+}
@@ -0,0 +1,98 @@
+// Package server
+package server
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_formatUnits(t *testing.T) {
+	assert.Equal(t, "1.5", formatUnits("1500000000000000000", 18))
+	assert.Equal(t, "0.000000000000000001", formatUnits("1", 18))
+	assert.Equal(t, "0", formatUnits("0", 18))
+	assert.Equal(t, "100", formatUnits("10000", 2))
+	assert.Equal(t, "-0.25", formatUnits("-25", 2))
+	assert.Equal(t, "42", formatUnits("42", 0))
+	assert.Equal(t, "", formatUnits("", 18))
+}
+
+func Test_priceAt(t *testing.T) {
+	now := time.Now()
+	points := []*types.PricePoint{
+		{Price: 1, Time: now.Add(-2 * time.Hour)},
+		{Price: 2, Time: now.Add(-time.Hour)},
+	}
+	_, ok := priceAt(points, now.Add(-3*time.Hour))
+	assert.False(t, ok)
+	price, _ := priceAt(points, now.Add(-90*time.Minute))
+	assert.Equal(t, float64(1), price)
+	price, _ = priceAt(points, now.Add(-time.Hour))
+	assert.Equal(t, float64(2), price)
+
+	price2, value := fiatValue(points, "1.5", now)
+	assert.Equal(t, "2", price2)
+	assert.Equal(t, "3.000000", value)
+}
+
+func Test_exportWriters(t *testing.T) {
+	header := []string{"hash", "value", "arguments"}
+	row := []interface{}{"0x1", "1.5", map[string]interface{}{"to": "0x2"}}
+
+	var buf bytes.Buffer
+	csvWriter, err := newCSVExportWriter(&buf, header)
+	assert.Nil(t, err)
+	assert.Nil(t, csvWriter.WriteRow(row))
+	assert.Nil(t, csvWriter.Flush())
+	assert.Equal(t, "hash,value,arguments\n0x1,1.5,\"{\"\"to\"\":\"\"0x2\"\"}\"\n", buf.String())
+
+	buf.Reset()
+	ndjsonWriter := newNDJSONExportWriter(&buf, header)
+	assert.Nil(t, ndjsonWriter.WriteRow(row))
+	assert.Nil(t, ndjsonWriter.WriteRow(row))
+	assert.Nil(t, ndjsonWriter.Flush())
+	line := `{"hash":"0x1","value":"1.5","arguments":{"to":"0x2"}}` + "\n"
+	assert.Equal(t, line+line, buf.String())
+}
+
+func Test_escapeCSVFormula(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "0x1", want: "0x1"},
+		{in: "", want: ""},
+		{in: "=HYPERLINK(\"x\")", want: "'=HYPERLINK(\"x\")"},
+		{in: "+1", want: "'+1"},
+		{in: "-1", want: "'-1"},
+		{in: "@SUM(A1)", want: "'@SUM(A1)"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, escapeCSVFormula(tt.in))
+	}
+}
+
+func Test_exportWritersError(t *testing.T) {
+	var buf bytes.Buffer
+	csvWriter, err := newCSVExportWriter(&buf, []string{"hash"})
+	assert.Nil(t, err)
+	assert.Nil(t, csvWriter.WriteError(exportInterruptedError))
+	assert.Nil(t, csvWriter.Flush())
+	assert.Equal(t, "hash\n#error,\""+exportInterruptedError+"\"\n", buf.String())
+
+	buf.Reset()
+	ndjsonWriter := newNDJSONExportWriter(&buf, []string{"hash"})
+	assert.Nil(t, ndjsonWriter.WriteError(exportInterruptedError))
+	assert.Nil(t, ndjsonWriter.Flush())
+	assert.Equal(t, `{"#error":"`+exportInterruptedError+`"}`+"\n", buf.String())
+}
+
+func Test_isWrappedKAI(t *testing.T) {
+	assert.True(t, isWrappedKAI(&types.KRCTokenInfo{TokenSymbol: "wkai", IsVerified: true}))
+	assert.False(t, isWrappedKAI(&types.KRCTokenInfo{TokenSymbol: "WKAI"}))
+	assert.False(t, isWrappedKAI(&types.KRCTokenInfo{TokenSymbol: "BEC", IsVerified: true}))
+}
@@ -0,0 +1,221 @@
+// Package server
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/labstack/echo"
+)
+
+const (
+	defaultGraphQLMaxDepth      = 8
+	defaultGraphQLMaxComplexity = 1000
+)
+
+type graphQLServer struct {
+	schema        graphql.Schema
+	listFields    map[string]bool
+	maxDepth      int
+	maxComplexity int
+}
+
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func newGraphQLServer(s *Server, maxDepth, maxComplexity int) (*graphQLServer, error) {
+	if maxDepth <= 0 {
+		maxDepth = defaultGraphQLMaxDepth
+	}
+	if maxComplexity <= 0 {
+		maxComplexity = defaultGraphQLMaxComplexity
+	}
+	schema, err := newGraphQLSchema(s)
+	if err != nil {
+		return nil, err
+	}
+	return &graphQLServer{
+		schema:        schema,
+		listFields:    listFieldNames(schema),
+		maxDepth:      maxDepth,
+		maxComplexity: maxComplexity,
+	}, nil
+}
+
+// GraphQL execute a query against the explorer schema, both GET and POST are accepted
+func (s *Server) GraphQL(c echo.Context) error {
+	var req graphQLRequest
+	if c.Request().Method == http.MethodGet {
+		req.Query = c.QueryParam("query")
+		req.OperationName = c.QueryParam("operationName")
+		if vars := c.QueryParam("variables"); vars != "" {
+			if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+				return c.JSON(http.StatusBadRequest, graphQLError("invalid variables"))
+			}
+		}
+	} else if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, graphQLError("invalid request body"))
+	}
+	if req.Query == "" {
+		return c.JSON(http.StatusBadRequest, graphQLError("missing query"))
+	}
+	if err := s.graphQL.checkLimits(req.Query, req.Variables); err != nil {
+		return c.JSON(http.StatusOK, graphQLError(err.Error()))
+	}
+
+	ctx := context.WithValue(context.Background(), graphQLLoadersKey{}, s.newGraphQLLoaders())
+	result := graphql.Do(graphql.Params{
+		Schema:         s.graphQL.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        ctx,
+	})
+	return c.JSON(http.StatusOK, result)
+}
+
+func graphQLError(msg string) *graphql.Result {
+	return &graphql.Result{Errors: []gqlerrors.FormattedError{{Message: msg}}}
+}
+
+// checkLimits reject query which is nested too deep or may fetch too many items. Syntax errors
+// are left for graphql.Do to report
+func (g *graphQLServer) checkLimits(query string, variables map[string]interface{}) error {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return nil
+	}
+	depth, complexity := measureQuery(doc, variables, g.listFields)
+	if depth > g.maxDepth {
+		return fmt.Errorf("query depth %d exceeds limit %d", depth, g.maxDepth)
+	}
+	if complexity > g.maxComplexity {
+		return fmt.Errorf("query complexity %d exceeds limit %d", complexity, g.maxComplexity)
+	}
+	return nil
+}
+
+// measureQuery return max depth and complexity of operations in doc. Each field cost 1, plus
+// cost of its children multiplied by its `limit` argument for list fields
+func measureQuery(doc *ast.Document, variables map[string]interface{}, listFields map[string]bool) (int, int) {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, def := range doc.Definitions {
+		if frag, ok := def.(*ast.FragmentDefinition); ok && frag.Name != nil {
+			fragments[frag.Name.Value] = frag
+		}
+	}
+	var maxDepth, maxComplexity int
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		depth, complexity := measureSelectionSet(op.SelectionSet, withVariableDefaults(op, variables), listFields, fragments, make(map[string]bool))
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+		if complexity > maxComplexity {
+			maxComplexity = complexity
+		}
+	}
+	return maxDepth, maxComplexity
+}
+
+func measureSelectionSet(set *ast.SelectionSet, variables map[string]interface{}, listFields map[string]bool, fragments map[string]*ast.FragmentDefinition, visiting map[string]bool) (int, int) {
+	if set == nil {
+		return 0, 0
+	}
+	var depth, complexity int
+	for _, selection := range set.Selections {
+		var d, cpx int
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if sel.Name == nil || strings.HasPrefix(sel.Name.Value, "__") {
+				continue
+			}
+			childDepth, childComplexity := measureSelectionSet(sel.SelectionSet, variables, listFields, fragments, visiting)
+			d = childDepth + 1
+			cpx = 1 + childComplexity*listSize(sel, variables, listFields)
+		case *ast.InlineFragment:
+			d, cpx = measureSelectionSet(sel.SelectionSet, variables, listFields, fragments, visiting)
+		case *ast.FragmentSpread:
+			frag, ok := fragments[sel.Name.Value]
+			if !ok || visiting[sel.Name.Value] {
+				continue
+			}
+			visiting[sel.Name.Value] = true
+			d, cpx = measureSelectionSet(frag.SelectionSet, variables, listFields, fragments, visiting)
+			delete(visiting, sel.Name.Value)
+		}
+		if d > depth {
+			depth = d
+		}
+		complexity += cpx
+	}
+	return depth, complexity
+}
+
+// withVariableDefaults return variables of op, filling the ones not given with their default
+// value, e.g `query($n: Int = 100)`
+func withVariableDefaults(op *ast.OperationDefinition, variables map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(variables))
+	for name, value := range variables {
+		merged[name] = value
+	}
+	for _, def := range op.VariableDefinitions {
+		if def.Variable == nil || def.Variable.Name == nil {
+			continue
+		}
+		if _, ok := merged[def.Variable.Name.Value]; ok {
+			continue
+		}
+		if v, ok := def.DefaultValue.(*ast.IntValue); ok {
+			if size, err := strconv.Atoi(v.Value); err == nil {
+				merged[def.Variable.Name.Value] = size
+			}
+		}
+	}
+	return merged
+}
+
+// listSize read `limit` argument of a field, as literal or variable. List fields without it
+// return default limit
+func listSize(field *ast.Field, variables map[string]interface{}, listFields map[string]bool) int {
+	for _, arg := range field.Arguments {
+		if arg.Name == nil || arg.Name.Value != "limit" {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			if size, err := strconv.Atoi(v.Value); err == nil && size > 0 {
+				return size
+			}
+		case *ast.Variable:
+			switch size := variables[v.Name.Value].(type) {
+			case float64:
+				if size > 0 {
+					return int(size)
+				}
+			case int:
+				if size > 0 {
+					return size
+				}
+			}
+		}
+	}
+	if listFields[field.Name.Value] {
+		return graphQLDefaultLimit
+	}
+	return 1
+}
@@ -0,0 +1,136 @@
+// Package server
+package server
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kardiachain/kardia-explorer-backend/db"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+type graphQLLoadersKey struct{}
+
+// batchLoader collect keys requested by resolvers of the same level and fetch them in one query.
+// graphql-go resolve thunks breadth-first, so the first thunk called trigger the fetch of all
+// keys registered by its siblings.
+type batchLoader struct {
+	mu      sync.Mutex
+	fetch   func(ctx context.Context, keys []string) (map[string]interface{}, error)
+	pending []string
+	results map[string]interface{}
+	errs    map[string]error
+}
+
+func newBatchLoader(fetch func(ctx context.Context, keys []string) (map[string]interface{}, error)) *batchLoader {
+	return &batchLoader{
+		fetch:   fetch,
+		results: make(map[string]interface{}),
+		errs:    make(map[string]error),
+	}
+}
+
+func (l *batchLoader) load(ctx context.Context, key string) func() (interface{}, error) {
+	l.mu.Lock()
+	if _, ok := l.results[key]; !ok {
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if len(l.pending) > 0 {
+			keys := l.pending
+			l.pending = nil
+			values, err := l.fetch(ctx, keys)
+			for _, k := range keys {
+				l.results[k] = values[k]
+				if err != nil {
+					l.errs[k] = err
+				}
+			}
+		}
+		if err := l.errs[key]; err != nil {
+			return nil, err
+		}
+		return l.results[key], nil
+	}
+}
+
+// graphQLLoaders live in a single request, so results are not shared between requests
+type graphQLLoaders struct {
+	addresses  *batchLoader
+	blocks     *batchLoader
+	validators *batchLoader
+}
+
+func (s *Server) newGraphQLLoaders() *graphQLLoaders {
+	return &graphQLLoaders{
+		addresses:  newBatchLoader(s.fetchAddresses),
+		blocks:     newBatchLoader(s.fetchBlocks),
+		validators: newBatchLoader(s.fetchValidators),
+	}
+}
+
+func loadersFromContext(ctx context.Context) *graphQLLoaders {
+	loaders, _ := ctx.Value(graphQLLoadersKey{}).(*graphQLLoaders)
+	return loaders
+}
+
+func (s *Server) fetchAddresses(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	addresses, err := s.dbClient.AddressesByHashes(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{}, len(addresses))
+	for _, addr := range addresses {
+		result[addr.Address] = addr
+	}
+	// addresses which are not indexed yet still resolve with their hash
+	for _, k := range keys {
+		if _, ok := result[k]; !ok {
+			result[k] = &types.Address{Address: k, BalanceString: "0"}
+		}
+	}
+	return result, nil
+}
+
+func (s *Server) fetchBlocks(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	heights := make([]uint64, 0, len(keys))
+	for _, k := range keys {
+		height, err := strconv.ParseUint(k, 10, 64)
+		if err != nil {
+			continue
+		}
+		heights = append(heights, height)
+	}
+	blocks, err := s.dbClient.BlocksByHeights(ctx, heights)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{}, len(blocks))
+	for _, b := range blocks {
+		result[strconv.FormatUint(b.Height, 10)] = b
+	}
+	return result, nil
+}
+
+// fetchValidators load the whole validator set once, keys are either validator or staking
+// contract address
+func (s *Server) fetchValidators(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	validators, err := s.dbClient.Validators(ctx, db.ValidatorsFilter{})
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{}, len(keys))
+	for _, v := range validators {
+		for _, k := range keys {
+			if strings.EqualFold(k, v.Address) || strings.EqualFold(k, v.SmcAddress) {
+				result[k] = v
+			}
+		}
+	}
+	return result, nil
+}
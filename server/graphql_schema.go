@@ -0,0 +1,558 @@
+// Package server
+package server
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/kardiachain/go-kardia/lib/common"
+
+	"github.com/kardiachain/kardia-explorer-backend/db"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const graphQLDefaultLimit = 10
+
+func graphQLPagingArgs(extra graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	args := graphql.FieldConfigArgument{
+		"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: graphQLDefaultLimit},
+		"skip":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+	}
+	for name, arg := range extra {
+		args[name] = arg
+	}
+	return args
+}
+
+func graphQLPagination(p graphql.ResolveParams) *types.Pagination {
+	limit, _ := p.Args["limit"].(int)
+	skip, _ := p.Args["skip"].(int)
+	pagination := &types.Pagination{Skip: skip, Limit: limit}
+	pagination.Sanitize()
+	return pagination
+}
+
+func graphQLAddressArg(p graphql.ResolveParams, name string) string {
+	addr, _ := p.Args[name].(string)
+	return common.HexToAddress(addr).String()
+}
+
+func newGraphQLSchema(s *Server) (graphql.Schema, error) {
+	var (
+		blockType       *graphql.Object
+		transactionType *graphql.Object
+		addressType     *graphql.Object
+		tokenType       *graphql.Object
+		holderType      *graphql.Object
+		validatorType   *graphql.Object
+		delegatorType   *graphql.Object
+	)
+
+	logType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Log",
+		Fields: graphql.Fields{
+			"address":         &graphql.Field{Type: graphql.String},
+			"methodName":      &graphql.Field{Type: graphql.String},
+			"argumentsName":   &graphql.Field{Type: graphql.String},
+			"topics":          &graphql.Field{Type: graphql.NewList(graphql.String)},
+			"data":            &graphql.Field{Type: graphql.String},
+			"blockHeight":     &graphql.Field{Type: graphql.Int},
+			"blockHash":       &graphql.Field{Type: graphql.String},
+			"transactionHash": &graphql.Field{Type: graphql.String},
+			"logIndex":        &graphql.Field{Type: graphql.Int},
+			"time":            &graphql.Field{Type: graphql.DateTime},
+		},
+	})
+
+	blockType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Block",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"hash":              &graphql.Field{Type: graphql.String},
+				"height":            &graphql.Field{Type: graphql.Int},
+				"commitHash":        &graphql.Field{Type: graphql.String},
+				"gasLimit":          &graphql.Field{Type: graphql.Float},
+				"gasUsed":           &graphql.Field{Type: graphql.Float},
+				"rewards":           &graphql.Field{Type: graphql.String},
+				"numTxs":            &graphql.Field{Type: graphql.Int},
+				"time":              &graphql.Field{Type: graphql.DateTime},
+				"proposerAddress":   &graphql.Field{Type: graphql.String},
+				"lastBlock":         &graphql.Field{Type: graphql.String},
+				"validatorHash":     &graphql.Field{Type: graphql.String},
+				"nextValidatorHash": &graphql.Field{Type: graphql.String},
+				"proposer": &graphql.Field{
+					Type: validatorType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						block, _ := p.Source.(*types.Block)
+						return loadersFromContext(p.Context).validators.load(p.Context, block.ProposerAddress), nil
+					},
+				},
+				"transactions": &graphql.Field{
+					Type: graphql.NewList(transactionType),
+					Args: graphQLPagingArgs(nil),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						block, _ := p.Source.(*types.Block)
+						txs, _, err := s.dbClient.TxsByBlockHeight(p.Context, block.Height, graphQLPagination(p))
+						return txs, err
+					},
+				},
+			}
+		}),
+	})
+
+	transactionType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Transaction",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"hash":             &graphql.Field{Type: graphql.String},
+				"blockHash":        &graphql.Field{Type: graphql.String},
+				"blockNumber":      &graphql.Field{Type: graphql.Int},
+				"from":             &graphql.Field{Type: graphql.String},
+				"to":               &graphql.Field{Type: graphql.String},
+				"status":           &graphql.Field{Type: graphql.Int},
+				"type":             &graphql.Field{Type: graphql.String},
+				"contractAddress":  &graphql.Field{Type: graphql.String},
+				"value":            &graphql.Field{Type: graphql.String},
+				"gasPrice":         &graphql.Field{Type: graphql.Float},
+				"gas":              &graphql.Field{Type: graphql.Float},
+				"gasUsed":          &graphql.Field{Type: graphql.Float},
+				"txFee":            &graphql.Field{Type: graphql.String},
+				"nonce":            &graphql.Field{Type: graphql.Float},
+				"time":             &graphql.Field{Type: graphql.DateTime},
+				"input":            &graphql.Field{Type: graphql.String},
+				"transactionIndex": &graphql.Field{Type: graphql.Int},
+				"logs":             &graphql.Field{Type: graphql.NewList(logType)},
+				"fromAddress": &graphql.Field{
+					Type: addressType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						tx, _ := p.Source.(*types.Transaction)
+						return loadersFromContext(p.Context).addresses.load(p.Context, tx.From), nil
+					},
+				},
+				"toAddress": &graphql.Field{
+					Type: addressType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						tx, _ := p.Source.(*types.Transaction)
+						if tx.To == "" {
+							return nil, nil
+						}
+						return loadersFromContext(p.Context).addresses.load(p.Context, tx.To), nil
+					},
+				},
+				"block": &graphql.Field{
+					Type: blockType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						tx, _ := p.Source.(*types.Transaction)
+						return loadersFromContext(p.Context).blocks.load(p.Context, strconv.FormatUint(tx.BlockNumber, 10)), nil
+					},
+				},
+			}
+		}),
+	})
+
+	addressType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Address",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"address":      &graphql.Field{Type: graphql.String},
+				"name":         &graphql.Field{Type: graphql.String},
+				"balance":      &graphql.Field{Type: graphql.String},
+				"isContract":   &graphql.Field{Type: graphql.Boolean},
+				"type":         &graphql.Field{Type: graphql.String},
+				"ownerAddress": &graphql.Field{Type: graphql.String},
+				"logo":         &graphql.Field{Type: graphql.String},
+				"txCount":      &graphql.Field{Type: graphql.Int},
+				"token": &graphql.Field{
+					Type: tokenType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						addr, _ := p.Source.(*types.Address)
+						if addr == nil || addr.TokenSymbol == "" {
+							return nil, nil
+						}
+						return addr, nil
+					},
+				},
+				"transactions": &graphql.Field{
+					Type: graphql.NewList(transactionType),
+					Args: graphQLPagingArgs(graphql.FieldConfigArgument{
+						"type": &graphql.ArgumentConfig{Type: graphql.String},
+					}),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						addr, _ := p.Source.(*types.Address)
+						txType, _ := p.Args["type"].(string)
+						txs, _, err := s.dbClient.TxsByAddress(p.Context, addr.Address, txType, graphQLPagination(p))
+						return txs, err
+					},
+				},
+			}
+		}),
+	})
+
+	// token is read from the address document of its contract
+	tokenType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Token",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"address":     &graphql.Field{Type: graphql.String},
+				"name":        &graphql.Field{Type: graphql.String, Resolve: tokenField(func(t *types.Address) interface{} { return t.TokenName })},
+				"symbol":      &graphql.Field{Type: graphql.String, Resolve: tokenField(func(t *types.Address) interface{} { return t.TokenSymbol })},
+				"decimals":    &graphql.Field{Type: graphql.Int},
+				"totalSupply": &graphql.Field{Type: graphql.String},
+				"type":        &graphql.Field{Type: graphql.String},
+				"logo":        &graphql.Field{Type: graphql.String},
+				"holderCount": &graphql.Field{Type: graphql.Int},
+				"holders": &graphql.Field{
+					Type: graphql.NewList(holderType),
+					Args: graphQLPagingArgs(nil),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						token, _ := p.Source.(*types.Address)
+						holders, _, err := s.dbClient.GetListHolders(p.Context, &types.HolderFilter{
+							Pagination:      graphQLPagination(p),
+							ContractAddress: token.Address,
+						})
+						return holders, err
+					},
+				},
+			}
+		}),
+	})
+
+	holderType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "TokenHolder",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"contractAddress": &graphql.Field{Type: graphql.String},
+				"holderAddress":   &graphql.Field{Type: graphql.String},
+				"balance":         &graphql.Field{Type: graphql.String},
+				"token": &graphql.Field{
+					Type: tokenType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						holder, _ := p.Source.(*types.TokenHolder)
+						return loadersFromContext(p.Context).addresses.load(p.Context, holder.ContractAddress), nil
+					},
+				},
+				"holder": &graphql.Field{
+					Type: addressType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						holder, _ := p.Source.(*types.TokenHolder)
+						return loadersFromContext(p.Context).addresses.load(p.Context, holder.HolderAddress), nil
+					},
+				},
+			}
+		}),
+	})
+
+	contractType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Contract",
+		Fields: graphql.Fields{
+			"name":         &graphql.Field{Type: graphql.String},
+			"address":      &graphql.Field{Type: graphql.String},
+			"bytecode":     &graphql.Field{Type: graphql.String},
+			"abi":          &graphql.Field{Type: graphql.String},
+			"ownerAddress": &graphql.Field{Type: graphql.String},
+			"txHash":       &graphql.Field{Type: graphql.String},
+			"createdAt":    &graphql.Field{Type: graphql.Int},
+			"type":         &graphql.Field{Type: graphql.String},
+			"info":         &graphql.Field{Type: graphql.String},
+			"logo":         &graphql.Field{Type: graphql.String},
+			"isVerified":   &graphql.Field{Type: graphql.Boolean},
+		},
+	})
+
+	validatorType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Validator",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"address":               &graphql.Field{Type: graphql.String},
+				"smcAddress":            &graphql.Field{Type: graphql.String},
+				"name":                  &graphql.Field{Type: graphql.String},
+				"status":                &graphql.Field{Type: graphql.Int},
+				"role":                  &graphql.Field{Type: graphql.Int},
+				"jailed":                &graphql.Field{Type: graphql.Boolean},
+				"votingPowerPercentage": &graphql.Field{Type: graphql.String},
+				"stakedAmount":          &graphql.Field{Type: graphql.String},
+				"accumulatedCommission": &graphql.Field{Type: graphql.String},
+				"commissionRate":        &graphql.Field{Type: graphql.String},
+				"maxRate":               &graphql.Field{Type: graphql.String},
+				"maxChangeRate":         &graphql.Field{Type: graphql.String},
+				"totalDelegators":       &graphql.Field{Type: graphql.Int},
+				"delegators": &graphql.Field{
+					Type: graphql.NewList(delegatorType),
+					Args: graphQLPagingArgs(nil),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						validator, _ := p.Source.(*types.Validator)
+						pagination := graphQLPagination(p)
+						return s.dbClient.Delegators(p.Context, db.DelegatorFilter{
+							ValidatorSMCAddress: validator.SmcAddress,
+							Skip:                int64(pagination.Skip),
+							Limit:               int64(pagination.Limit),
+						})
+					},
+				},
+			}
+		}),
+	})
+
+	delegatorType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Delegator",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"address":             &graphql.Field{Type: graphql.String},
+				"validatorSMCAddress": &graphql.Field{Type: graphql.String},
+				"stakedAmount":        &graphql.Field{Type: graphql.String},
+				"reward":              &graphql.Field{Type: graphql.String},
+				"validator": &graphql.Field{
+					Type: validatorType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						delegator, _ := p.Source.(*types.Delegator)
+						return loadersFromContext(p.Context).validators.load(p.Context, delegator.ValidatorSMCAddress), nil
+					},
+				},
+			}
+		}),
+	})
+
+	// metadata fields are embedded in ProposalDetail, which default resolver doesn't walk into
+	proposalType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Proposal",
+		Fields: graphql.Fields{
+			"id":                  &graphql.Field{Type: graphql.Int, Resolve: proposalField(func(d *types.ProposalDetail) interface{} { return d.ID })},
+			"proposer":            &graphql.Field{Type: graphql.String, Resolve: proposalField(func(d *types.ProposalDetail) interface{} { return d.Proposer })},
+			"startTime":           &graphql.Field{Type: graphql.Int, Resolve: proposalField(func(d *types.ProposalDetail) interface{} { return d.StartTime })},
+			"endTime":             &graphql.Field{Type: graphql.Int, Resolve: proposalField(func(d *types.ProposalDetail) interface{} { return d.EndTime })},
+			"deposit":             &graphql.Field{Type: graphql.String, Resolve: proposalField(func(d *types.ProposalDetail) interface{} { return d.Deposit })},
+			"status":              &graphql.Field{Type: graphql.Int, Resolve: proposalField(func(d *types.ProposalDetail) interface{} { return d.Status })},
+			"voteYes":             &graphql.Field{Type: graphql.Float},
+			"voteNo":              &graphql.Field{Type: graphql.Float},
+			"voteAbstain":         &graphql.Field{Type: graphql.Float},
+			"numberOfVoteYes":     &graphql.Field{Type: graphql.Int},
+			"numberOfVoteNo":      &graphql.Field{Type: graphql.Int},
+			"numberOfVoteAbstain": &graphql.Field{Type: graphql.Int},
+			"updateTime":          &graphql.Field{Type: graphql.Int},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"block": &graphql.Field{
+				Type: blockType,
+				Args: graphql.FieldConfigArgument{
+					"height": &graphql.ArgumentConfig{Type: graphql.Int},
+					"hash":   &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if hash, ok := p.Args["hash"].(string); ok && hash != "" {
+						if block, err := s.cacheClient.BlockByHash(p.Context, hash); err == nil {
+							return block, nil
+						}
+						return s.dbClient.BlockByHash(p.Context, hash)
+					}
+					height, _ := p.Args["height"].(int)
+					if block, err := s.cacheClient.BlockByHeight(p.Context, uint64(height)); err == nil {
+						return block, nil
+					}
+					return s.dbClient.BlockByHeight(p.Context, uint64(height))
+				},
+			},
+			"blocks": &graphql.Field{
+				Type: graphql.NewList(blockType),
+				Args: graphQLPagingArgs(nil),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return s.dbClient.Blocks(p.Context, graphQLPagination(p))
+				},
+			},
+			"transaction": &graphql.Field{
+				Type: transactionType,
+				Args: graphql.FieldConfigArgument{
+					"hash": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					hash, _ := p.Args["hash"].(string)
+					return s.dbClient.TxByHash(p.Context, hash)
+				},
+			},
+			"transactions": &graphql.Field{
+				Type: graphql.NewList(transactionType),
+				Args: graphQLPagingArgs(graphql.FieldConfigArgument{
+					"address": &graphql.ArgumentConfig{Type: graphql.String},
+					"type":    &graphql.ArgumentConfig{Type: graphql.String},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					txType, _ := p.Args["type"].(string)
+					if _, ok := p.Args["address"].(string); ok {
+						txs, _, err := s.dbClient.TxsByAddress(p.Context, graphQLAddressArg(p, "address"), txType, graphQLPagination(p))
+						return txs, err
+					}
+					if txType != "" {
+						txs, _, err := s.dbClient.TxsByFilter(p.Context, &types.TxsFilter{Pagination: graphQLPagination(p), Type: txType})
+						return txs, err
+					}
+					return s.dbClient.LatestTxs(p.Context, graphQLPagination(p))
+				},
+			},
+			"logs": &graphql.Field{
+				Type: graphql.NewList(logType),
+				Args: graphQLPagingArgs(graphql.FieldConfigArgument{
+					"address":    &graphql.ArgumentConfig{Type: graphql.String},
+					"methodName": &graphql.ArgumentConfig{Type: graphql.String},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					filter := &types.EventsFilter{Pagination: graphQLPagination(p)}
+					if _, ok := p.Args["address"].(string); ok {
+						filter.ContractAddress = graphQLAddressArg(p, "address")
+					}
+					filter.MethodName, _ = p.Args["methodName"].(string)
+					logs, _, err := s.dbClient.GetListEvents(p.Context, filter)
+					return logs, err
+				},
+			},
+			"address": &graphql.Field{
+				Type: addressType,
+				Args: graphql.FieldConfigArgument{
+					"address": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFromContext(p.Context).addresses.load(p.Context, graphQLAddressArg(p, "address")), nil
+				},
+			},
+			"token": &graphql.Field{
+				Type: tokenType,
+				Args: graphql.FieldConfigArgument{
+					"address": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					addr, err := s.dbClient.AddressByHash(p.Context, graphQLAddressArg(p, "address"))
+					if err != nil || addr.TokenSymbol == "" {
+						return nil, err
+					}
+					return addr, nil
+				},
+			},
+			"tokenHolders": &graphql.Field{
+				Type: graphql.NewList(holderType),
+				Args: graphQLPagingArgs(graphql.FieldConfigArgument{
+					"token":  &graphql.ArgumentConfig{Type: graphql.String},
+					"holder": &graphql.ArgumentConfig{Type: graphql.String},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					filter := &types.HolderFilter{Pagination: graphQLPagination(p)}
+					if _, ok := p.Args["token"].(string); ok {
+						filter.ContractAddress = graphQLAddressArg(p, "token")
+					}
+					if _, ok := p.Args["holder"].(string); ok {
+						filter.HolderAddress = graphQLAddressArg(p, "holder")
+					}
+					holders, _, err := s.dbClient.GetListHolders(p.Context, filter)
+					return holders, err
+				},
+			},
+			"contract": &graphql.Field{
+				Type: contractType,
+				Args: graphql.FieldConfigArgument{
+					"address": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					contract, _, err := s.dbClient.Contract(p.Context, graphQLAddressArg(p, "address"))
+					return contract, err
+				},
+			},
+			"validators": &graphql.Field{
+				Type: graphql.NewList(validatorType),
+				Args: graphql.FieldConfigArgument{
+					"role": &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					role, _ := p.Args["role"].(int)
+					return s.dbClient.Validators(p.Context, db.ValidatorsFilter{Role: role})
+				},
+			},
+			"validator": &graphql.Field{
+				Type: validatorType,
+				Args: graphql.FieldConfigArgument{
+					"address": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFromContext(p.Context).validators.load(p.Context, graphQLAddressArg(p, "address")), nil
+				},
+			},
+			"delegators": &graphql.Field{
+				Type: graphql.NewList(delegatorType),
+				Args: graphQLPagingArgs(graphql.FieldConfigArgument{
+					"validator": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					pagination := graphQLPagination(p)
+					return s.dbClient.Delegators(p.Context, db.DelegatorFilter{
+						ValidatorSMCAddress: graphQLAddressArg(p, "validator"),
+						Skip:                int64(pagination.Skip),
+						Limit:               int64(pagination.Limit),
+					})
+				},
+			},
+			"proposals": &graphql.Field{
+				Type: graphql.NewList(proposalType),
+				Args: graphQLPagingArgs(nil),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					proposals, _, err := s.dbClient.GetListProposals(p.Context, graphQLPagination(p))
+					return proposals, err
+				},
+			},
+			"proposal": &graphql.Field{
+				Type: proposalType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, _ := p.Args["id"].(int)
+					return s.dbClient.ProposalInfo(p.Context, uint64(id))
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+func tokenField(fn func(t *types.Address) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		token, ok := p.Source.(*types.Address)
+		if !ok || token == nil {
+			return nil, nil
+		}
+		return fn(token), nil
+	}
+}
+
+func proposalField(fn func(d *types.ProposalDetail) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		detail, ok := p.Source.(*types.ProposalDetail)
+		if !ok || detail == nil {
+			return nil, nil
+		}
+		return fn(detail), nil
+	}
+}
+
+// listFieldNames return names of fields which resolve to a list, so complexity of their
+// children is multiplied by default limit when `limit` argument is missing
+func listFieldNames(schema graphql.Schema) map[string]bool {
+	names := make(map[string]bool)
+	for _, t := range schema.TypeMap() {
+		obj, ok := t.(*graphql.Object)
+		if !ok || strings.HasPrefix(obj.Name(), "__") {
+			continue
+		}
+		for name, field := range obj.Fields() {
+			fieldType := field.Type
+			if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+				fieldType = nonNull.OfType
+			}
+			if _, ok := fieldType.(*graphql.List); ok {
+				names[name] = true
+			}
+		}
+	}
+	return names
+}
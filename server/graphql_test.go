@@ -0,0 +1,34 @@
+// Package server
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_graphQLLimits(t *testing.T) {
+	g, err := newGraphQLServer(&Server{}, 4, 100)
+	assert.Nil(t, err)
+
+	// 1 + 5 * (1 + 1)
+	assert.Nil(t, g.checkLimits(`{ blocks(limit: 5) { height hash } }`, nil))
+	// list without limit use default limit, 1 + 10 * (1 + 10 * 1)
+	assert.NotNil(t, g.checkLimits(`{ blocks { transactions { hash } } }`, nil))
+	// limit from variables
+	assert.Nil(t, g.checkLimits(`query($n: Int) { blocks(limit: $n) { transactions(limit: 2) { hash } } }`, map[string]interface{}{"n": float64(3)}))
+	assert.NotNil(t, g.checkLimits(`query($n: Int) { blocks(limit: $n) { transactions(limit: 2) { hash } } }`, map[string]interface{}{"n": float64(50)}))
+	// limit from variable default, unless given
+	assert.NotNil(t, g.checkLimits(`query($n: Int = 100) { blocks(limit: $n) { hash } }`, nil))
+	assert.Nil(t, g.checkLimits(`query($n: Int = 100) { blocks(limit: $n) { hash } }`, map[string]interface{}{"n": float64(5)}))
+
+	// depth counted through fragments
+	query := `
+		{ transaction(hash: "0x") { ...tx } }
+		fragment tx on Transaction { block { proposer { delegators(limit: 1) { address } } } }`
+	assert.NotNil(t, g.checkLimits(query, nil))
+	// fragment which spread itself doesn't loop forever
+	assert.Nil(t, g.checkLimits(`{ address(address: "0x") { ...a } } fragment a on Address { name ...a }`, nil))
+	// introspection fields are free
+	assert.Nil(t, g.checkLimits(`{ __schema { types { name fields { name } } } }`, nil))
+}
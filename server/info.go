@@ -5,13 +5,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
-	"encoding/json"
-	"errors"
 	"fmt"
-	"io/ioutil"
 	"math/big"
-	"net"
-	"net/http"
 	"strings"
 	"time"
 
@@ -24,6 +19,7 @@ import (
 	"github.com/kardiachain/kardia-explorer-backend/db"
 	"github.com/kardiachain/kardia-explorer-backend/kardia"
 	"github.com/kardiachain/kardia-explorer-backend/metrics"
+	"github.com/kardiachain/kardia-explorer-backend/price"
 	"github.com/kardiachain/kardia-explorer-backend/types"
 	"github.com/kardiachain/kardia-explorer-backend/utils"
 )
@@ -54,6 +50,8 @@ type InfoServer interface {
 	PopUnverifiedBlockHeight(ctx context.Context) (uint64, error)
 
 	VerifyBlock(ctx context.Context, blockHeight uint64, networkBlock *types.Block) (bool, error)
+
+	BackfillTxs(ctx context.Context) error
 }
 
 // infoServer handle how data was retrieved, stored without interact with other network excluded dbClient
@@ -62,110 +60,25 @@ type infoServer struct {
 	cacheClient cache.Client
 	kaiClient   kardia.ClientInterface
 
+	priceProvider price.Provider
+
 	metrics *metrics.Provider
 
-	HttpRequestSecret string
-	verifyBlockParam  *types.VerifyBlockParam
+	verifyBlockParam *types.VerifyBlockParam
+	productionWindow uint64
 
 	logger *zap.Logger
 }
 
+// TokenInfo refresh market info from price providers, price history is only recorded by watcher
 func (s *infoServer) TokenInfo(ctx context.Context) (*types.TokenInfo, error) {
-	type CMQuote struct {
-		Price            float64 `json:"price"`
-		Volume24h        float64 `json:"volume_24h"`
-		PercentChange1h  float64 `json:"percent_change_1h"`
-		PercentChange24h float64 `json:"percent_change_24h"`
-		PercentChange7d  float64 `json:"percent_change_7d"`
-		MarketCap        float64 `json:"market_cap"`
-		LastUpdated      string  `json:"last_updated"`
-	}
-	type CMTokenInfo struct {
-		ID                int                `json:"id"`
-		Name              string             `json:"name"`
-		Symbol            string             `json:"symbol"`
-		Slug              string             `json:"slug"`
-		NumMarketPairs    int                `json:"num_market_pairs"`
-		DateAdded         string             `json:"date_added"`
-		Tags              []string           `json:"tags"`
-		MaxSupply         int64              `json:"max_supply"`
-		CirculatingSupply int64              `json:"circulating_supply"`
-		TotalSupply       int64              `json:"total_supply"`
-		IsActive          int                `json:"is_active"`
-		Platform          interface{}        `json:"platform"`
-		CmcRank           int                `json:"cmc_rank"`
-		IsFiat            int                `json:"is_fiat"`
-		LastUpdated       string             `json:"last_updated"`
-		Quote             map[string]CMQuote `json:"quote"`
-	}
-
-	type CMResponseStatus struct {
-		Timestamp    string `json:"timestamp"`
-		ErrorCode    int    `json:"error_code"`
-		ErrorMessage string `json:"error_message"`
-		Elapsed      int    `json:"elapsed"`
-		CreditCount  int    `json:"credit_count"`
-		Notice       string `json:"notice"`
-	}
-
-	type CMResponse struct {
-		Status CMResponseStatus       `json:"status"`
-		Data   map[string]CMTokenInfo `json:"data"`
-	}
-
-	coinMarketUrl := "https://pro-api.coinmarketcap.com/v1/cryptocurrency/quotes/latest?id=5453"
-	var netTransport = &http.Transport{
-		Dial: (&net.Dialer{
-			Timeout: 5 * time.Second,
-		}).Dial,
-		TLSHandshakeTimeout: 5 * time.Second,
-	}
-	var netClient = &http.Client{
-		Timeout:   time.Second * 10,
-		Transport: netTransport,
-	}
-
-	req, _ := http.NewRequest(http.MethodGet, coinMarketUrl, nil)
-	req.Header.Set("X-CMC_PRO_API_KEY", "a9aaf65c-1d6f-4daf-8e2e-df30bd405b66")
-
-	response, _ := netClient.Do(req)
-	defer response.Body.Close()
-	body, err := ioutil.ReadAll(response.Body)
+	tokenInfo, err := s.priceProvider.TokenInfo(ctx)
 	if err != nil {
 		return nil, err
 	}
-
-	var cmResponse CMResponse
-
-	if err := json.Unmarshal(body, &cmResponse); err != nil {
-		return nil, err
-	}
-
-	if cmResponse.Status.ErrorCode != 0 {
-		return nil, errors.New("api failed")
-	}
-	cmData := cmResponse.Data["5453"]
-	cmQuote := cmData.Quote["USD"]
-
-	// Cast to internal
-	tokenInfo := &types.TokenInfo{
-		Name:                     cmData.Name,
-		Symbol:                   cmData.Symbol,
-		Decimal:                  18,
-		TotalSupply:              cmData.TotalSupply,
-		ERC20CirculatingSupply:   cmData.CirculatingSupply,
-		MainnetCirculatingSupply: 0,
-		Price:                    cmQuote.Price,
-		Volume24h:                cmQuote.Volume24h,
-		Change1h:                 cmQuote.PercentChange1h,
-		Change24h:                cmQuote.PercentChange24h,
-		Change7d:                 cmQuote.PercentChange7d,
-		MarketCap:                cmQuote.MarketCap,
-	}
 	if err := s.cacheClient.UpdateTokenInfo(ctx, tokenInfo); err != nil {
 		return nil, err
 	}
-
 	return tokenInfo, nil
 }
 
@@ -309,12 +222,14 @@ func (s *infoServer) ImportBlock(ctx context.Context, block *types.Block, writeT
 	// merge receipts into corresponding transactions
 	// because getBlockByHash/Height API returns 2 array contains txs and receipts separately
 	block.Txs = s.mergeAdditionalInfoToTxs(ctx, block.Txs, block.Receipts)
+	s.classifyTxs(ctx, block.Txs)
 
 	if err := s.filterProposalEvent(ctx, block.Txs); err != nil {
 		s.logger.Warn("Filter proposal event failed", zap.Error(err))
 	}
 
 	// Start import block
+	block.StatsPending = []string{types.BlockStatsProduction, types.BlockStatsNetwork}
 	startTime := time.Now()
 	if err := s.dbClient.InsertBlock(ctx, block); err != nil {
 		return err
@@ -361,6 +276,41 @@ func (s *infoServer) ImportBlock(ctx context.Context, block *types.Block, writeT
 	if _, err := s.cacheClient.UpdateTotalTxs(ctx, block.NumTxs); err != nil {
 		return err
 	}
+
+	if err := s.dbClient.IncProductionStats(ctx, block, s.productionWindow); err != nil {
+		s.logger.Warn("Cannot update block production stats", zap.Error(err))
+	} else if err := s.dbClient.MarkBlockCounted(ctx, block.Height, types.BlockStatsProduction); err != nil {
+		s.logger.Warn("Cannot mark block counted in production stats", zap.Error(err))
+	}
+	if err := s.incNetworkStats(ctx, block); err != nil {
+		s.logger.Warn("Cannot update network stats", zap.Uint64("height", block.Height), zap.Error(err))
+	}
+	if err := s.recordValidatorSetChange(ctx, block); err != nil {
+		s.logger.Warn("Cannot record validator set change", zap.Uint64("height", block.Height), zap.Error(err))
+	}
+	if err := s.enqueueWebhooks(ctx, block); err != nil {
+		s.logger.Warn("Cannot enqueue webhook deliveries", zap.Uint64("height", block.Height), zap.Error(err))
+	}
+	return nil
+}
+
+// incNetworkStats count block in network stats, unless they are being rebuilt. The block is left pending then,
+// rebuild counts it when done
+func (s *infoServer) incNetworkStats(ctx context.Context, block *types.Block) error {
+	rebuilding, err := s.dbClient.NetworkStatsRebuilding(ctx)
+	if err != nil || rebuilding {
+		return err
+	}
+	claimed, err := s.dbClient.ClaimBlockStats(ctx, block.Height, types.BlockStatsNetwork)
+	if err != nil || !claimed {
+		return err
+	}
+	if err := s.dbClient.IncNetworkStats(ctx, []*types.Block{block}); err != nil {
+		if err := s.dbClient.ReleaseBlockStats(ctx, block.Height, types.BlockStatsNetwork); err != nil {
+			s.logger.Warn("Cannot mark block pending in network stats", zap.Uint64("height", block.Height), zap.Error(err))
+		}
+		return err
+	}
 	return nil
 }
 
@@ -384,6 +334,25 @@ func (s *infoServer) DeleteBlockByHeight(ctx context.Context, height uint64) err
 
 func (s *infoServer) UpsertBlock(ctx context.Context, block *types.Block) error {
 	s.logger.Info("Upserting block:", zap.Uint64("Height", block.Height), zap.Int("Txs length", len(block.Txs)), zap.Int("Receipts length", len(block.Receipts)))
+	// remove old block from production and network stats, it will be counted again while importing
+	if oldBlock, err := s.dbClient.BlockByHeight(ctx, block.Height); err == nil && oldBlock != nil {
+		if oldBlock.IsCountedIn(types.BlockStatsProduction) {
+			if err := s.dbClient.RevertProductionStats(ctx, oldBlock, s.productionWindow); err != nil {
+				s.logger.Warn("Cannot revert block production stats", zap.Error(err))
+			}
+		}
+		if oldBlock.IsCountedIn(types.BlockStatsNetwork) {
+			if oldBlock.Txs, _, err = s.dbClient.TxsByBlockHeight(ctx, block.Height, nil); err == nil {
+				if err := s.dbClient.RevertNetworkStats(ctx, oldBlock); err != nil {
+					s.logger.Warn("Cannot revert network stats", zap.Error(err))
+				}
+			}
+		}
+	}
+	// events of old block which are not in new one are delivered again as removed
+	if err := s.revertWebhooks(ctx, block); err != nil {
+		s.logger.Warn("Cannot revert webhook deliveries", zap.Uint64("height", block.Height), zap.Error(err))
+	}
 	if err := s.dbClient.DeleteBlockByHeight(ctx, block.Height); err != nil {
 		return err
 	}
@@ -0,0 +1,117 @@
+// Package server
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/labstack/echo"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+// getTimeRange parse `from` and `to` unix timestamps, missing ones are zero
+func getTimeRange(c echo.Context) (types.TimeFilter, error) {
+	var tf types.TimeFilter
+	from, err := parseOptionalInt(c.QueryParam("from"))
+	if err != nil {
+		return tf, err
+	}
+	to, err := parseOptionalInt(c.QueryParam("to"))
+	if err != nil {
+		return tf, err
+	}
+	if from != 0 {
+		tf.FromTime = time.Unix(from, 0)
+	}
+	if to != 0 {
+		tf.ToTime = time.Unix(to, 0)
+	}
+	return tf, nil
+}
+
+// parseOptionalInt, parseOptionalUint and parseOptionalFloat return zero for missing params, and error for
+// malformed ones, so a typo isn't answered as if the filter wasn't given
+func parseOptionalInt(param string) (int64, error) {
+	if param == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(param, 10, 64)
+}
+
+func parseOptionalUint(param string) (uint64, error) {
+	if param == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(param, 10, 64)
+}
+
+func parseOptionalFloat(param string) (float64, error) {
+	if param == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(param, 64)
+}
+
+func getTxsFilter(c echo.Context, pagination *types.Pagination) (*types.TxsFilter, error) {
+	tf, err := getTimeRange(c)
+	if err != nil {
+		return nil, err
+	}
+	filter := &types.TxsFilter{
+		Pagination: pagination,
+		TimeFilter: tf,
+		Type:       c.QueryParam("type"),
+		Method:     c.QueryParam("method"),
+	}
+	if to := c.QueryParam("toAddress"); to != "" {
+		filter.To = common.HexToAddress(to).String()
+	}
+	if filter.FromBlock, err = parseOptionalUint(c.QueryParam("fromBlock")); err != nil {
+		return nil, err
+	}
+	if filter.ToBlock, err = parseOptionalUint(c.QueryParam("toBlock")); err != nil {
+		return nil, err
+	}
+	if filter.MinValue, err = parseOptionalFloat(c.QueryParam("minValue")); err != nil {
+		return nil, err
+	}
+	if filter.MaxValue, err = parseOptionalFloat(c.QueryParam("maxValue")); err != nil {
+		return nil, err
+	}
+	switch status := c.QueryParam("status"); status {
+	case "":
+	case "success":
+		status := types.TxStatusSuccess
+		filter.Status = &status
+	case "failed":
+		status := types.TxStatusFailed
+		filter.Status = &status
+	default:
+		return nil, fmt.Errorf("unknown tx status %q", status)
+	}
+	return filter, nil
+}
+
+func getBlocksFilter(c echo.Context, pagination *types.Pagination) (*types.BlocksFilter, error) {
+	tf, err := getTimeRange(c)
+	if err != nil {
+		return nil, err
+	}
+	filter := &types.BlocksFilter{
+		Pagination: pagination,
+		TimeFilter: tf,
+	}
+	if proposer := c.QueryParam("proposer"); proposer != "" {
+		filter.Proposer = common.HexToAddress(proposer).String()
+	}
+	if filter.FromHeight, err = parseOptionalUint(c.QueryParam("fromHeight")); err != nil {
+		return nil, err
+	}
+	if filter.ToHeight, err = parseOptionalUint(c.QueryParam("toHeight")); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
@@ -0,0 +1,54 @@
+// Package server
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_getTxsFilter(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantErr bool
+	}{
+		{query: ""},
+		{query: "?status=failed&minValue=1.5&fromBlock=10&from=1614556800"},
+		{query: "?status=pending", wantErr: true},
+		{query: "?minValue=abc", wantErr: true},
+		{query: "?maxValue=1e", wantErr: true},
+		{query: "?fromBlock=-1", wantErr: true},
+		{query: "?toBlock=x", wantErr: true},
+		{query: "?from=yesterday", wantErr: true},
+		{query: "?to=1.5", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/txs"+tt.query, nil), httptest.NewRecorder())
+			filter, err := getTxsFilter(c, &types.Pagination{})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, filter)
+		})
+	}
+}
+
+func Test_getBlocksFilter(t *testing.T) {
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/blocks?fromHeight=5&toHeight=10", nil), httptest.NewRecorder())
+	filter, err := getBlocksFilter(c, &types.Pagination{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), filter.FromHeight)
+	assert.Equal(t, uint64(10), filter.ToHeight)
+
+	c = echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/blocks?toHeight=latest", nil), httptest.NewRecorder())
+	_, err = getBlocksFilter(c, &types.Pagination{})
+	assert.Error(t, err)
+}
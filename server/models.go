@@ -13,6 +13,8 @@ type PagingResponse struct {
 	Limit int         `json:"limit"`
 	Total uint64      `json:"total"`
 	Data  interface{} `json:"data"`
+	Next  string      `json:"next,omitempty"`
+	Prev  string      `json:"prev,omitempty"`
 }
 
 type Blocks []SimpleBlock
@@ -50,6 +52,7 @@ type SimpleTransaction struct {
 	Value              string              `json:"value"`
 	TxFee              string              `json:"txFee"`
 	Status             uint                `json:"status"`
+	Type               string              `json:"type,omitempty"`
 	DecodedInputData   *types.FunctionCall `json:"decodedInputData,omitempty"`
 	InputData          string              `json:"input"`
 }
@@ -66,8 +69,11 @@ type Transaction struct {
 	IsInValidatorsList bool                   `json:"isInValidatorsList"`
 	Role               int                    `json:"role"`
 	Status             uint                   `json:"status"`
+	Type               string                 `json:"type,omitempty"`
 	ContractAddress    string                 `json:"contractAddress"`
 	Value              string                 `json:"value"`
+	PriceUSD           string                 `json:"priceUSD,omitempty"` // KAI price at time of tx
+	ValueUSD           string                 `json:"valueUSD,omitempty"`
 	GasPrice           uint64                 `json:"gasPrice"`
 	GasLimit           uint64                 `json:"gas"`
 	GasUsed            uint64                 `json:"gasUsed"`
@@ -98,6 +104,14 @@ type SimpleAddress struct {
 	IsInValidatorsList bool   `json:"isInValidatorsList"`
 	Role               int    `json:"role"`
 	Rank               uint64 `json:"rank"`
+
+	// rich list
+	Tag              string   `json:"tag,omitempty"`
+	SupplyPercentage float64  `json:"supplyPercentage,omitempty"`
+	RankChange24h    *int64   `json:"rankChange24h,omitempty"`
+	RankChange7d     *int64   `json:"rankChange7d,omitempty"`
+	BalanceChange24h *float64 `json:"balanceChange24h,omitempty"`
+	BalanceChange7d  *float64 `json:"balanceChange7d,omitempty"`
 }
 
 type valInfoResponse struct {
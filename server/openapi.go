@@ -0,0 +1,69 @@
+// Package server
+package server
+
+import (
+	"github.com/kardiachain/kardia-explorer-backend/metrics"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+// OpenAPIModels return zero values of response and request models, keyed by schema names used in api route table
+func (s *Server) OpenAPIModels() map[string]interface{} {
+	return map[string]interface{}{
+		"PagingResponse":      PagingResponse{},
+		"PingStat":            pingStat{},
+		"DashboardStats":      statsResponse{},
+		"TotalHolders":        totalHoldersResponse{},
+		"SimpleBlock":         SimpleBlock{},
+		"Block":               Block{},
+		"BlockHeights":        []uint64{},
+		"SimpleTransaction":   SimpleTransaction{},
+		"Transaction":         Transaction{},
+		"NodeInfo":            NodeInfo{},
+		"SimpleAddress":       SimpleAddress{},
+		"KRCTokenInfo":        KRCTokenInfo{},
+		"SimpleKRCTokenInfo":  SimpleKRCTokenInfo{},
+		"InternalTransaction": InternalTransaction{},
+		"WebhookWatchRequest": webhookWatchRequest{},
+		"RateLimitCounters":   map[string]metrics.RateLimitCounter{},
+		"APIKeyRequest":       apiKeyRequest{},
+		"SearchResult":        SearchResult{},
+		"OwnAPIKey":           ownAPIKeyResponse{},
+
+		"ValidatorHistoryResponse": historyResponse{},
+		"MobileValidators":         mobileResponse{},
+
+		"TokenInfo":                types.TokenInfo{},
+		"SupplyInfo":               types.SupplyInfo{},
+		"NetworkNode":              types.NodeInfo{},
+		"UpdateAddress":            types.UpdateAddress{},
+		"Address":                  types.Address{},
+		"Contract":                 types.Contract{},
+		"ContractABI":              types.ContractABI{},
+		"TokenHolder":              types.TokenHolder{},
+		"ProposalDetail":           types.ProposalDetail{},
+		"ProposalParams":           map[string]interface{}{},
+		"StakingStats":             types.StakingStats{},
+		"Validator":                types.Validator{},
+		"ValidatorsByDelegator":    types.ValidatorsByDelegator{},
+		"SlashEvent":               types.SlashEvent{},
+		"ProductionStats":          types.ProductionStats{},
+		"ProposerPerformance":      types.ProposerPerformance{},
+		"NetworkStats":             types.NetworkStats{},
+		"Chart":                    types.Chart{},
+		"ChartPoint":               types.ChartPoint{},
+		"PricePoint":               types.PricePoint{},
+		"ValidatorSetChange":       types.ValidatorSetChange{},
+		"StakingSimulationRequest": types.StakingSimulationRequest{},
+		"StakingSimulation":        types.StakingSimulation{},
+		"RewardHistory":            types.RewardHistory{},
+		"UnbondingSchedule":        types.UnbondingSchedule{},
+		"WebhookWatch":             types.WebhookWatch{},
+		"WebhookDelivery":          types.WebhookDelivery{},
+		"APIKey":                   types.APIKey{},
+		"APIKeyTier":               types.APIKeyTier{},
+		"APIKeyUsage":              types.APIKeyUsage{},
+		"AutocompleteEntry":        types.AutocompleteEntry{},
+		"AuditLog":                 types.AuditLog{},
+		"Operator":                 types.Operator{},
+	}
+}
@@ -0,0 +1,549 @@
+// Package server
+package server
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"runtime/debug"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/api"
+	"github.com/kardiachain/kardia-explorer-backend/cache"
+	"github.com/kardiachain/kardia-explorer-backend/db"
+	"github.com/kardiachain/kardia-explorer-backend/kardia"
+	"github.com/kardiachain/kardia-explorer-backend/metrics"
+	"github.com/kardiachain/kardia-explorer-backend/price"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const (
+	openAPIAddress    = "0x1111111111111111111111111111111111111111"
+	openAPISMCAddress = "0x3333333333333333333333333333333333333333"
+)
+
+// openAPIFakeDB only implements methods used by handlers in Test_openAPIResponses, others panic
+type openAPIFakeDB struct {
+	db.Client
+}
+
+func (openAPIFakeDB) SlashEvents(ctx context.Context, filter *types.SlashEventsFilter) ([]*types.SlashEvent, uint64, error) {
+	return []*types.SlashEvent{{
+		ValidatorAddress: openAPIAddress,
+		Height:           10,
+		Delegators:       []*types.SlashedDelegator{{Address: "0x2222222222222222222222222222222222222222", AmountLost: "1"}},
+	}}, 1, nil
+}
+
+func (openAPIFakeDB) ValidatorSetChanges(ctx context.Context, filter *types.ValidatorSetChangesFilter) ([]*types.ValidatorSetChange, uint64, error) {
+	return []*types.ValidatorSetChange{{
+		Height:     20,
+		Validators: []*types.ValidatorSetMember{{Address: openAPIAddress, VotingPower: "10"}},
+		Joined:     []*types.ValidatorSetMember{{Address: openAPIAddress, VotingPower: "10"}},
+		Time:       time.Unix(1600000000, 0),
+	}}, 1, nil
+}
+
+func (openAPIFakeDB) Validators(ctx context.Context, filter db.ValidatorsFilter) ([]*types.Validator, error) {
+	return []*types.Validator{{Address: openAPIAddress, SmcAddress: openAPISMCAddress, Name: "validator", StakedAmount: "10", Role: 2}}, nil
+}
+
+func (openAPIFakeDB) CommissionIncreases(ctx context.Context, since time.Time) ([]*types.ValidatorHistory, error) {
+	return []*types.ValidatorHistory{{ValidatorAddress: openAPIAddress}}, nil
+}
+
+func (openAPIFakeDB) WebhookWatches(ctx context.Context, owner string) ([]*types.WebhookWatch, error) {
+	return []*types.WebhookWatch{{Type: types.WebhookWatchIncoming, Address: openAPIAddress, URL: "https://example.com/hook", Secret: "secret"}}, nil
+}
+
+func (openAPIFakeDB) Validator(ctx context.Context, validatorAddress string) (*types.Validator, error) {
+	return &types.Validator{Address: openAPIAddress, SmcAddress: openAPISMCAddress, Name: "validator", StakedAmount: "10", Role: 2}, nil
+}
+
+func (openAPIFakeDB) GetListHolders(ctx context.Context, filter *types.HolderFilter) ([]*types.TokenHolder, uint64, error) {
+	return []*types.TokenHolder{{}}, 1, nil
+}
+
+func (openAPIFakeDB) SMCABIByType(ctx context.Context, smcType string) (string, error) {
+	return "[]", nil
+}
+
+func (openAPIFakeDB) Contract(ctx context.Context, contractAddr string) (*types.Contract, *types.Address, error) {
+	return &types.Contract{Address: openAPIAddress}, &types.Address{Address: openAPIAddress}, nil
+}
+
+func (openAPIFakeDB) Contracts(ctx context.Context, filter *types.ContractsFilter) ([]*types.Contract, uint64, error) {
+	return []*types.Contract{{Address: openAPIAddress}}, 1, nil
+}
+
+func (openAPIFakeDB) UnbondingEntries(ctx context.Context, delegatorAddress string) ([]*types.UnbondingEntry, error) {
+	return []*types.UnbondingEntry{{}}, nil
+}
+
+func (openAPIFakeDB) GetListInternalTxs(ctx context.Context, filter *types.InternalTxsFilter) ([]*types.TokenTransfer, uint64, error) {
+	return []*types.TokenTransfer{{}}, 1, nil
+}
+
+func (openAPIFakeDB) AddressByHash(ctx context.Context, addressHash string) (*types.Address, error) {
+	return &types.Address{Address: openAPIAddress}, nil
+}
+
+func (openAPIFakeDB) Addresses(ctx context.Context) ([]*types.Address, error) {
+	return []*types.Address{{Address: openAPIAddress}}, nil
+}
+
+func (openAPIFakeDB) ValidatorSetAt(ctx context.Context, height uint64) (*types.ValidatorSetChange, error) {
+	return &types.ValidatorSetChange{Height: 20, Validators: []*types.ValidatorSetMember{{Address: openAPIAddress, VotingPower: "10"}}}, nil
+}
+
+func (openAPIFakeDB) GetListEvents(ctx context.Context, filter *types.EventsFilter) ([]*types.Log, uint64, error) {
+	return []*types.Log{{Address: openAPIAddress}}, 1, nil
+}
+
+func (openAPIFakeDB) ValidatorHistory(ctx context.Context, filter *types.ValidatorHistoryFilter) ([]*types.ValidatorHistory, uint64, error) {
+	return []*types.ValidatorHistory{{ValidatorAddress: openAPIAddress}}, 1, nil
+}
+
+func (openAPIFakeDB) ProductionStats(ctx context.Context, filter *types.ProductionStatsFilter) ([]*types.ProductionStats, error) {
+	return []*types.ProductionStats{{}}, nil
+}
+
+func (openAPIFakeDB) RewardSnapshots(ctx context.Context, filter *types.RewardSnapshotsFilter) ([]*types.RewardSnapshot, uint64, error) {
+	return []*types.RewardSnapshot{{}}, 1, nil
+}
+
+func (openAPIFakeDB) APIKey(ctx context.Context, id string) (*types.APIKey, error) {
+	return &types.APIKey{ID: primitive.NewObjectID(), Tier: types.APIKeyTierFree}, nil
+}
+
+func (openAPIFakeDB) APIKeys(ctx context.Context, filter *types.APIKeysFilter) ([]*types.APIKey, uint64, error) {
+	return []*types.APIKey{{ID: primitive.NewObjectID(), Tier: types.APIKeyTierFree}}, 1, nil
+}
+
+func (openAPIFakeDB) APIKeyUsage(ctx context.Context, keyID string, from, to string) ([]*types.APIKeyUsage, error) {
+	return []*types.APIKeyUsage{{}}, nil
+}
+
+func (openAPIFakeDB) RevokeAPIKey(ctx context.Context, id string) (*types.APIKey, error) {
+	return &types.APIKey{ID: primitive.NewObjectID(), Tier: types.APIKeyTierFree}, nil
+}
+
+func (openAPIFakeDB) AuditLogs(ctx context.Context, filter *types.AuditLogsFilter) ([]*types.AuditLog, uint64, error) {
+	return []*types.AuditLog{{}}, 1, nil
+}
+
+func (openAPIFakeDB) Blocks(ctx context.Context, pagination *types.Pagination) ([]*types.Block, error) {
+	return []*types.Block{{Height: 10}}, nil
+}
+
+func (openAPIFakeDB) BlocksByProposer(ctx context.Context, proposer string, pagination *types.Pagination) ([]*types.Block, uint64, error) {
+	return []*types.Block{{Height: 10}}, 1, nil
+}
+
+func (openAPIFakeDB) ExportTxs(ctx context.Context, filter *types.ExportFilter, fn func(tx *types.Transaction) error) error {
+	return fn(&types.Transaction{})
+}
+
+func (openAPIFakeDB) ExportTokenTransfers(ctx context.Context, filter *types.ExportFilter, fn func(transfer *types.TokenTransfer) error) error {
+	return fn(&types.TokenTransfer{})
+}
+
+func (openAPIFakeDB) ExportTokenHolders(ctx context.Context, filter *types.ExportFilter, fn func(holder *types.TokenHolder) error) error {
+	return fn(&types.TokenHolder{})
+}
+
+func (openAPIFakeDB) ExportEvents(ctx context.Context, filter *types.ExportFilter, fn func(event *types.Log) error) error {
+	return fn(&types.Log{})
+}
+
+func (openAPIFakeDB) ExportRewardSnapshots(ctx context.Context, filter *types.ExportFilter, fn func(snapshot *types.RewardSnapshot) error) error {
+	return fn(&types.RewardSnapshot{})
+}
+
+func (openAPIFakeDB) GetListProposals(ctx context.Context, pagination *types.Pagination) ([]*types.ProposalDetail, uint64, error) {
+	return []*types.ProposalDetail{{}}, 1, nil
+}
+
+func (openAPIFakeDB) ProposalInfo(ctx context.Context, proposalID uint64) (*types.ProposalDetail, error) {
+	return &types.ProposalDetail{}, nil
+}
+
+func (openAPIFakeDB) NetworkStats(ctx context.Context, filter *types.NetworkStatsFilter) ([]*types.NetworkStats, error) {
+	return []*types.NetworkStats{{}}, nil
+}
+
+func (openAPIFakeDB) PricePoints(ctx context.Context, symbol, currency string, from, to time.Time) ([]*types.PricePoint, error) {
+	return []*types.PricePoint{{Symbol: symbol, Currency: currency, Price: 0.05, Time: from}}, nil
+}
+
+func (openAPIFakeDB) RemoveNode(ctx context.Context, id string) error {
+	return nil
+}
+
+func (openAPIFakeDB) RemoveWebhookWatch(ctx context.Context, owner, id string) (bool, error) {
+	return true, nil
+}
+
+func (openAPIFakeDB) RetryWebhookDelivery(ctx context.Context, owner, id string) (bool, error) {
+	return true, nil
+}
+
+func (openAPIFakeDB) WebhookDeliveries(ctx context.Context, filter *types.WebhookDeliveriesFilter) ([]*types.WebhookDelivery, uint64, error) {
+	return []*types.WebhookDelivery{{}}, 1, nil
+}
+
+func (openAPIFakeDB) RichList(ctx context.Context, pagination *types.Pagination) ([]*types.RichListEntry, uint64, error) {
+	return []*types.RichListEntry{{Address: openAPIAddress}}, 1, nil
+}
+
+func (openAPIFakeDB) GetListAddresses(ctx context.Context, sortDirection int, pagination *types.Pagination) ([]*types.Address, error) {
+	return []*types.Address{{Address: openAPIAddress}, {Address: openAPISMCAddress}}, nil
+}
+
+func (openAPIFakeDB) TxByHash(ctx context.Context, txHash string) (*types.Transaction, error) {
+	return &types.Transaction{Hash: txHash}, nil
+}
+
+func (openAPIFakeDB) TxsByAddress(ctx context.Context, address, txType string, pagination *types.Pagination) ([]*types.Transaction, uint64, error) {
+	return []*types.Transaction{{}}, 1, nil
+}
+
+func (openAPIFakeDB) UpsertSMCABIByType(ctx context.Context, smcType, abi string) error {
+	return nil
+}
+
+func (openAPIFakeDB) InsertContract(ctx context.Context, contract *types.Contract, addrInfo *types.Address) error {
+	return nil
+}
+
+func (openAPIFakeDB) UpdateContract(ctx context.Context, contract *types.Contract, addrInfo *types.Address) error {
+	return nil
+}
+
+func (openAPIFakeDB) UpdateAddresses(ctx context.Context, addresses []*types.Address) error {
+	return nil
+}
+
+func (openAPIFakeDB) Delegators(ctx context.Context, filter db.DelegatorFilter) ([]*types.Delegator, error) {
+	return []*types.Delegator{{Address: openAPIAddress, StakedAmount: "10"}}, nil
+}
+
+func (openAPIFakeDB) UpdateAPIKey(ctx context.Context, key *types.APIKey) error {
+	return nil
+}
+
+func (openAPIFakeDB) RotateAPIKey(ctx context.Context, id, hash, prefix string) (*types.APIKey, error) {
+	return &types.APIKey{ID: primitive.NewObjectID(), Tier: types.APIKeyTierFree, Prefix: prefix}, nil
+}
+
+func (openAPIFakeDB) BlockByHeight(ctx context.Context, blockHeight uint64) (*types.Block, error) {
+	return &types.Block{Height: blockHeight}, nil
+}
+
+func (openAPIFakeDB) LatestTxs(ctx context.Context, pagination *types.Pagination) ([]*types.Transaction, error) {
+	return []*types.Transaction{{BlockNumber: 10}}, nil
+}
+
+func (openAPIFakeDB) Nodes(ctx context.Context) ([]*types.NodeInfo, error) {
+	return []*types.NodeInfo{{ID: "node", Moniker: "node"}}, nil
+}
+
+func (openAPIFakeDB) UpsertNode(ctx context.Context, node *types.NodeInfo) error {
+	return nil
+}
+
+func (openAPIFakeDB) SlashSummary(ctx context.Context, validatorAddress string) (*types.SlashSummary, error) {
+	return &types.SlashSummary{}, nil
+}
+
+func (openAPIFakeDB) InsertAPIKey(ctx context.Context, key *types.APIKey) error {
+	return nil
+}
+
+func (openAPIFakeDB) RevokeAdminToken(ctx context.Context, token *types.RevokedAdminToken) error {
+	return nil
+}
+
+func (openAPIFakeDB) InsertWebhookWatch(ctx context.Context, watch *types.WebhookWatch) error {
+	return nil
+}
+
+func (openAPIFakeDB) UpdateHolders(ctx context.Context, holdersInfo []*types.TokenHolder) error {
+	return nil
+}
+
+func (openAPIFakeDB) CountDelegators(ctx context.Context, filter db.DelegatorFilter) (int64, error) {
+	return 1, nil
+}
+
+func (openAPIFakeDB) UpdateInternalTxs(ctx context.Context, holdersInfo []*types.TokenTransfer) error {
+	return nil
+}
+
+func (openAPIFakeDB) InsertEvents(events []types.Log) error {
+	return nil
+}
+
+func (openAPIFakeDB) DeleteEmptyEvents(ctx context.Context, contractAddress string) error {
+	return nil
+}
+
+type openAPIFakeCache struct {
+	cache.Client
+}
+
+func (openAPIFakeCache) TotalHolders(ctx context.Context) (uint64, uint64) {
+	return 100, 5
+}
+
+func (openAPIFakeCache) StakingStats(ctx context.Context) (*types.StakingStats, error) {
+	return &types.StakingStats{TotalValidators: 3, TotalStakedAmount: "1000"}, nil
+}
+
+func (openAPIFakeCache) BlockByHeight(ctx context.Context, blockHeight uint64) (*types.Block, error) {
+	return &types.Block{Height: blockHeight}, nil
+}
+
+func (openAPIFakeCache) IsRequestToCoinMarket(ctx context.Context) bool {
+	return false
+}
+
+func (openAPIFakeCache) LatestBlockHeight(ctx context.Context) uint64 {
+	return 100
+}
+
+func (openAPIFakeCache) PersistentErrorBlockHeights(ctx context.Context) ([]uint64, error) {
+	return []uint64{10}, nil
+}
+
+func (openAPIFakeCache) TotalTxs(ctx context.Context) uint64 {
+	return 1000
+}
+
+func (openAPIFakeCache) TxsByBlockHeight(ctx context.Context, blockHeight uint64, pagination *types.Pagination) ([]*types.Transaction, uint64, error) {
+	return []*types.Transaction{{BlockNumber: blockHeight}}, 1, nil
+}
+
+func (openAPIFakeCache) SupplyAmounts(ctx context.Context) (*types.SupplyInfo, error) {
+	return &types.SupplyInfo{ERC20CirculatingSupply: 100}, nil
+}
+
+func (openAPIFakeCache) UpdateSupplyAmounts(ctx context.Context, supplyInfo *types.SupplyInfo) error {
+	return nil
+}
+
+func (openAPIFakeCache) Autocomplete(ctx context.Context, query string, limit int64) ([]*types.AutocompleteEntry, error) {
+	return []*types.AutocompleteEntry{{}}, nil
+}
+
+func (openAPIFakeCache) AddressInfo(ctx context.Context, addr string) (*types.Address, error) {
+	return &types.Address{Address: addr}, nil
+}
+
+func (openAPIFakeCache) SMCAbi(ctx context.Context, key string) (string, error) {
+	return "[]", nil
+}
+
+func (openAPIFakeCache) KRCTokenInfo(ctx context.Context, krcTokenAddr string) (*types.KRCTokenInfo, error) {
+	return &types.KRCTokenInfo{Address: krcTokenAddr}, nil
+}
+
+func (openAPIFakeCache) LatestBlocks(ctx context.Context, pagination *types.Pagination) ([]*types.Block, error) {
+	return []*types.Block{{Height: 10}}, nil
+}
+
+func (openAPIFakeCache) LatestTransactions(ctx context.Context, pagination *types.Pagination) ([]*types.Transaction, error) {
+	return []*types.Transaction{{BlockNumber: 10}}, nil
+}
+
+func (openAPIFakeCache) TokenInfo(ctx context.Context) (*types.TokenInfo, error) {
+	return &types.TokenInfo{Name: "KardiaChain", Symbol: "KAI", Price: 0.05}, nil
+}
+
+func (openAPIFakeCache) UpdateAddressInfo(ctx context.Context, addrInfo *types.Address) error {
+	return nil
+}
+
+func (openAPIFakeCache) RemoveAutocompleteEntry(ctx context.Context, address string) error {
+	return nil
+}
+
+// openAPIFakeKai only implements methods used by handlers in Test_openAPIResponses, others panic
+type openAPIFakeKai struct {
+	kardia.ClientInterface
+}
+
+func (openAPIFakeKai) GetBalance(ctx context.Context, account string) (string, error) {
+	return "10", nil
+}
+
+func (openAPIFakeKai) GetParams(ctx context.Context) ([]*types.NetworkParams, error) {
+	return []*types.NetworkParams{{}}, nil
+}
+
+func (openAPIFakeKai) GetProposals(ctx context.Context, pagination *types.Pagination) ([]*types.ProposalDetail, uint64, error) {
+	return []*types.ProposalDetail{{}}, 1, nil
+}
+
+func (openAPIFakeKai) GetValidatorsByDelegator(ctx context.Context, delAddr common.Address) ([]*types.ValidatorsByDelegator, error) {
+	return []*types.ValidatorsByDelegator{{}}, nil
+}
+
+func (openAPIFakeKai) NodesInfo(ctx context.Context) ([]*types.NodeInfo, error) {
+	return []*types.NodeInfo{{}}, nil
+}
+
+func (openAPIFakeKai) GetMaxProposers(ctx context.Context) (int64, error) {
+	return 2, nil
+}
+
+func (openAPIFakeKai) DecodeInputData(to string, input string) (*types.FunctionCall, error) {
+	return &types.FunctionCall{}, nil
+}
+
+func (openAPIFakeKai) GetCirculatingSupply(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1000), nil
+}
+
+func (openAPIFakeKai) GetCode(ctx context.Context, account string) (common.Bytes, error) {
+	return common.Bytes{}, nil
+}
+
+// openAPIPathValues are path params of every route, by param type or by name for string params
+var openAPIPathValues = map[string]string{
+	"address": openAPIAddress,
+	"hash":    "0x1111111111111111111111111111111111111111111111111111111111111111",
+	"integer": "10",
+	"block":   "10",
+	"nodeID":  "node",
+	"id":      "5f5b1f6a1c9d440000a1b2c3",
+	"jti":     "0123456789abcdef0123456789abcdef",
+}
+
+// openAPIRequestBodies are bodies of routes which can't be called with an empty object
+var openAPIRequestBodies = map[string]string{
+	"PUT /nodes":             `{"id":"node","moniker":"node"}`,
+	"POST /staking/simulate": `{"actions":[{"type":"delegate","validator":"0x3333333333333333333333333333333333333333","amount":"10"}]}`,
+	"POST /webhooks/watches": `{"type":"incoming","address":"0x1111111111111111111111111111111111111111","url":"https://example.com/hook"}`,
+	"POST /admin/apikeys":    `{"owner":"owner"}`,
+}
+
+// openAPIRequestQueries are queries of routes besides paging
+var openAPIRequestQueries = map[string]string{
+	"GET /search":           "q=10",
+	"GET /dashboard/charts": "metric=txs",
+}
+
+func newOpenAPITestServer(priceURL string) *Server {
+	m := metrics.New()
+	return &Server{
+		Logger:  zap.NewNop(),
+		metrics: m,
+		infoServer: infoServer{
+			dbClient:      openAPIFakeDB{},
+			cacheClient:   openAPIFakeCache{},
+			kaiClient:     openAPIFakeKai{},
+			priceProvider: price.NewStub(priceURL),
+			metrics:       m,
+			logger:        zap.NewNop(),
+		},
+		stream:  newStreamHub(10, []string{"*"}),
+		apiKeys: newAPIKeyCache(),
+	}
+}
+
+// callOpenAPIRoute call route handler with sample params, failing instead of panicking when handler calls
+// a dependency which is not faked
+func callOpenAPIRoute(t *testing.T, e *echo.Echo, route api.Route) (rec *httptest.ResponseRecorder, ok bool) {
+	target := route.Path
+	var names, values []string
+	for name, typ := range route.PathParams {
+		value, found := openAPIPathValues[typ]
+		if typ == "string" {
+			value, found = openAPIPathValues[name]
+		}
+		if !assert.True(t, found, "no sample value of %s in %s", name, route.Path) {
+			return nil, false
+		}
+		names, values = append(names, name), append(values, value)
+		target = strings.Replace(target, ":"+name, value, 1)
+	}
+	var body io.Reader
+	if route.Method != http.MethodGet {
+		b, found := openAPIRequestBodies[route.Method+" "+route.Path]
+		if !found {
+			b = "{}"
+		}
+		body = strings.NewReader(b)
+	}
+	query := "page=1&limit=10"
+	if q, found := openAPIRequestQueries[route.Method+" "+route.Path]; found {
+		query += "&" + q
+	}
+	req := httptest.NewRequest(route.Method, target+"?"+query, body)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec = httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath(route.Path)
+	c.SetParamNames(names...)
+	c.SetParamValues(values...)
+	api.WithAPIKey(c, &types.APIKey{ID: primitive.NewObjectID(), Tier: types.APIKeyTierFree})
+	if route.Admin {
+		api.WithOperator(c, &types.Operator{Name: "operator", Roles: types.AdminRoles})
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("%s %s panics: %v\n%s", route.Method, route.Path, r, debug.Stack())
+			ok = false
+		}
+	}()
+	if !assert.NoError(t, route.Handler(c), route.Path) {
+		return nil, false
+	}
+	return rec, true
+}
+
+func Test_openAPIResponses(t *testing.T) {
+	priceServer := price.NewStubServer(&types.TokenInfo{Name: "KardiaChain", Symbol: "KAI", Price: 0.05}, nil)
+	defer priceServer.Close()
+	s := newOpenAPITestServer(priceServer.URL)
+	spec, err := api.NewOpenAPI(s)
+	if !assert.NoError(t, err) {
+		return
+	}
+	webhookLookupIP = func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}
+	defer func() { webhookLookupIP = net.DefaultResolver.LookupIPAddr }()
+	e := echo.New()
+	for _, route := range spec.Routes() {
+		rec, ok := callOpenAPIRoute(t, e, route)
+		if !ok {
+			continue
+		}
+		if !assert.Equal(t, http.StatusOK, rec.Code, "%s %s: %s", route.Method, route.Path, rec.Body.String()) || route.Stream {
+			continue
+		}
+		assert.NoError(t, spec.ValidateResponse(route.Method, route.Path, rec.Body.Bytes()), route.Path)
+	}
+
+	// old clients of /search get a different shape
+	assert.NoError(t, spec.ValidateResponse(http.MethodGet, "/search", []byte(`{"code":1000,"msg":"Success","data":[{"name":"KardiaChain","address":"0x1111111111111111111111111111111111111111","type":"KRC20","decimal":18,"isVerified":true}]}`)))
+
+	// diverging responses
+	assert.Error(t, spec.ValidateResponse(http.MethodGet, "/dashboard/holders/total", []byte(`{"code":1000,"msg":"Success","data":{"totalHolders":"100","totalContracts":5}}`)))
+	assert.Error(t, spec.ValidateResponse(http.MethodGet, "/dashboard/holders/total", []byte(`{"code":1000,"msg":"Success","data":{"totalHolders":100}}`)))
+	assert.Error(t, spec.ValidateResponse(http.MethodGet, "/staking/slashes", []byte(`{"code":1000,"msg":"Success","data":{"page":1,"limit":10,"total":1,"data":[{"height":10}]}}`)))
+	assert.Error(t, spec.ValidateResponse(http.MethodGet, "/ping", []byte(`{"code":1000,"msg":"Success","data":{"version":"1.0","extra":true}}`)))
+}
@@ -0,0 +1,56 @@
+// Package server
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/api"
+)
+
+const (
+	defaultPriceHistoryDays = 30
+	maxPriceHistoryRange    = 366 * 24 * time.Hour
+	// a price recorded longer before a tx is not its price, e.g. txs before history starts
+	maxPriceAge = 24 * time.Hour
+)
+
+// PriceHistory return recorded prices in range, plus the latest one before it
+func (s *Server) PriceHistory(c echo.Context) error {
+	ctx := context.Background()
+	tf, err := getTimeRange(c)
+	if err != nil {
+		return api.Invalid.Build(c)
+	}
+	if tf.ToTime.IsZero() {
+		tf.ToTime = time.Now()
+	}
+	if tf.FromTime.IsZero() {
+		tf.FromTime = tf.ToTime.AddDate(0, 0, -defaultPriceHistoryDays)
+	}
+	if tf.FromTime.After(tf.ToTime) || tf.ToTime.Sub(tf.FromTime) > maxPriceHistoryRange {
+		return api.Invalid.Build(c)
+	}
+	points, err := s.dbClient.PricePoints(ctx, priceSymbolKAI, priceCurrencyUSD, tf.FromTime, tf.ToTime)
+	if err != nil {
+		s.logger.Warn("Cannot get price history from db", zap.Error(err))
+		return api.Invalid.Build(c)
+	}
+	return api.OK.SetData(points).Build(c)
+}
+
+// fiatValueAt return KAI price at t and USD value of amount in wei, empty if price history doesn't cover t
+func (s *Server) fiatValueAt(ctx context.Context, amount string, t time.Time) (string, string) {
+	points, err := s.dbClient.PricePoints(ctx, priceSymbolKAI, priceCurrencyUSD, t, t)
+	if err != nil {
+		s.logger.Warn("Cannot get price history from db", zap.Error(err))
+		return "", ""
+	}
+	if len(points) == 0 || t.Sub(points[len(points)-1].Time) > maxPriceAge {
+		return "", ""
+	}
+	return fiatValue(points, formatUnits(amount, kaiDecimals), t)
+}
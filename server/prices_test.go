@@ -0,0 +1,60 @@
+// Package server
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/db"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+type pricesFakeDB struct {
+	db.Client
+	points []*types.PricePoint
+}
+
+// PricePoints return the latest point at or before to, as db does for a single instant
+func (d pricesFakeDB) PricePoints(ctx context.Context, symbol, currency string, from, to time.Time) ([]*types.PricePoint, error) {
+	var latest []*types.PricePoint
+	for _, p := range d.points {
+		if !p.Time.After(to) {
+			latest = []*types.PricePoint{p}
+		}
+	}
+	return latest, nil
+}
+
+func Test_fiatValueAt(t *testing.T) {
+	start := time.Unix(1614556800, 0)
+	s := &Server{infoServer: infoServer{
+		dbClient: pricesFakeDB{points: []*types.PricePoint{
+			{Price: 0.05, Time: start},
+			{Price: 0.1, Time: start.Add(time.Hour)},
+		}},
+		logger: zap.NewNop(),
+	}}
+	tests := []struct {
+		name      string
+		t         time.Time
+		wantPrice string
+		wantValue string
+	}{
+		{"before history", start.Add(-time.Minute), "", ""},
+		{"at first price", start, "0.05", "0.100000"},
+		{"latest price", start.Add(90 * time.Minute), "0.1", "0.200000"},
+		{"within max age", start.Add(time.Hour + maxPriceAge), "0.1", "0.200000"},
+		{"older than max age", start.Add(time.Hour + maxPriceAge + time.Second), "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, value := s.fiatValueAt(context.Background(), "2000000000000000000", tt.t)
+			assert.Equal(t, tt.wantPrice, price)
+			assert.Equal(t, tt.wantValue, value)
+		})
+	}
+}
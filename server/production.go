@@ -0,0 +1,131 @@
+// Package server
+package server
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/api"
+	"github.com/kardiachain/kardia-explorer-backend/db"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const (
+	defaultProductionWindow = 1000
+	defaultProductionDays   = 30
+	// proposer which produced less than this ratio of expected blocks is under-performing
+	underPerformingThreshold = 0.8
+)
+
+// ValidatorProduction return production stats of a proposer by bucket, for charts
+func (s *Server) ValidatorProduction(c echo.Context) error {
+	ctx := context.Background()
+	filter := s.getProductionStatsFilter(c)
+	filter.ProposerAddress = common.HexToAddress(c.Param("address")).Hex()
+	stats, err := s.dbClient.ProductionStats(ctx, filter)
+	if err != nil {
+		s.logger.Warn("Cannot get production stats from db", zap.Error(err))
+		return api.Invalid.Build(c)
+	}
+	return api.OK.SetData(stats).Build(c)
+}
+
+// ProposersProduction return leaderboard of proposers in range, with expected blocks from current voting power
+func (s *Server) ProposersProduction(c echo.Context) error {
+	ctx := context.Background()
+	filter := s.getProductionStatsFilter(c)
+	stats, err := s.dbClient.ProductionStats(ctx, filter)
+	if err != nil {
+		s.logger.Warn("Cannot get production stats from db", zap.Error(err))
+		return api.Invalid.Build(c)
+	}
+	validators, err := s.dbClient.Validators(ctx, db.ValidatorsFilter{})
+	if err != nil {
+		s.logger.Warn("Cannot get validators from db", zap.Error(err))
+		return api.Invalid.Build(c)
+	}
+	return api.OK.SetData(calculateProposerPerformance(stats, validators)).Build(c)
+}
+
+func calculateProposerPerformance(stats []*types.ProductionStats, validators []*types.Validator) []*types.ProposerPerformance {
+	var (
+		totalBlocks  uint64
+		performances []*types.ProposerPerformance
+		byProposer   = make(map[string]*types.ProposerPerformance)
+	)
+	for _, v := range validators {
+		p := &types.ProposerPerformance{
+			ProposerAddress:       v.Address,
+			Name:                  v.Name,
+			VotingPowerPercentage: v.VotingPowerPercentage,
+			Rewards:               "0",
+		}
+		byProposer[v.Address] = p
+		performances = append(performances, p)
+	}
+	for _, stat := range stats {
+		p, ok := byProposer[stat.ProposerAddress]
+		if !ok {
+			p = &types.ProposerPerformance{ProposerAddress: stat.ProposerAddress, Rewards: "0"}
+			byProposer[stat.ProposerAddress] = p
+			performances = append(performances, p)
+		}
+		p.BlocksProposed += stat.BlocksProposed
+		p.TxsIncluded += stat.TxsIncluded
+		p.GasUsed += stat.GasUsed
+		p.Rewards = addBigIntString(p.Rewards, stat.Rewards)
+		totalBlocks += stat.BlocksProposed
+	}
+
+	for _, p := range performances {
+		votingPower, err := strconv.ParseFloat(p.VotingPowerPercentage, 64)
+		if err != nil {
+			continue
+		}
+		p.ExpectedBlocks = float64(totalBlocks) * votingPower / 100
+		if p.ExpectedBlocks > 0 {
+			p.Performance = float64(p.BlocksProposed) / p.ExpectedBlocks
+			p.UnderPerforming = p.ExpectedBlocks >= 1 && p.Performance < underPerformingThreshold
+		}
+	}
+	sort.Slice(performances, func(i, j int) bool {
+		return performances[i].BlocksProposed > performances[j].BlocksProposed
+	})
+	return performances
+}
+
+// getProductionStatsFilter read bucket type and range from query params. Range of `day` bucket
+// is unix timestamp, default to last 30 days, range of `window` bucket is block height
+func (s *Server) getProductionStatsFilter(c echo.Context) *types.ProductionStatsFilter {
+	filter := &types.ProductionStatsFilter{
+		BucketType: types.ProductionBucketDay,
+	}
+	from, fromErr := strconv.ParseInt(c.QueryParam("from"), 10, 64)
+	to, toErr := strconv.ParseInt(c.QueryParam("to"), 10, 64)
+	if c.QueryParam("type") == types.ProductionBucketWindow {
+		filter.BucketType = types.ProductionBucketWindow
+		if fromErr == nil {
+			filter.FromBucket = from / int64(s.productionWindow)
+		}
+		if toErr == nil {
+			filter.ToBucket = to / int64(s.productionWindow)
+		}
+		return filter
+	}
+
+	day := 24 * time.Hour
+	filter.FromBucket = time.Now().UTC().Add(-defaultProductionDays * day).Truncate(day).Unix()
+	if fromErr == nil {
+		filter.FromBucket = time.Unix(from, 0).UTC().Truncate(day).Unix()
+	}
+	if toErr == nil {
+		filter.ToBucket = time.Unix(to, 0).UTC().Truncate(day).Unix()
+	}
+	return filter
+}
@@ -0,0 +1,49 @@
+// Package server
+package server
+
+import (
+	"context"
+
+	"github.com/labstack/echo"
+
+	"github.com/kardiachain/kardia-explorer-backend/api"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+// RateLimit take a token of every client bucket, a request is only allowed if all buckets have one
+func (s *Server) RateLimit(ctx context.Context, limit types.RateLimit, clients ...string) (*types.RateLimitResult, error) {
+	var result *types.RateLimitResult
+	for _, client := range clients {
+		r, err := s.cacheClient.TakeRateLimitToken(ctx, client, limit)
+		if err != nil {
+			s.metrics.RecordRateLimitError(limit.Name)
+			return nil, err
+		}
+		result = mostRestrictive(result, r)
+	}
+	if result == nil {
+		result = &types.RateLimitResult{Allowed: true, Remaining: limit.Burst}
+	}
+	s.metrics.RecordRateLimit(limit.Name, result.Allowed)
+	return result, nil
+}
+
+func mostRestrictive(a, b *types.RateLimitResult) *types.RateLimitResult {
+	if a == nil {
+		return b
+	}
+	if a.Allowed != b.Allowed {
+		if a.Allowed {
+			return b
+		}
+		return a
+	}
+	if b.RetryAfter > a.RetryAfter || (b.RetryAfter == a.RetryAfter && b.Remaining < a.Remaining) {
+		return b
+	}
+	return a
+}
+
+func (s *Server) RateLimitMetrics(c echo.Context) error {
+	return api.OK.SetData(s.metrics.GetRateLimitCounters()).Build(c)
+}
@@ -0,0 +1,25 @@
+// Package server
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_mostRestrictive(t *testing.T) {
+	ip := &types.RateLimitResult{Allowed: true, Remaining: 10}
+	key := &types.RateLimitResult{Allowed: true, Remaining: 3}
+	limited := &types.RateLimitResult{Allowed: false, RetryAfter: time.Second}
+	longer := &types.RateLimitResult{Allowed: false, RetryAfter: 5 * time.Second}
+
+	assert.Equal(t, ip, mostRestrictive(nil, ip))
+	assert.Equal(t, key, mostRestrictive(ip, key))
+	assert.Equal(t, key, mostRestrictive(key, ip))
+	assert.Equal(t, limited, mostRestrictive(ip, limited))
+	assert.Equal(t, limited, mostRestrictive(limited, key))
+	assert.Equal(t, longer, mostRestrictive(limited, longer))
+}
@@ -24,10 +24,25 @@ import (
 	"github.com/kardiachain/kardia-explorer-backend/types"
 )
 
+type pingStat struct {
+	Version string `json:"version"`
+}
+
+type dashboardStat struct {
+	NumTxs uint64 `json:"numTxs"`
+	Time   uint64 `json:"time"`
+}
+
+type statsResponse struct {
+	Data []*dashboardStat `json:"data"`
+}
+
+type totalHoldersResponse struct {
+	TotalHolders   uint64 `json:"totalHolders"`
+	TotalContracts uint64 `json:"totalContracts"`
+}
+
 func (s *Server) Ping(c echo.Context) error {
-	type pingStat struct {
-		Version string `json:"version"`
-	}
 	stats := &pingStat{Version: cfg.ServerVersion}
 	return api.OK.SetData(stats).Build(c)
 }
@@ -43,23 +58,16 @@ func (s *Server) Stats(c echo.Context) error {
 		return api.InternalServer.Build(c)
 	}
 
-	type Stat struct {
-		NumTxs uint64 `json:"numTxs"`
-		Time   uint64 `json:"time"`
-	}
-
-	var stats []*Stat
+	var stats []*dashboardStat
 	for _, b := range blocks {
-		stat := &Stat{
+		stat := &dashboardStat{
 			NumTxs: b.NumTxs,
 			Time:   uint64(b.Time.Unix()),
 		}
 		stats = append(stats, stat)
 	}
 
-	return api.OK.SetData(struct {
-		Data interface{} `json:"data"`
-	}{
+	return api.OK.SetData(statsResponse{
 		Data: stats,
 	}).Build(c)
 }
@@ -67,10 +75,7 @@ func (s *Server) Stats(c echo.Context) error {
 func (s *Server) TotalHolders(c echo.Context) error {
 	ctx := context.Background()
 	totalHolders, totalContracts := s.cacheClient.TotalHolders(ctx)
-	return api.OK.SetData(struct {
-		TotalHolders   uint64 `json:"totalHolders"`
-		TotalContracts uint64 `json:"totalContracts"`
-	}{
+	return api.OK.SetData(totalHoldersResponse{
 		TotalHolders:   totalHolders,
 		TotalContracts: totalContracts,
 	}).Build(c)
@@ -143,13 +148,13 @@ func (s *Server) TokenInfo(c echo.Context) error {
 
 func (s *Server) UpdateSupplyAmounts(c echo.Context) error {
 	ctx := context.Background()
-	if c.Request().Header.Get("Authorization") != s.infoServer.HttpRequestSecret {
-		return api.Unauthorized.Build(c)
-	}
 	var supplyInfo *types.SupplyInfo
 	if err := c.Bind(&supplyInfo); err != nil {
 		return api.Invalid.Build(c)
 	}
+	if before, err := s.cacheClient.SupplyAmounts(ctx); err == nil && before != nil {
+		api.AuditBefore(c, before)
+	}
 	if err := s.cacheClient.UpdateSupplyAmounts(ctx, supplyInfo); err != nil {
 		return api.Invalid.Build(c)
 	}
@@ -234,15 +239,41 @@ func (s *Server) Blocks(c echo.Context) error {
 		err    error
 		blocks []*types.Block
 	)
-	pagination, page, limit := getPagingOption(c)
+	pagination, page, limit, err := getCursorPagingOption(c)
+	if err != nil {
+		return api.Invalid.Build(c)
+	}
+	if pagination == nil {
+		pagination = &types.Pagination{}
+		pagination.Sanitize()
+	}
+	total := s.cacheClient.LatestBlockHeight(ctx)
 
-	blocks, err = s.cacheClient.LatestBlocks(ctx, pagination)
-	if err != nil || blocks == nil {
-		blocks, err = s.dbClient.Blocks(ctx, pagination)
+	filter, err := getBlocksFilter(c, pagination)
+	if err != nil {
+		return api.Invalid.Build(c)
+	}
+	if !filter.IsEmpty() {
+		blocks, total, err = s.dbClient.BlocksByFilter(ctx, filter)
 		if err != nil {
-			s.logger.Info("Cannot get latest blocks from db", zap.Error(err))
+			s.logger.Info("Cannot get filtered blocks from db", zap.Error(err))
+			if errors.Is(err, db.ErrUnindexedFilter) {
+				return api.Invalid.Build(c)
+			}
 			return api.InternalServer.Build(c)
 		}
+	} else {
+		// cache only keep latest blocks, cursor may point anywhere
+		if pagination.Cursor == nil {
+			blocks, err = s.cacheClient.LatestBlocks(ctx, pagination)
+		}
+		if err != nil || blocks == nil {
+			blocks, err = s.dbClient.Blocks(ctx, pagination)
+			if err != nil {
+				s.logger.Info("Cannot get latest blocks from db", zap.Error(err))
+				return api.InternalServer.Build(c)
+			}
+		}
 	}
 
 	smcAddress := map[string]*valInfoResponse{}
@@ -277,13 +308,16 @@ func (s *Server) Blocks(c echo.Context) error {
 		}
 		result = append(result, b)
 	}
-	total := s.cacheClient.LatestBlockHeight(ctx)
-	return api.OK.SetData(PagingResponse{
+	resp := PagingResponse{
 		Page:  page,
 		Limit: limit,
 		Data:  result,
 		Total: total,
-	}).Build(c)
+	}
+	if len(blocks) > 0 {
+		resp.Next, resp.Prev = pageCursors(pagination, len(blocks), &types.Cursor{Height: blocks[0].Height}, &types.Cursor{Height: blocks[len(blocks)-1].Height})
+	}
+	return api.OK.SetData(resp).Build(c)
 }
 
 func (s *Server) Block(c echo.Context) error {
@@ -376,7 +410,13 @@ func (s *Server) BlockTxs(c echo.Context) error {
 		err   error
 	)
 
-	if strings.HasPrefix(block, "0x") {
+	if txType := c.QueryParam("type"); txType != "" {
+		txs, total, err = s.blockTxsOfType(ctx, block, txType, pagination)
+		if err != nil {
+			s.logger.Warn("cannot get block txs by type from db", zap.String("block", block), zap.Error(err))
+			return api.Invalid.Build(c)
+		}
+	} else if strings.HasPrefix(block, "0x") {
 		// get block txs in block if exist
 		txs, total, err = s.cacheClient.TxsByBlockHash(ctx, block, pagination)
 		if err != nil {
@@ -445,6 +485,7 @@ func (s *Server) BlockTxs(c echo.Context) error {
 			Value:            tx.Value,
 			TxFee:            tx.TxFee,
 			Status:           tx.Status,
+			Type:             tx.Type,
 			DecodedInputData: tx.DecodedInputData,
 			InputData:        tx.InputData,
 		}
@@ -526,17 +567,39 @@ func (s *Server) BlocksByProposer(c echo.Context) error {
 
 func (s *Server) Txs(c echo.Context) error {
 	ctx := context.Background()
-	pagination, page, limit := getPagingOption(c)
-	var (
-		err error
-		txs []*types.Transaction
-	)
+	pagination, page, limit, err := getCursorPagingOption(c)
+	if err != nil {
+		return api.Invalid.Build(c)
+	}
+	if pagination == nil {
+		pagination = &types.Pagination{}
+		pagination.Sanitize()
+	}
+	var txs []*types.Transaction
+	total := s.cacheClient.TotalTxs(ctx)
 
-	txs, err = s.cacheClient.LatestTransactions(ctx, pagination)
-	if err != nil || txs == nil || len(txs) < limit {
-		txs, err = s.dbClient.LatestTxs(ctx, pagination)
+	filter, err := getTxsFilter(c, pagination)
+	if err != nil {
+		return api.Invalid.Build(c)
+	}
+	if !filter.IsEmpty() {
+		txs, total, err = s.dbClient.TxsByFilter(ctx, filter)
 		if err != nil {
-			return api.Invalid.Build(c)
+			s.logger.Info("Cannot get filtered txs from db", zap.Error(err))
+			if errors.Is(err, db.ErrUnindexedFilter) {
+				return api.Invalid.Build(c)
+			}
+			return api.InternalServer.Build(c)
+		}
+	} else {
+		if pagination.Cursor == nil {
+			txs, err = s.cacheClient.LatestTransactions(ctx, pagination)
+		}
+		if err != nil || txs == nil || len(txs) < limit {
+			txs, err = s.dbClient.LatestTxs(ctx, pagination)
+			if err != nil {
+				return api.Invalid.Build(c)
+			}
 		}
 	}
 
@@ -553,6 +616,7 @@ func (s *Server) Txs(c echo.Context) error {
 			Value:            tx.Value,
 			TxFee:            tx.TxFee,
 			Status:           tx.Status,
+			Type:             tx.Type,
 			DecodedInputData: tx.DecodedInputData,
 			InputData:        tx.InputData,
 		}
@@ -572,12 +636,16 @@ func (s *Server) Txs(c echo.Context) error {
 		result = append(result, t)
 	}
 
-	return api.OK.SetData(PagingResponse{
+	resp := PagingResponse{
 		Page:  page,
 		Limit: limit,
-		Total: s.cacheClient.TotalTxs(ctx),
+		Total: total,
 		Data:  result,
-	}).Build(c)
+	}
+	if len(txs) > 0 {
+		resp.Next, resp.Prev = pageCursors(pagination, len(txs), timeCursor(txs[0].Time, txs[0].Hash), timeCursor(txs[len(txs)-1].Time, txs[len(txs)-1].Hash))
+	}
+	return api.OK.SetData(resp).Build(c)
 }
 
 func (s *Server) Addresses(c echo.Context) error {
@@ -588,44 +656,97 @@ func (s *Server) Addresses(c echo.Context) error {
 	if err != nil || (sortDirection != 1 && sortDirection != -1) {
 		sortDirection = -1 // DESC
 	}
+	smcAddress := s.getValidatorsAddressAndRole(ctx)
+	totalHolders, totalContracts := s.cacheClient.TotalHolders(ctx)
+	total := totalHolders + totalContracts
+	result := Addresses{}
+	seen := make(map[string]bool)
+	// rich list is refreshed by watcher, pages past its end and pages before its first refresh are read from
+	// stored balances
+	if sortDirection == -1 {
+		entries, richListTotal, err := s.dbClient.RichList(ctx, pagination)
+		if err != nil {
+			s.logger.Warn("Cannot get rich list from db", zap.Error(err))
+		}
+		if richListTotal > total {
+			total = richListTotal
+		}
+		if richListTotal > 0 && uint64(pagination.Skip) < richListTotal {
+			for _, e := range entries {
+				addrInfo := SimpleAddress{
+					Address:          e.Address,
+					BalanceString:    e.BalanceString,
+					IsContract:       e.IsContract,
+					Name:             e.Name,
+					Rank:             e.Rank,
+					Tag:              e.Tag,
+					SupplyPercentage: e.SupplyPercentage,
+					RankChange24h:    e.RankChange24h,
+					RankChange7d:     e.RankChange7d,
+					BalanceChange24h: e.BalanceChange24h,
+					BalanceChange7d:  e.BalanceChange7d,
+				}
+				if smcAddress[e.Address] != nil {
+					addrInfo.IsInValidatorsList = true
+					addrInfo.Role = smcAddress[e.Address].Role
+				}
+				result = append(result, addrInfo)
+				seen[e.Address] = true
+			}
+			if len(entries) >= pagination.Limit {
+				return api.OK.SetData(PagingResponse{
+					Page:  page,
+					Limit: limit,
+					Total: total,
+					Data:  result,
+				}).Build(c)
+			}
+			// page goes past the end of rich list, the rest of it is read from stored balances
+			pagination = &types.Pagination{Skip: int(richListTotal), Limit: pagination.Limit - len(entries)}
+		}
+	}
+
 	addrs, err := s.dbClient.GetListAddresses(ctx, sortDirection, pagination)
 	if err != nil {
 		return api.Invalid.Build(c)
 	}
-	totalHolders, totalContracts := s.cacheClient.TotalHolders(ctx)
-	smcAddress := s.getValidatorsAddressAndRole(ctx)
-	var result Addresses
-	for _, addr := range addrs {
+	for i, addr := range addrs {
+		if seen[addr.Address] {
+			continue
+		}
 		addrInfo := SimpleAddress{
 			Address:       addr.Address,
 			BalanceString: addr.BalanceString,
 			IsContract:    addr.IsContract,
 			Name:          addr.Name,
-			Rank:          addr.Rank,
+			Rank:          addressRank(sortDirection, pagination.Skip+i, total),
 		}
 		if smcAddress[addr.Address] != nil {
 			addrInfo.IsInValidatorsList = true
 			addrInfo.Role = smcAddress[addr.Address].Role
 		}
-		// double check with balance from RPC
-		balance, err := s.kaiClient.GetBalance(ctx, addr.Address)
-		if err != nil {
-			return err
-		}
-		if balance != addr.BalanceString {
-			addr.BalanceString = balance
-			_ = s.dbClient.UpdateAddresses(ctx, []*types.Address{addr})
-		}
 		result = append(result, addrInfo)
 	}
 	return api.OK.SetData(PagingResponse{
 		Page:  page,
 		Limit: limit,
-		Total: totalHolders + totalContracts,
+		Total: total,
 		Data:  result,
 	}).Build(c)
 }
 
+// addressRank return rank by balance of address at offset of addresses sorted in direction, ranks of ascending
+// list count from the richest of total addresses
+func addressRank(sortDirection, offset int, total uint64) uint64 {
+	if sortDirection == -1 {
+		return uint64(offset + 1)
+	}
+	if uint64(offset) >= total {
+		return 0
+	}
+	return total - uint64(offset)
+}
+
 func (s *Server) AddressInfo(c echo.Context) error {
 	ctx := context.Background()
 	// Convert to addr and get back string to avoid wrong checksum
@@ -714,9 +835,12 @@ func (s *Server) AddressTxs(c echo.Context) error {
 	ctx := context.Background()
 	var err error
 	address := c.Param("address")
-	pagination, page, limit := getPagingOption(c)
+	pagination, page, limit, err := getCursorPagingOption(c)
+	if err != nil {
+		return api.Invalid.Build(c)
+	}
 
-	txs, total, err := s.dbClient.TxsByAddress(ctx, address, pagination)
+	txs, total, err := s.dbClient.TxsByAddress(ctx, address, c.QueryParam("type"), pagination)
 	if err != nil {
 		return err
 	}
@@ -734,6 +858,7 @@ func (s *Server) AddressTxs(c echo.Context) error {
 			Value:            tx.Value,
 			TxFee:            tx.TxFee,
 			Status:           tx.Status,
+			Type:             tx.Type,
 			DecodedInputData: tx.DecodedInputData,
 			InputData:        tx.InputData,
 		}
@@ -752,12 +877,16 @@ func (s *Server) AddressTxs(c echo.Context) error {
 		result = append(result, t)
 	}
 
-	return api.OK.SetData(PagingResponse{
+	resp := PagingResponse{
 		Page:  page,
 		Limit: limit,
 		Total: total,
 		Data:  result,
-	}).Build(c)
+	}
+	if len(txs) > 0 {
+		resp.Next, resp.Prev = pageCursors(pagination, len(txs), timeCursor(txs[0].Time, txs[0].Hash), timeCursor(txs[len(txs)-1].Time, txs[len(txs)-1].Hash))
+	}
+	return api.OK.SetData(resp).Build(c)
 }
 
 func (s *Server) AddressHolders(c echo.Context) error {
@@ -901,6 +1030,7 @@ func (s *Server) TxByHash(c echo.Context) error {
 		From:             tx.From,
 		To:               tx.To,
 		Status:           tx.Status,
+		Type:             tx.Type,
 		ContractAddress:  tx.ContractAddress,
 		Value:            tx.Value,
 		GasPrice:         tx.GasPrice,
@@ -916,6 +1046,7 @@ func (s *Server) TxByHash(c echo.Context) error {
 		LogsBloom:        tx.LogsBloom,
 		Root:             tx.Root,
 	}
+	result.PriceUSD, result.ValueUSD = s.fiatValueAt(ctx, tx.Value, tx.Time)
 	addrInfo, _ := s.getAddressInfo(ctx, tx.From)
 	if addrInfo != nil {
 		result.FromName = addrInfo.Name
@@ -1068,9 +1199,6 @@ func (s *Server) getValidatorsAddressAndRole(ctx context.Context) map[string]*va
 
 func (s *Server) UpsertNetworkNodes(c echo.Context) error {
 	//ctx := context.Background()
-	if c.Request().Header.Get("Authorization") != s.infoServer.HttpRequestSecret {
-		return api.Unauthorized.Build(c)
-	}
 	var nodeInfo *types.NodeInfo
 	if err := c.Bind(&nodeInfo); err != nil {
 		return api.Invalid.Build(c)
@@ -1079,6 +1207,9 @@ func (s *Server) UpsertNetworkNodes(c echo.Context) error {
 		return api.Invalid.Build(c)
 	}
 	ctx := context.Background()
+	if before := s.networkNode(ctx, nodeInfo.ID); before != nil {
+		api.AuditBefore(c, before)
+	}
 	if err := s.dbClient.UpsertNode(ctx, nodeInfo); err != nil {
 		return api.InternalServer.Build(c)
 	}
@@ -1088,15 +1219,15 @@ func (s *Server) UpsertNetworkNodes(c echo.Context) error {
 
 func (s *Server) RemoveNetworkNodes(c echo.Context) error {
 	//ctx := context.Background()
-	if c.Request().Header.Get("Authorization") != s.infoServer.HttpRequestSecret {
-		return api.Unauthorized.Build(c)
-	}
 	nodesID := c.Param("nodeID")
 	if nodesID == "" {
 		return api.Invalid.Build(c)
 	}
 
 	ctx := context.Background()
+	if before := s.networkNode(ctx, nodesID); before != nil {
+		api.AuditBefore(c, before)
+	}
 	if err := s.dbClient.RemoveNode(ctx, nodesID); err != nil {
 		return api.InternalServer.Build(c)
 	}
@@ -1104,12 +1235,22 @@ func (s *Server) RemoveNetworkNodes(c echo.Context) error {
 	return api.OK.Build(c)
 }
 
-func (s *Server) ReloadAddressesBalance(c echo.Context) error {
-	ctx := context.Background()
-	if c.Request().Header.Get("Authorization") != s.infoServer.HttpRequestSecret {
-		return api.Unauthorized.Build(c)
+// networkNode return stored node of id for audit log, nil if it's not found
+func (s *Server) networkNode(ctx context.Context, id string) *types.NodeInfo {
+	nodes, err := s.dbClient.Nodes(ctx)
+	if err != nil {
+		return nil
 	}
+	for _, node := range nodes {
+		if node.ID == id {
+			return node
+		}
+	}
+	return nil
+}
 
+func (s *Server) ReloadAddressesBalance(c echo.Context) error {
+	ctx := context.Background()
 	addresses, err := s.dbClient.Addresses(ctx)
 	if err != nil {
 		return api.Invalid.Build(c)
@@ -1132,9 +1273,6 @@ func (s *Server) ReloadAddressesBalance(c echo.Context) error {
 
 func (s *Server) UpdateAddressName(c echo.Context) error {
 	ctx := context.Background()
-	if c.Request().Header.Get("Authorization") != s.infoServer.HttpRequestSecret {
-		return api.Unauthorized.Build(c)
-	}
 	var addressName types.UpdateAddress
 	if err := c.Bind(&addressName); err != nil {
 		fmt.Println("cannot bind ", err)
@@ -1144,6 +1282,8 @@ func (s *Server) UpdateAddressName(c echo.Context) error {
 	if err != nil {
 		return api.Invalid.Build(c)
 	}
+	before := *addressInfo
+	api.AuditBefore(c, &before)
 
 	addressInfo.Name = addressName.Name
 
@@ -1152,14 +1292,11 @@ func (s *Server) UpdateAddressName(c echo.Context) error {
 		return api.Invalid.Build(c)
 	}
 	_ = s.cacheClient.UpdateAddressInfo(ctx, addressInfo)
+	s.refreshAutocomplete(ctx, addressInfo.Address)
 	return api.OK.Build(c)
 }
 
 func (s *Server) ReloadValidators(c echo.Context) error {
-	if c.Request().Header.Get("Authorization") != s.infoServer.HttpRequestSecret {
-		return api.Unauthorized.Build(c)
-	}
-
 	//todo longnd: rework reload validator API
 	//validators, err := s.kaiClient.Validators(ctx)
 	//if err != nil {
@@ -1301,10 +1438,6 @@ func (s *Server) Contract(c echo.Context) error {
 func (s *Server) InsertContract(c echo.Context) error {
 	lgr := s.logger.With(zap.String("method", "InsertContract"))
 
-	if c.Request().Header.Get("Authorization") != s.infoServer.HttpRequestSecret {
-		return api.Unauthorized.Build(c)
-	}
-
 	lgr.Debug("Start insert contract")
 	var (
 		contract     types.Contract
@@ -1339,6 +1472,9 @@ func (s *Server) InsertContract(c echo.Context) error {
 		addrInfo.Decimals = krcTokenInfoFromRPC.Decimals
 	}
 	currTokenInfo, _ := s.dbClient.AddressByHash(ctx, addrInfo.Address)
+	if currTokenInfo != nil {
+		api.AuditBefore(c, currTokenInfo)
+	}
 	if err := s.dbClient.InsertContract(ctx, &contract, &addrInfo); err != nil {
 		lgr.Error("cannot bind insert", zap.Error(err))
 		return api.InternalServer.Build(c)
@@ -1349,6 +1485,7 @@ func (s *Server) InsertContract(c echo.Context) error {
 			lgr.Error("cannot retrieve history transfer of KRC token", zap.Error(err), zap.String("address", addrInfo.Address))
 		}
 	}
+	s.refreshAutocomplete(ctx, addrInfo.Address)
 
 	return api.OK.Build(c)
 }
@@ -1356,10 +1493,6 @@ func (s *Server) InsertContract(c echo.Context) error {
 func (s *Server) UpdateContract(c echo.Context) error {
 	lgr := s.logger.With(zap.String("method", "UpdateContract"))
 
-	if c.Request().Header.Get("Authorization") != s.infoServer.HttpRequestSecret {
-		return api.Unauthorized.Build(c)
-	}
-
 	lgr.Debug("Start insert contract")
 	var (
 		contract     types.Contract
@@ -1394,6 +1527,9 @@ func (s *Server) UpdateContract(c echo.Context) error {
 		addrInfo.Decimals = krcTokenInfoFromRPC.Decimals
 	}
 	currTokenInfo, _ := s.dbClient.AddressByHash(ctx, addrInfo.Address)
+	if currTokenInfo != nil {
+		api.AuditBefore(c, currTokenInfo)
+	}
 	if err := s.dbClient.UpdateContract(ctx, &contract, &addrInfo); err != nil {
 		lgr.Error("cannot bind insert", zap.Error(err))
 		return api.InternalServer.Build(c)
@@ -1404,19 +1540,20 @@ func (s *Server) UpdateContract(c echo.Context) error {
 			lgr.Error("cannot retrieve history transfer of KRC token", zap.Error(err), zap.String("address", addrInfo.Address))
 		}
 	}
+	s.refreshAutocomplete(ctx, addrInfo.Address)
 
 	return api.OK.SetData(addrInfo).Build(c)
 }
 
 func (s *Server) UpdateSMCABIByType(c echo.Context) error {
-	if c.Request().Header.Get("Authorization") != s.infoServer.HttpRequestSecret {
-		return api.Unauthorized.Build(c)
-	}
 	ctx := context.Background()
 	var smcABI *types.ContractABI
 	if err := c.Bind(&smcABI); err != nil {
 		return api.Invalid.Build(c)
 	}
+	if abi, err := s.dbClient.SMCABIByType(ctx, smcABI.Type); err == nil {
+		api.AuditBefore(c, &types.ContractABI{Type: smcABI.Type, ABI: abi})
+	}
 	err := s.dbClient.UpsertSMCABIByType(ctx, smcABI.Type, smcABI.ABI)
 	if err != nil {
 		return api.Invalid.Build(c)
@@ -1424,63 +1561,6 @@ func (s *Server) UpdateSMCABIByType(c echo.Context) error {
 	return api.OK.Build(c)
 }
 
-func (s *Server) SearchAddressByName(c echo.Context) error {
-	var (
-		ctx     = context.Background()
-		name    = c.QueryParam("name")
-		addrMap = make(map[string]*SimpleKRCTokenInfo)
-	)
-	if name == "" {
-		return api.Invalid.Build(c)
-	}
-
-	addresses, err := s.dbClient.AddressByName(ctx, name)
-	if err == nil && len(addresses) > 0 {
-		for _, addr := range addresses {
-			addrMap[addr.Address] = &SimpleKRCTokenInfo{
-				Name:        addr.Name,
-				Address:     addr.Address,
-				Info:        addr.Info,
-				Type:        "Address",
-				TokenSymbol: addr.TokenSymbol,
-			}
-		}
-	}
-	s.logger.Info("Addresses search results", zap.Any("addresses", addresses), zap.Error(err))
-
-	contracts, err := s.dbClient.ContractByName(ctx, name)
-	if err == nil && len(contracts) > 0 {
-		for _, smc := range contracts {
-			if smc.Type == "" {
-				smc.Type = "SMC"
-			}
-			if addrMap[smc.Address] != nil {
-				addrMap[smc.Address].Type = smc.Type
-				addrMap[smc.Address].Name = smc.Name
-				addrMap[smc.Address].Logo = smc.Logo
-			} else {
-				addrMap[smc.Address] = &SimpleKRCTokenInfo{
-					Name:    smc.Name,
-					Address: smc.Address,
-					Info:    smc.Info,
-					Logo:    smc.Logo,
-					Type:    smc.Type,
-				}
-			}
-		}
-	}
-	s.logger.Info("Contracts search results", zap.Any("contracts", contracts), zap.Error(err))
-
-	if len(addrMap) == 0 {
-		return api.Invalid.Build(c)
-	}
-	result := make([]*SimpleKRCTokenInfo, 0, len(addrMap))
-	for _, addr := range addrMap {
-		result = append(result, addr)
-	}
-	return api.OK.SetData(result).Build(c)
-}
-
 func (s *Server) GetHoldersListByToken(c echo.Context) error {
 	ctx := context.Background()
 	var (
@@ -1522,11 +1602,10 @@ func (s *Server) GetHoldersListByToken(c echo.Context) error {
 
 func (s *Server) GetInternalTxs(c echo.Context) error {
 	ctx := context.Background()
-	var (
-		page, limit int
-		err         error
-	)
-	pagination, page, limit := getPagingOption(c)
+	pagination, page, limit, err := getCursorPagingOption(c)
+	if err != nil {
+		return api.Invalid.Build(c)
+	}
 	filterCrit := &types.InternalTxsFilter{
 		Pagination:      pagination,
 		Contract:        c.QueryParam("contractAddress"),
@@ -1565,12 +1644,17 @@ func (s *Server) GetInternalTxs(c echo.Context) error {
 			result[i].KRCTokenInfo = krcTokenInfo
 		}
 	}
-	return api.OK.SetData(PagingResponse{
+	resp := PagingResponse{
 		Page:  page,
 		Limit: limit,
 		Total: total,
 		Data:  result,
-	}).Build(c)
+	}
+	if len(iTxs) > 0 {
+		first, last := iTxs[0], iTxs[len(iTxs)-1]
+		resp.Next, resp.Prev = pageCursors(pagination, len(iTxs), timeCursor(first.Time, first.ID.Hex()), timeCursor(last.Time, last.ID.Hex()))
+	}
+	return api.OK.SetData(resp).Build(c)
 }
 
 func (s *Server) getAddressInfo(ctx context.Context, address string) (*types.Address, error) {
@@ -0,0 +1,180 @@
+// Package server
+package server
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/api"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const (
+	defaultRewardHistoryRange = 30 * 24 * time.Hour
+	year                      = 365 * 24 * time.Hour
+)
+
+func (s *Server) DelegatorRewards(c echo.Context) error {
+	lgr := s.logger.With(zap.String("method", "DelegatorRewards"))
+	ctx := context.Background()
+	address := common.HexToAddress(c.Param("address")).Hex()
+	timeFilter := getRewardTimeFilter(c)
+	snapshots, _, err := s.dbClient.RewardSnapshots(ctx, &types.RewardSnapshotsFilter{
+		TimeFilter:       timeFilter,
+		DelegatorAddress: address,
+	})
+	if err != nil {
+		lgr.Error("cannot load reward snapshots", zap.Error(err))
+		return api.Invalid.Build(c)
+	}
+
+	// group delegations by validator
+	validatorSnapshots := make(map[string][]*types.RewardSnapshot)
+	for _, snapshot := range snapshots {
+		validatorSnapshots[snapshot.ValidatorSMCAddress] = append(validatorSnapshots[snapshot.ValidatorSMCAddress], snapshot)
+	}
+	validators := s.getValidatorsAddressAndRole(ctx)
+	var histories []*types.RewardHistory
+	for smcAddress, list := range validatorSnapshots {
+		history := buildRewardHistory(list, timeFilter)
+		history.ValidatorSMCAddress = smcAddress
+		history.DelegatorAddress = address
+		if v, ok := validators[smcAddress]; ok {
+			history.ValidatorName = v.Name
+		}
+		histories = append(histories, history)
+	}
+	sort.Slice(histories, func(i, j int) bool {
+		return histories[i].ValidatorSMCAddress < histories[j].ValidatorSMCAddress
+	})
+	return api.OK.SetData(histories).Build(c)
+}
+
+func (s *Server) ValidatorRewards(c echo.Context) error {
+	lgr := s.logger.With(zap.String("method", "ValidatorRewards"))
+	ctx := context.Background()
+	validator, err := s.dbClient.Validator(ctx, common.HexToAddress(c.Param("address")).Hex())
+	if err != nil {
+		lgr.Error("cannot load validator from db", zap.Error(err))
+		return api.Invalid.Build(c)
+	}
+	timeFilter := getRewardTimeFilter(c)
+	snapshots, _, err := s.dbClient.RewardSnapshots(ctx, &types.RewardSnapshotsFilter{
+		TimeFilter:          timeFilter,
+		ValidatorSMCAddress: validator.SmcAddress,
+	})
+	if err != nil {
+		lgr.Error("cannot load reward snapshots", zap.Error(err))
+		return api.Invalid.Build(c)
+	}
+
+	history := buildRewardHistory(sumSnapshotsByTime(snapshots), timeFilter)
+	history.ValidatorSMCAddress = validator.SmcAddress
+	history.ValidatorName = validator.Name
+	return api.OK.SetData(history).Build(c)
+}
+
+// getRewardTimeFilter read `from` and `to` unix timestamp from query params, default to last 30 days
+func getRewardTimeFilter(c echo.Context) *types.TimeFilter {
+	filter := &types.TimeFilter{
+		FromTime: time.Now().Add(-defaultRewardHistoryRange),
+		ToTime:   time.Now(),
+	}
+	if from, err := strconv.ParseInt(c.QueryParam("from"), 10, 64); err == nil {
+		filter.FromTime = time.Unix(from, 0)
+	}
+	if to, err := strconv.ParseInt(c.QueryParam("to"), 10, 64); err == nil {
+		filter.ToTime = time.Unix(to, 0)
+	}
+	return filter
+}
+
+// sumSnapshotsByTime merge snapshots of all delegations taken at the same time into one
+func sumSnapshotsByTime(snapshots []*types.RewardSnapshot) []*types.RewardSnapshot {
+	var (
+		result []*types.RewardSnapshot
+		byTime = make(map[int64]*types.RewardSnapshot)
+	)
+	for _, snapshot := range snapshots {
+		merged, ok := byTime[snapshot.Time.Unix()]
+		if !ok {
+			merged = &types.RewardSnapshot{
+				ValidatorSMCAddress: snapshot.ValidatorSMCAddress,
+				StakedAmount:        "0",
+				Reward:              "0",
+				WithdrawnReward:     "0",
+				EarnedReward:        "0",
+				Time:                snapshot.Time,
+			}
+			byTime[snapshot.Time.Unix()] = merged
+			result = append(result, merged)
+		}
+		merged.StakedAmount = addBigIntString(merged.StakedAmount, snapshot.StakedAmount)
+		merged.Reward = addBigIntString(merged.Reward, snapshot.Reward)
+		merged.WithdrawnReward = addBigIntString(merged.WithdrawnReward, snapshot.WithdrawnReward)
+		merged.EarnedReward = addBigIntString(merged.EarnedReward, snapshot.EarnedReward)
+	}
+	return result
+}
+
+func buildRewardHistory(snapshots []*types.RewardSnapshot, timeFilter *types.TimeFilter) *types.RewardHistory {
+	history := &types.RewardHistory{
+		FromTime:  timeFilter.FromTime,
+		ToTime:    timeFilter.ToTime,
+		Snapshots: snapshots,
+	}
+	var (
+		totalEarned = big.NewInt(0)
+		totalStaked = big.NewInt(0)
+	)
+	for i, snapshot := range snapshots {
+		// earned of first snapshot was made before the range, so it's left out of the earned over duration
+		if earned, ok := new(big.Int).SetString(snapshot.EarnedReward, 10); ok && i > 0 {
+			totalEarned = new(big.Int).Add(totalEarned, earned)
+		}
+		if staked, ok := new(big.Int).SetString(snapshot.StakedAmount, 10); ok {
+			totalStaked = new(big.Int).Add(totalStaked, staked)
+		}
+	}
+	averageStaked := big.NewInt(0)
+	if len(snapshots) > 0 {
+		averageStaked = new(big.Int).Div(totalStaked, big.NewInt(int64(len(snapshots))))
+	}
+	var duration time.Duration
+	if len(snapshots) > 1 {
+		duration = snapshots[len(snapshots)-1].Time.Sub(snapshots[0].Time)
+	}
+	history.TotalEarned = totalEarned.String()
+	history.AverageStaked = averageStaked.String()
+	history.RealizedAPR = calculateRealizedAPR(totalEarned, averageStaked, duration)
+	return history
+}
+
+// calculateRealizedAPR return annualized percentage of earned over staked in duration
+func calculateRealizedAPR(earned, staked *big.Int, duration time.Duration) string {
+	if staked.Sign() <= 0 || duration <= 0 {
+		return "0"
+	}
+	apr := new(big.Float).Quo(new(big.Float).SetInt(earned), new(big.Float).SetInt(staked))
+	apr.Mul(apr, big.NewFloat(float64(year)/float64(duration)*100))
+	return apr.Text('f', 2)
+}
+
+func addBigIntString(a, b string) string {
+	x, ok := new(big.Int).SetString(a, 10)
+	if !ok {
+		x = big.NewInt(0)
+	}
+	y, ok := new(big.Int).SetString(b, 10)
+	if !ok {
+		return x.String()
+	}
+	return x.Add(x, y).String()
+}
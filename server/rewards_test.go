@@ -0,0 +1,32 @@
+// Package server
+package server
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_calculateRealizedAPR(t *testing.T) {
+	// earned 1 over 100 staked in 1/12 year
+	assert.Equal(t, "12.00", calculateRealizedAPR(big.NewInt(1), big.NewInt(100), year/12))
+	assert.Equal(t, "0", calculateRealizedAPR(big.NewInt(1), big.NewInt(0), year))
+	assert.Equal(t, "0", calculateRealizedAPR(big.NewInt(1), big.NewInt(100), 0))
+}
+
+func Test_buildRewardHistory(t *testing.T) {
+	now := time.Now()
+	snapshots := []*types.RewardSnapshot{
+		{StakedAmount: "100", EarnedReward: "50", Time: now.Add(-year / 12)},
+		{StakedAmount: "100", EarnedReward: "1", Time: now},
+	}
+	// earned of first snapshot happened before the range
+	history := buildRewardHistory(snapshots, &types.TimeFilter{FromTime: now.Add(-year / 12), ToTime: now})
+	assert.Equal(t, "1", history.TotalEarned)
+	assert.Equal(t, "100", history.AverageStaked)
+	assert.Equal(t, "12.00", history.RealizedAPR)
+}
@@ -0,0 +1,270 @@
+// Package server
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kardiachain/go-kardia/rpc"
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/api"
+)
+
+const (
+	rpcMaxBatchSize    = 100
+	rpcMaxConcurrency  = 10 // batch items forwarded at the same time
+	rpcMaxBodySize     = 1 << 20
+	rpcRateLimitWindow = time.Minute
+	// immutable results (old blocks, receipts of indexed txs) are kept much longer
+	rpcImmutableTTL = 7 * 24 * time.Hour
+
+	rpcErrParse          = -32700
+	rpcErrInvalidRequest = -32600
+	rpcErrMethodNotFound = -32601
+	rpcErrServer         = -32000
+	rpcErrLimitExceeded  = -32005
+)
+
+type rpcRequest struct {
+	JSONRPC string            `json:"jsonrpc"`
+	ID      json.RawMessage   `json:"id,omitempty"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// rpcImmutableCheck tell if a non-null result of a call never changes, so it can be cached for long
+type rpcImmutableCheck func(s *Server, ctx context.Context, params []json.RawMessage) bool
+
+type rpcMethodPolicy struct {
+	ttl       time.Duration // cache TTL of mutable results, zero means no caching
+	rateLimit int64         // requests per client per window
+	trusted   bool          // forward to trusted node
+	immutable rpcImmutableCheck
+}
+
+var rpcAllowedMethods = map[string]*rpcMethodPolicy{
+	"kai_blockNumber":            {ttl: time.Second, rateLimit: 600},
+	"kai_getBlockByHash":         {rateLimit: 300, immutable: rpcAlwaysImmutable},
+	"kai_getBlockHeaderByHash":   {rateLimit: 300, immutable: rpcAlwaysImmutable},
+	"kai_getBlockByNumber":       {ttl: time.Second, rateLimit: 300, immutable: rpcIndexedBlockNumber},
+	"kai_getBlockHeaderByNumber": {ttl: time.Second, rateLimit: 300, immutable: rpcIndexedBlockNumber},
+	"kai_kardiaCall":             {ttl: time.Second, rateLimit: 300},
+	"kai_estimateGas":            {rateLimit: 120},
+	"kai_validator":              {ttl: 30 * time.Second, rateLimit: 60},
+	"kai_validators":             {ttl: 30 * time.Second, rateLimit: 60},
+	"tx_getTransaction":          {rateLimit: 300, immutable: rpcIndexedTx},
+	"tx_getTransactionReceipt":   {rateLimit: 300, immutable: rpcIndexedTx},
+	"tx_sendRawTransaction":      {rateLimit: 30, trusted: true},
+	"account_balance":            {ttl: time.Second, rateLimit: 300},
+	"account_nonce":              {rateLimit: 300},
+	"account_getCode":            {ttl: 5 * time.Second, rateLimit: 120},
+	"account_getStorageAt":       {ttl: time.Second, rateLimit: 120},
+}
+
+func rpcAlwaysImmutable(s *Server, ctx context.Context, params []json.RawMessage) bool {
+	return true
+}
+
+// rpcIndexedBlockNumber is true when requested block is a number which explorer already indexed
+func rpcIndexedBlockNumber(s *Server, ctx context.Context, params []json.RawMessage) bool {
+	if len(params) == 0 {
+		return false
+	}
+	height, ok := parseRPCBlockNumber(params[0])
+	return ok && height <= s.cacheClient.LatestBlockHeight(ctx)
+}
+
+// rpcIndexedTx is true when tx is already imported to db, so it's mined and its receipt won't change
+func rpcIndexedTx(s *Server, ctx context.Context, params []json.RawMessage) bool {
+	if len(params) == 0 {
+		return false
+	}
+	var hash string
+	if err := json.Unmarshal(params[0], &hash); err != nil {
+		return false
+	}
+	tx, err := s.dbClient.TxByHash(ctx, hash)
+	return err == nil && tx != nil
+}
+
+// parseRPCBlockNumber parse block number param, which is a decimal number, quoted or not
+func parseRPCBlockNumber(param json.RawMessage) (uint64, bool) {
+	input := strings.Trim(strings.TrimSpace(string(param)), `"`)
+	height, err := strconv.ParseUint(input, 10, 64)
+	return height, err == nil
+}
+
+// parseRPCBody parse single or batch JSON-RPC request
+func parseRPCBody(body []byte) ([]*rpcRequest, bool, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var batch []*rpcRequest
+		if err := json.Unmarshal(body, &batch); err != nil {
+			return nil, true, err
+		}
+		if len(batch) == 0 {
+			return nil, true, errors.New("empty batch")
+		}
+		return batch, true, nil
+	}
+	var req *rpcRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, false, err
+	}
+	if req == nil {
+		return nil, false, errors.New("empty request")
+	}
+	return []*rpcRequest{req}, false, nil
+}
+
+func rpcCacheKey(method string, params []json.RawMessage) string {
+	data, _ := json.Marshal(params)
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, data); err == nil {
+		data = compacted.Bytes()
+	}
+	sum := sha256.Sum256(append([]byte(method+":"), data...))
+	return method + "#" + hex.EncodeToString(sum[:])
+}
+
+// RPC proxy allowlisted JSON-RPC methods to node pool, with response caching and per method
+// rate limits for each client
+func (s *Server) RPC(c echo.Context) error {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(c.Response(), c.Request().Body, rpcMaxBodySize))
+	if err != nil {
+		return c.JSON(http.StatusOK, newRPCErrorResponse(nil, rpcErrParse, "cannot read request body"))
+	}
+	requests, isBatch, err := parseRPCBody(body)
+	if err != nil {
+		return c.JSON(http.StatusOK, newRPCErrorResponse(nil, rpcErrParse, "parse error"))
+	}
+	if len(requests) > rpcMaxBatchSize {
+		return c.JSON(http.StatusOK, newRPCErrorResponse(nil, rpcErrInvalidRequest, "batch is too large"))
+	}
+
+	ctx := context.Background()
+	clientIP := api.ClientIP(c)
+	responses := make([]*rpcResponse, len(requests))
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, rpcMaxConcurrency)
+	)
+	for i := range requests {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			responses[i] = s.handleRPCRequest(ctx, clientIP, requests[i])
+		}(i)
+	}
+	wg.Wait()
+
+	// notifications are forwarded but never answered, a request of only notifications gets no content
+	var replies []*rpcResponse
+	for i, req := range requests {
+		if !isRPCNotification(req) {
+			replies = append(replies, responses[i])
+		}
+	}
+	if len(replies) == 0 {
+		return c.NoContent(http.StatusNoContent)
+	}
+	if !isBatch {
+		return c.JSON(http.StatusOK, replies[0])
+	}
+	return c.JSON(http.StatusOK, replies)
+}
+
+// isRPCNotification is true for a valid request without id, `"id": null` is still a call
+func isRPCNotification(req *rpcRequest) bool {
+	return req != nil && req.Method != "" && len(req.ID) == 0
+}
+
+func (s *Server) handleRPCRequest(ctx context.Context, clientIP string, req *rpcRequest) *rpcResponse {
+	if req == nil || req.Method == "" {
+		return newRPCErrorResponse(nil, rpcErrInvalidRequest, "invalid request")
+	}
+	policy, ok := rpcAllowedMethods[req.Method]
+	if !ok {
+		return newRPCErrorResponse(req.ID, rpcErrMethodNotFound, "the method "+req.Method+" does not exist/is not available")
+	}
+	if count, err := s.cacheClient.IncrRPCRequests(ctx, req.Method+"#"+clientIP, rpcRateLimitWindow); err != nil {
+		s.logger.Warn("Cannot count rpc requests", zap.Error(err))
+	} else if count > policy.rateLimit {
+		return newRPCErrorResponse(req.ID, rpcErrLimitExceeded, "rate limit exceeded for "+req.Method)
+	}
+
+	cacheable := policy.ttl > 0 || policy.immutable != nil
+	key := rpcCacheKey(req.Method, req.Params)
+	if cacheable {
+		if result, err := s.cacheClient.RPCResponse(ctx, key); err == nil {
+			return newRPCResponse(req.ID, result)
+		}
+	}
+	result, err := s.kaiClient.Forward(ctx, req.Method, req.Params, policy.trusted)
+	if err != nil {
+		code := rpcErrServer
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			code = rpcErr.ErrorCode()
+		}
+		return newRPCErrorResponse(req.ID, code, err.Error())
+	}
+
+	ttl := policy.ttl
+	isNull := len(result) == 0 || string(result) == "null"
+	if !isNull && policy.immutable != nil && policy.immutable(s, ctx, req.Params) {
+		ttl = rpcImmutableTTL
+	}
+	if ttl > 0 && !isNull {
+		if err := s.cacheClient.UpdateRPCResponse(ctx, key, result, ttl); err != nil {
+			s.logger.Warn("Cannot cache rpc response", zap.String("method", req.Method), zap.Error(err))
+		}
+	}
+	return newRPCResponse(req.ID, result)
+}
+
+func newRPCResponse(id json.RawMessage, result json.RawMessage) *rpcResponse {
+	if len(result) == 0 {
+		result = json.RawMessage("null")
+	}
+	return &rpcResponse{JSONRPC: "2.0", ID: rpcID(id), Result: result}
+}
+
+func newRPCErrorResponse(id json.RawMessage, code int, msg string) *rpcResponse {
+	return &rpcResponse{JSONRPC: "2.0", ID: rpcID(id), Error: &rpcError{Code: code, Message: msg}}
+}
+
+func rpcID(id json.RawMessage) json.RawMessage {
+	if len(id) == 0 {
+		return json.RawMessage("null")
+	}
+	return id
+}
@@ -0,0 +1,61 @@
+// Package server
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseRPCBlockNumber(t *testing.T) {
+	height, ok := parseRPCBlockNumber(json.RawMessage(`100`))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(100), height)
+
+	height, ok = parseRPCBlockNumber(json.RawMessage(`"42"`))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(42), height)
+
+	_, ok = parseRPCBlockNumber(json.RawMessage(`"latest"`))
+	assert.False(t, ok)
+	_, ok = parseRPCBlockNumber(json.RawMessage(`"pending"`))
+	assert.False(t, ok)
+}
+
+func Test_parseRPCBody(t *testing.T) {
+	requests, isBatch, err := parseRPCBody([]byte(`{"jsonrpc":"2.0","id":1,"method":"kai_blockNumber","params":[]}`))
+	assert.Nil(t, err)
+	assert.False(t, isBatch)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, "kai_blockNumber", requests[0].Method)
+
+	requests, isBatch, err = parseRPCBody([]byte(` [{"id":1,"method":"kai_blockNumber"},{"id":2,"method":"account_balance","params":["0x1","latest"]}]`))
+	assert.Nil(t, err)
+	assert.True(t, isBatch)
+	assert.Len(t, requests, 2)
+	assert.Len(t, requests[1].Params, 2)
+
+	_, _, err = parseRPCBody([]byte(`[]`))
+	assert.NotNil(t, err)
+	_, _, err = parseRPCBody([]byte(`{"id":1,`))
+	assert.NotNil(t, err)
+}
+
+func Test_rpcCacheKey(t *testing.T) {
+	a := rpcCacheKey("kai_getBlockByNumber", []json.RawMessage{json.RawMessage(`1`), json.RawMessage(`true`)})
+	b := rpcCacheKey("kai_getBlockByNumber", []json.RawMessage{json.RawMessage(` 1`), json.RawMessage(`true `)})
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, rpcCacheKey("kai_getBlockHeaderByNumber", []json.RawMessage{json.RawMessage(`1`), json.RawMessage(`true`)}))
+}
+
+func Test_isRPCNotification(t *testing.T) {
+	requests, _, err := parseRPCBody([]byte(`[{"method":"kai_blockNumber"},{"id":null,"method":"kai_blockNumber"},{"id":1,"method":"kai_blockNumber"},{"id":2}]`))
+	assert.Nil(t, err)
+	assert.True(t, isRPCNotification(requests[0]))
+	assert.False(t, isRPCNotification(requests[1]))
+	assert.False(t, isRPCNotification(requests[2]))
+	// invalid requests are answered with an error
+	assert.False(t, isRPCNotification(requests[3]))
+	assert.False(t, isRPCNotification(nil))
+}
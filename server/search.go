@@ -0,0 +1,446 @@
+// Package server
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+
+	"github.com/kardiachain/kardia-explorer-backend/api"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const (
+	searchResultBlock     = "block"
+	searchResultTx        = "tx"
+	searchResultAddress   = "address"
+	searchResultContract  = "contract"
+	searchResultToken     = "token"
+	searchResultValidator = "validator"
+
+	searchLimit = 20
+
+	migrationSearchNames = "searchNames"
+)
+
+type searchKind int
+
+const (
+	searchKindText searchKind = iota
+	searchKindHeight
+	searchKindHash // block or tx hash
+	searchKindAddress
+)
+
+// SearchResult is a match of search text, Redirect is the explorer page of the result
+type SearchResult struct {
+	Type     string `json:"type"`
+	Name     string `json:"name,omitempty"`
+	Symbol   string `json:"symbol,omitempty"`
+	Address  string `json:"address,omitempty"`
+	Hash     string `json:"hash,omitempty"`
+	Height   uint64 `json:"height,omitempty"`
+	Logo     string `json:"logo,omitempty"`
+	Match    string `json:"match,omitempty"` // matched field of name searches: name, tokenName, symbol
+	Redirect string `json:"redirect"`
+
+	score int
+}
+
+// classifySearch return kind of search text and its normalized form, hashes are lower case and addresses are checksummed
+func classifySearch(text string) (searchKind, string) {
+	text = strings.TrimSpace(text)
+	if height, err := strconv.ParseUint(text, 10, 64); err == nil {
+		return searchKindHeight, strconv.FormatUint(height, 10)
+	}
+	hex := strings.TrimPrefix(strings.TrimPrefix(text, "0x"), "0X")
+	if isHex(hex) {
+		switch len(hex) {
+		case 64:
+			return searchKindHash, "0x" + strings.ToLower(hex)
+		case 40:
+			return searchKindAddress, common.HexToAddress(hex).String()
+		}
+	}
+	return searchKindText, text
+}
+
+func isHex(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// matchScore rank how well value matches text: exact, prefix, word prefix then substring
+func matchScore(value, text string) int {
+	value, text = strings.ToLower(strings.TrimSpace(value)), strings.ToLower(text)
+	if value == "" || text == "" {
+		return 0
+	}
+	switch {
+	case value == text:
+		return 100
+	case strings.HasPrefix(value, text):
+		return 60
+	case strings.Contains(value, " "+text) || strings.Contains(value, "-"+text) || strings.Contains(value, "_"+text):
+		return 40
+	case strings.Contains(value, text):
+		return 20
+	}
+	return 0
+}
+
+// searchTypeOrder break ties of name matches, tokens are what people look for most
+var searchTypeOrder = map[string]int{
+	searchResultToken:     0,
+	searchResultValidator: 1,
+	searchResultContract:  2,
+	searchResultAddress:   3,
+}
+
+// rankSearchResults merge results of the same address, keeping the best match, and sort them by score
+func rankSearchResults(results []*SearchResult, limit int) []*SearchResult {
+	merged := make(map[string]*SearchResult)
+	ranked := make([]*SearchResult, 0, len(results))
+	for _, r := range results {
+		if r.score == 0 {
+			continue
+		}
+		prev, ok := merged[r.Address]
+		if !ok {
+			merged[r.Address] = r
+			ranked = append(ranked, r)
+			continue
+		}
+		if prev.Logo == "" {
+			prev.Logo = r.Logo
+		}
+		if prev.Symbol == "" {
+			prev.Symbol = r.Symbol
+		}
+		if r.score > prev.score || r.score == prev.score && searchTypeOrder[r.Type] < searchTypeOrder[prev.Type] {
+			prev.Type, prev.Name, prev.Match, prev.Redirect, prev.score = r.Type, r.Name, r.Match, r.Redirect, r.score
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		if searchTypeOrder[ranked[i].Type] != searchTypeOrder[ranked[j].Type] {
+			return searchTypeOrder[ranked[i].Type] < searchTypeOrder[ranked[j].Type]
+		}
+		return strings.ToLower(ranked[i].Name) < strings.ToLower(ranked[j].Name)
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+// addressResultType tell tokens and contracts apart from plain addresses
+func addressResultType(addr *types.Address) string {
+	switch {
+	case strings.HasPrefix(addr.KrcTypes, "KRC") || addr.TokenSymbol != "":
+		return searchResultToken
+	case addr.IsContract || addr.KrcTypes != "":
+		return searchResultContract
+	}
+	return searchResultAddress
+}
+
+func addressRedirect(typ, address string) string {
+	switch typ {
+	case searchResultToken:
+		return "/token/" + address
+	case searchResultValidator:
+		return "/validator/" + address
+	}
+	return "/address/" + address
+}
+
+// Search classify `q` as block height, block or tx hash, address or name, and return typed results.
+// Old clients which send `name` get the old response
+func (s *Server) Search(c echo.Context) error {
+	ctx := context.Background()
+	text := c.QueryParam("q")
+	if text == "" && c.QueryParam("name") != "" {
+		return s.searchLegacyName(c, c.QueryParam("name"))
+	}
+	if strings.TrimSpace(text) == "" {
+		return api.Invalid.Build(c)
+	}
+	var results []*SearchResult
+	kind, normalized := classifySearch(text)
+	switch kind {
+	case searchKindHeight:
+		results = s.searchBlockByHeight(ctx, normalized)
+		if len(results) == 0 {
+			results = s.searchByName(ctx, normalized)
+		}
+	case searchKindHash:
+		results = s.searchHash(ctx, normalized)
+	case searchKindAddress:
+		results = s.searchAddress(ctx, normalized)
+	default:
+		results = s.searchByName(ctx, normalized)
+	}
+	if results == nil {
+		results = []*SearchResult{}
+	}
+	return api.OK.SetData(results).Build(c)
+}
+
+// searchLegacyName answer `?name=` as before typed search, with addresses and contracts whose name contains
+// name, and Invalid if there is none
+func (s *Server) searchLegacyName(c echo.Context, name string) error {
+	ctx := context.Background()
+	addrMap := make(map[string]*SimpleKRCTokenInfo)
+	addresses, err := s.dbClient.AddressByName(ctx, name)
+	if err != nil {
+		s.logger.Warn("Cannot search addresses by name", zap.String("name", name), zap.Error(err))
+	}
+	for _, addr := range addresses {
+		addrMap[addr.Address] = &SimpleKRCTokenInfo{
+			Name:        addr.Name,
+			Address:     addr.Address,
+			Info:        addr.Info,
+			Type:        "Address",
+			TokenSymbol: addr.TokenSymbol,
+		}
+	}
+	contracts, err := s.dbClient.ContractByName(ctx, name)
+	if err != nil {
+		s.logger.Warn("Cannot search contracts by name", zap.String("name", name), zap.Error(err))
+	}
+	for _, smc := range contracts {
+		if smc.Type == "" {
+			smc.Type = "SMC"
+		}
+		if prev, ok := addrMap[smc.Address]; ok {
+			prev.Type, prev.Name, prev.Logo = smc.Type, smc.Name, smc.Logo
+			continue
+		}
+		addrMap[smc.Address] = &SimpleKRCTokenInfo{
+			Name:    smc.Name,
+			Address: smc.Address,
+			Info:    smc.Info,
+			Logo:    smc.Logo,
+			Type:    smc.Type,
+		}
+	}
+	if len(addrMap) == 0 {
+		return api.Invalid.Build(c)
+	}
+	result := make([]*SimpleKRCTokenInfo, 0, len(addrMap))
+	for _, addr := range addrMap {
+		result = append(result, addr)
+	}
+	return api.OK.SetData(result).Build(c)
+}
+
+func (s *Server) searchBlockByHeight(ctx context.Context, text string) []*SearchResult {
+	height, _ := strconv.ParseUint(text, 10, 64)
+	block, err := s.cacheClient.BlockByHeight(ctx, height)
+	if err != nil || block == nil {
+		if block, err = s.dbClient.BlockByHeight(ctx, height); err != nil || block == nil {
+			return nil
+		}
+	}
+	return []*SearchResult{blockResult(block)}
+}
+
+// searchHash try block hash first since they are fewer, a hash can't be both
+func (s *Server) searchHash(ctx context.Context, hash string) []*SearchResult {
+	block, err := s.cacheClient.BlockByHash(ctx, hash)
+	if err != nil || block == nil {
+		block, err = s.dbClient.BlockByHash(ctx, hash)
+	}
+	if err == nil && block != nil {
+		return []*SearchResult{blockResult(block)}
+	}
+	tx, err := s.dbClient.TxByHash(ctx, hash)
+	if err != nil || tx == nil {
+		return nil
+	}
+	return []*SearchResult{{
+		Type:     searchResultTx,
+		Hash:     tx.Hash,
+		Height:   tx.BlockNumber,
+		Redirect: "/tx/" + tx.Hash,
+	}}
+}
+
+func blockResult(block *types.Block) *SearchResult {
+	return &SearchResult{
+		Type:     searchResultBlock,
+		Hash:     block.Hash,
+		Height:   block.Height,
+		Redirect: fmt.Sprintf("/block/%d", block.Height),
+	}
+}
+
+// searchAddress always return the address, any valid address has a page even if it never appeared on chain
+func (s *Server) searchAddress(ctx context.Context, address string) []*SearchResult {
+	result := &SearchResult{Type: searchResultAddress, Address: address}
+	addr, err := s.cacheClient.AddressInfo(ctx, address)
+	if err != nil || addr == nil {
+		addr, err = s.dbClient.AddressByHash(ctx, address)
+	}
+	if err == nil && addr != nil {
+		result.Type = addressResultType(addr)
+		result.Name, result.Symbol, result.Logo = addr.Name, addr.TokenSymbol, addr.Logo
+		if result.Type == searchResultToken && addr.TokenName != "" {
+			result.Name = addr.TokenName
+		}
+	}
+	for _, v := range s.cachedValidators(ctx) {
+		if v.SmcAddress == address || v.Address == address {
+			result.Type, result.Name, result.Address = searchResultValidator, v.Name, v.SmcAddress
+			break
+		}
+	}
+	result.Redirect = addressRedirect(result.Type, result.Address)
+	return []*SearchResult{result}
+}
+
+// searchByName rank matches of address labels, token names and symbols, contract names and validator names.
+// Autocomplete index answers prefix matches, db is only searched when index has none, e.g. for names which contain
+// text in the middle or while index is down
+func (s *Server) searchByName(ctx context.Context, text string) []*SearchResult {
+	entries, err := s.cacheClient.Autocomplete(ctx, text, searchLimit)
+	if err != nil {
+		s.logger.Warn("Cannot search autocomplete index", zap.String("text", text), zap.Error(err))
+	}
+	results := autocompleteSearchResults(entries, text)
+	if len(results) == 0 {
+		results = s.searchByNameInDB(ctx, text)
+	}
+	return rankSearchResults(results, searchLimit)
+}
+
+// autocompleteSearchResults score entries by their best matching name, entries matched by normalized names only,
+// e.g. "kai swap" of "Kai-Swap", are scored as substring matches
+func autocompleteSearchResults(entries []*types.AutocompleteEntry, text string) []*SearchResult {
+	results := make([]*SearchResult, 0, len(entries))
+	for _, e := range entries {
+		name := e.Name
+		if name == "" {
+			name = e.Label
+		}
+		result := &SearchResult{
+			Type:     e.Type,
+			Name:     name,
+			Symbol:   e.Symbol,
+			Address:  e.Address,
+			Logo:     e.Logo,
+			Match:    "name",
+			Redirect: addressRedirect(e.Type, e.Address),
+			score:    20,
+		}
+		nameField := "name"
+		if e.Type == searchResultToken {
+			nameField = "tokenName"
+		}
+		for _, field := range []struct{ match, value string }{
+			{"name", e.Label},
+			{nameField, e.Name},
+			{"symbol", e.Symbol},
+		} {
+			if score := matchScore(field.value, text); score > result.score {
+				result.Match, result.score = field.match, score
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// MigrateSearchNames index names of addresses and contracts which were written before name search was indexed,
+// it's recorded as done so later starts skip scanning for them
+func (s *Server) MigrateSearchNames(ctx context.Context) error {
+	done, err := s.dbClient.MigrationDone(ctx, migrationSearchNames)
+	if err != nil || done {
+		return err
+	}
+	updated, err := s.dbClient.BackfillSearchNames(ctx)
+	if err != nil {
+		return err
+	}
+	s.logger.Info("Migrated search names", zap.Int("updated", updated))
+	return s.dbClient.MarkMigrationDone(ctx, migrationSearchNames)
+}
+
+// searchByNameInDB return unranked matches of text anywhere in names
+func (s *Server) searchByNameInDB(ctx context.Context, text string) []*SearchResult {
+	var results []*SearchResult
+	addrs, err := s.dbClient.SearchAddresses(ctx, text, searchLimit)
+	if err != nil {
+		s.logger.Warn("Cannot search addresses", zap.String("text", text), zap.Error(err))
+	}
+	for _, addr := range addrs {
+		typ := addressResultType(addr)
+		for _, field := range []struct{ match, value string }{
+			{"name", addr.Name},
+			{"tokenName", addr.TokenName},
+			{"symbol", addr.TokenSymbol},
+		} {
+			name := addr.Name
+			if field.match != "name" && addr.TokenName != "" {
+				name = addr.TokenName
+			}
+			results = append(results, &SearchResult{
+				Type:     typ,
+				Name:     name,
+				Symbol:   addr.TokenSymbol,
+				Address:  addr.Address,
+				Logo:     addr.Logo,
+				Match:    field.match,
+				Redirect: addressRedirect(typ, addr.Address),
+				score:    matchScore(field.value, text),
+			})
+		}
+	}
+	contracts, err := s.dbClient.SearchContracts(ctx, text, searchLimit)
+	if err != nil {
+		s.logger.Warn("Cannot search contracts", zap.String("text", text), zap.Error(err))
+	}
+	for _, smc := range contracts {
+		typ := searchResultContract
+		if strings.HasPrefix(smc.Type, "KRC") {
+			typ = searchResultToken
+		}
+		results = append(results, &SearchResult{
+			Type:     typ,
+			Name:     smc.Name,
+			Address:  smc.Address,
+			Logo:     smc.Logo,
+			Match:    "name",
+			Redirect: addressRedirect(typ, smc.Address),
+			score:    matchScore(smc.Name, text),
+		})
+	}
+	for _, v := range s.cachedValidators(ctx) {
+		results = append(results, &SearchResult{
+			Type:     searchResultValidator,
+			Name:     v.Name,
+			Address:  v.SmcAddress,
+			Match:    "name",
+			Redirect: addressRedirect(searchResultValidator, v.SmcAddress),
+			score:    matchScore(v.Name, text),
+		})
+	}
+	return results
+}
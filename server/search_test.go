@@ -0,0 +1,56 @@
+// Package server
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_classifySearch(t *testing.T) {
+	kind, text := classifySearch(" 1024 ")
+	assert.Equal(t, searchKindHeight, kind)
+	assert.Equal(t, "1024", text)
+
+	kind, text = classifySearch("0xAB3a7d2be1f8f8dbe4b1d93d40e6fd2b3e1c96cd0e5bc1ae3d17f14b36d5e1f0")
+	assert.Equal(t, searchKindHash, kind)
+	assert.Equal(t, "0xab3a7d2be1f8f8dbe4b1d93d40e6fd2b3e1c96cd0e5bc1ae3d17f14b36d5e1f0", text)
+
+	kind, text = classifySearch("c1fe56e3f58d3244f606306611a5d10c8333f1f6")
+	assert.Equal(t, searchKindAddress, kind)
+	assert.Equal(t, "0xc1fe56E3F58D3244F606306611a5d10c8333f1f6", text)
+
+	kind, _ = classifySearch("0xc1fe56")
+	assert.Equal(t, searchKindText, kind)
+	kind, text = classifySearch("KAI Swap")
+	assert.Equal(t, searchKindText, kind)
+	assert.Equal(t, "KAI Swap", text)
+}
+
+func Test_matchScore(t *testing.T) {
+	assert.Equal(t, 100, matchScore("KAI", "kai"))
+	assert.Equal(t, 60, matchScore("KaiSwap", "kai"))
+	assert.Equal(t, 40, matchScore("Wrapped KAI", "kai"))
+	assert.Equal(t, 20, matchScore("BeKAI", "kai"))
+	assert.Equal(t, 0, matchScore("Tether", "kai"))
+	assert.Equal(t, 0, matchScore("", "kai"))
+}
+
+func Test_rankSearchResults(t *testing.T) {
+	results := rankSearchResults([]*SearchResult{
+		{Type: searchResultAddress, Name: "Becoin holder", Address: "0x1", score: 40},
+		{Type: searchResultContract, Name: "Becoin", Address: "0x2", score: 100},
+		{Type: searchResultToken, Name: "Becoin", Symbol: "BEC", Address: "0x2", Logo: "logo", score: 100},
+		{Type: searchResultValidator, Name: "Becoin node", Address: "0x3", score: 60},
+		{Type: searchResultAddress, Name: "Other", Address: "0x4", score: 0},
+	}, 10)
+	assert.Len(t, results, 3)
+	assert.Equal(t, "0x2", results[0].Address)
+	assert.Equal(t, searchResultToken, results[0].Type)
+	assert.Equal(t, "logo", results[0].Logo)
+	assert.Equal(t, "BEC", results[0].Symbol)
+	assert.Equal(t, "0x3", results[1].Address)
+	assert.Equal(t, "0x1", results[2].Address)
+
+	assert.Len(t, rankSearchResults(results, 1), 1)
+}
@@ -19,12 +19,15 @@
 package server
 
 import (
+	"context"
+
 	"go.uber.org/zap"
 
 	"github.com/kardiachain/kardia-explorer-backend/cache"
 	"github.com/kardiachain/kardia-explorer-backend/db"
 	"github.com/kardiachain/kardia-explorer-backend/kardia"
 	"github.com/kardiachain/kardia-explorer-backend/metrics"
+	"github.com/kardiachain/kardia-explorer-backend/price"
 	"github.com/kardiachain/kardia-explorer-backend/types"
 )
 
@@ -46,10 +49,21 @@ type Config struct {
 
 	BlockBuffer int64
 
-	HttpRequestSecret string
+	PriceProviders   []string
+	CoinMarketAPIKey string
+	PriceStaticFile  string
+	PriceStubURL     string
 
 	VerifyBlockParam *types.VerifyBlockParam
 
+	ProductionWindowSize uint64
+
+	GraphQLMaxDepth      int
+	GraphQLMaxComplexity int
+
+	StreamMaxSubscriptions int
+	CORSAllowOrigins       []string
+
 	Metrics *metrics.Provider
 	Logger  *zap.Logger
 }
@@ -63,6 +77,13 @@ type Server struct {
 
 	VerifyBlockParam *types.VerifyBlockParam
 
+	graphQL *graphQLServer
+	stream  *streamHub
+	apiKeys *apiKeyCache
+
+	apiKeyUsage *apiKeyUsageBuffer
+	validators  validatorCache
+
 	infoServer
 }
 
@@ -103,21 +124,46 @@ func New(cfg Config) (*Server, error) {
 	if err != nil {
 		return nil, err
 	}
+	priceProvider, err := price.New(price.Config{
+		Providers:        cfg.PriceProviders,
+		CoinMarketAPIKey: cfg.CoinMarketAPIKey,
+		StaticFile:       cfg.PriceStaticFile,
+		StubURL:          cfg.PriceStubURL,
+		Logger:           cfg.Logger,
+	})
+	if err != nil {
+		return nil, err
+	}
 	avgMetrics := metrics.New()
 
+	productionWindow := cfg.ProductionWindowSize
+	if productionWindow == 0 {
+		productionWindow = defaultProductionWindow
+	}
+
 	infoServer := infoServer{
-		dbClient:          dbClient,
-		cacheClient:       cacheClient,
-		kaiClient:         kaiClient,
-		HttpRequestSecret: cfg.HttpRequestSecret,
-		verifyBlockParam:  cfg.VerifyBlockParam,
-		logger:            cfg.Logger,
-		metrics:           avgMetrics,
+		dbClient:         dbClient,
+		cacheClient:      cacheClient,
+		kaiClient:        kaiClient,
+		priceProvider:    priceProvider,
+		verifyBlockParam: cfg.VerifyBlockParam,
+		productionWindow: productionWindow,
+		logger:           cfg.Logger,
+		metrics:          avgMetrics,
 	}
 
-	return &Server{
+	srv := &Server{
 		Logger:     cfg.Logger,
 		metrics:    avgMetrics,
 		infoServer: infoServer,
-	}, nil
+		stream:     newStreamHub(cfg.StreamMaxSubscriptions, cfg.CORSAllowOrigins),
+		apiKeys:    newAPIKeyCache(),
+
+		apiKeyUsage: newAPIKeyUsageBuffer(),
+	}
+	if srv.graphQL, err = newGraphQLServer(srv, cfg.GraphQLMaxDepth, cfg.GraphQLMaxComplexity); err != nil {
+		return nil, err
+	}
+	go srv.flushAPIKeyUsage(context.Background())
+	return srv, nil
 }
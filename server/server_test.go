@@ -23,7 +23,6 @@ func createDevSrv() (*Server, error) {
 		CacheDB:            0,
 		CacheIsFlush:       true,
 		BlockBuffer:        20,
-		HttpRequestSecret:  "httpTestSecret",
 		VerifyBlockParam: &types.VerifyBlockParam{
 			VerifyTxCount:   false,
 			VerifyBlockHash: false,
@@ -50,7 +49,6 @@ func createTestSrv() (*Server, error) {
 		CacheDB:            0,
 		CacheIsFlush:       false,
 		BlockBuffer:        0,
-		HttpRequestSecret:  "",
 		VerifyBlockParam:   nil,
 		Metrics:            nil,
 		Logger:             nil,
@@ -74,7 +72,6 @@ func createProdSrv() (*Server, error) {
 		CacheDB:            0,
 		CacheIsFlush:       false,
 		BlockBuffer:        0,
-		HttpRequestSecret:  "",
 		VerifyBlockParam:   nil,
 		Metrics:            nil,
 		Logger:             nil,
@@ -0,0 +1,264 @@
+// Package server
+package server
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/api"
+	"github.com/kardiachain/kardia-explorer-backend/cfg"
+	"github.com/kardiachain/kardia-explorer-backend/db"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+	"github.com/kardiachain/kardia-explorer-backend/utils"
+)
+
+const (
+	// number of latest blocks used to estimate block rewards and block time
+	simulationRecentBlocks = 100
+	secondsPerDay          = 24 * 60 * 60
+	// validator status when its node is started
+	validatorStatusStarted = 2
+)
+
+// simulatedValidator keep staked amount as big.Int while simulating
+type simulatedValidator struct {
+	*types.SimulatedValidator
+	status uint8
+	jailed bool
+	staked *big.Int
+}
+
+// SimulateStaking apply hypothetical delegations to current validators and return resulting ranking,
+// proposers set, voting power and estimated daily rewards
+func (s *Server) SimulateStaking(c echo.Context) error {
+	ctx := context.Background()
+	var req *types.StakingSimulationRequest
+	if err := c.Bind(&req); err != nil || req == nil || len(req.Actions) == 0 {
+		return api.Invalid.Build(c)
+	}
+	validators, err := s.dbClient.Validators(ctx, db.ValidatorsFilter{})
+	if err != nil {
+		s.logger.Warn("Cannot get validators from db", zap.Error(err))
+		return api.InternalServer.Build(c)
+	}
+	maxProposers, err := s.kaiClient.GetMaxProposers(ctx)
+	if err != nil {
+		s.logger.Warn("Cannot get max proposers", zap.Error(err))
+		return api.InternalServer.Build(c)
+	}
+
+	// delegations of actions start from current stake of delegator if provided
+	delegations := make(map[string]*big.Int)
+	for _, action := range req.Actions {
+		smcAddr := common.HexToAddress(action.Validator).Hex()
+		if _, ok := delegations[smcAddr]; !ok {
+			delegations[smcAddr] = s.delegatorStakedAmount(ctx, smcAddr, req.Delegator)
+		}
+	}
+	simulation, simulated, err := simulateStaking(validators, maxProposers, req.Actions, delegations)
+	if err != nil {
+		s.logger.Debug("Invalid staking simulation", zap.Error(err))
+		return api.Invalid.Build(c)
+	}
+	rewardPerBlock, blocksPerDay := s.recentBlockRewards(ctx)
+	simulation.RewardPerBlock = rewardPerBlock.String()
+	simulation.BlocksPerDay = blocksPerDay
+	validatorRewards := estimateValidatorRewards(simulated, new(big.Int).Mul(rewardPerBlock, new(big.Int).SetUint64(blocksPerDay)))
+
+	for _, v := range simulated {
+		delegation, ok := delegations[v.SmcAddress]
+		if !ok {
+			continue
+		}
+		simulation.Delegations = append(simulation.Delegations, &types.SimulatedDelegation{
+			ValidatorSMCAddress: v.SmcAddress,
+			StakedAmount:        delegation.String(),
+			EstimatedReward:     estimateDelegatorReward(validatorRewards[v.SmcAddress], v.staked, delegation, v.CommissionRate).String(),
+		})
+	}
+	return api.OK.SetData(simulation).Build(c)
+}
+
+// simulateStaking apply actions to validators and to delegations of delegator by validator SMC address, which start
+// from current stakes of delegator. A delegator can't undelegate more than it has staked
+func simulateStaking(validators []*types.Validator, maxProposers int64, actions []*types.SimulationAction,
+	delegations map[string]*big.Int) (*types.StakingSimulation, []*simulatedValidator, error) {
+	var (
+		simulated   []*simulatedValidator
+		bySMC       = make(map[string]*simulatedValidator)
+		totalStaked = big.NewInt(0)
+	)
+	for _, v := range validators {
+		staked, ok := new(big.Int).SetString(v.StakedAmount, 10)
+		if !ok {
+			staked = big.NewInt(0)
+		}
+		sv := &simulatedValidator{
+			SimulatedValidator: &types.SimulatedValidator{
+				Address:        v.Address,
+				SmcAddress:     v.SmcAddress,
+				Name:           v.Name,
+				CommissionRate: v.CommissionRate,
+				PreviousRole:   v.Role,
+			},
+			status: v.Status,
+			jailed: v.Jailed,
+			staked: staked,
+		}
+		simulated = append(simulated, sv)
+		bySMC[v.SmcAddress] = sv
+	}
+
+	for _, action := range actions {
+		sv, ok := bySMC[common.HexToAddress(action.Validator).Hex()]
+		if !ok {
+			return nil, nil, fmt.Errorf("validator %s not found", action.Validator)
+		}
+		amount, ok := new(big.Int).SetString(action.Amount, 10)
+		if !ok || amount.Sign() <= 0 {
+			return nil, nil, fmt.Errorf("invalid amount %s", action.Amount)
+		}
+		delegation, ok := delegations[sv.SmcAddress]
+		if !ok {
+			delegation = big.NewInt(0)
+			delegations[sv.SmcAddress] = delegation
+		}
+		switch action.Type {
+		case types.SimulateDelegate:
+			sv.staked.Add(sv.staked, amount)
+			delegation.Add(delegation, amount)
+		case types.SimulateUndelegate:
+			if delegation.Cmp(amount) < 0 {
+				return nil, nil, fmt.Errorf("undelegate amount exceeds delegator's stake in %s", action.Validator)
+			}
+			sv.staked.Sub(sv.staked, amount)
+			delegation.Sub(delegation, amount)
+		default:
+			return nil, nil, fmt.Errorf("invalid action type %s", action.Type)
+		}
+	}
+
+	sort.SliceStable(simulated, func(i, j int) bool {
+		return simulated[i].staked.Cmp(simulated[j].staked) > 0
+	})
+	for _, sv := range simulated {
+		totalStaked.Add(totalStaked, sv.staked)
+	}
+
+	simulation := &types.StakingSimulation{
+		MaxProposers:      maxProposers,
+		TotalStakedAmount: totalStaked.String(),
+	}
+	for i, sv := range simulated {
+		sv.Rank = i + 1
+		sv.StakedAmount = sv.staked.String()
+		sv.VotingPowerPercentage = "0"
+		if totalStaked.Sign() > 0 {
+			sv.VotingPowerPercentage = calculateVotingPower(sv.staked, totalStaked)
+		}
+		switch {
+		case sv.status == validatorStatusStarted && !sv.jailed && int64(len(simulation.Proposers)) < maxProposers:
+			sv.Role = cfg.RoleProposer
+			simulation.Proposers = append(simulation.Proposers, sv.Address)
+		case sv.status == validatorStatusStarted:
+			sv.Role = cfg.RoleValidator
+		default:
+			sv.Role = cfg.RoleCandidate
+		}
+		simulation.Validators = append(simulation.Validators, sv.SimulatedValidator)
+	}
+	return simulation, simulated, nil
+}
+
+// estimateValidatorRewards split rewards among proposers by their staked amount
+func estimateValidatorRewards(simulated []*simulatedValidator, rewards *big.Int) map[string]*big.Int {
+	proposersStaked := big.NewInt(0)
+	for _, sv := range simulated {
+		if sv.Role == cfg.RoleProposer {
+			proposersStaked.Add(proposersStaked, sv.staked)
+		}
+	}
+	validatorRewards := make(map[string]*big.Int)
+	for _, sv := range simulated {
+		reward := big.NewInt(0)
+		if sv.Role == cfg.RoleProposer && proposersStaked.Sign() > 0 {
+			reward.Mul(rewards, sv.staked)
+			reward.Div(reward, proposersStaked)
+		}
+		validatorRewards[sv.SmcAddress] = reward
+		sv.EstimatedReward = reward.String()
+	}
+	return validatorRewards
+}
+
+// estimateDelegatorReward return share of delegation in validator reward after commission, which is a percentage
+func estimateDelegatorReward(validatorReward, validatorStaked, delegation *big.Int, commissionRate string) *big.Int {
+	if validatorReward == nil || validatorStaked.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	commission, err := strconv.ParseFloat(commissionRate, 64)
+	if err != nil || commission > 100 {
+		commission = 0
+	}
+	// commission in basis points to stay in integer math
+	reward := new(big.Int).Mul(validatorReward, big.NewInt(10000-int64(commission*100)))
+	reward.Div(reward, big.NewInt(10000))
+	reward.Mul(reward, delegation)
+	return reward.Div(reward, validatorStaked)
+}
+
+func calculateVotingPower(staked, total *big.Int) string {
+	votingPower, err := utils.CalculateVotingPower(staked.String(), total)
+	if err != nil {
+		return "0"
+	}
+	return votingPower
+}
+
+// recentBlockRewards return average rewards per block and number of blocks per day from latest blocks
+func (s *Server) recentBlockRewards(ctx context.Context) (*big.Int, uint64) {
+	rewardPerBlock := big.NewInt(0)
+	blocks, err := s.dbClient.Blocks(ctx, &types.Pagination{Skip: 0, Limit: simulationRecentBlocks})
+	if err != nil || len(blocks) < 2 {
+		return rewardPerBlock, 0
+	}
+	for _, b := range blocks {
+		reward, ok := new(big.Int).SetString(b.Rewards, 10)
+		if ok {
+			rewardPerBlock.Add(rewardPerBlock, reward)
+		}
+	}
+	rewardPerBlock.Div(rewardPerBlock, big.NewInt(int64(len(blocks))))
+
+	duration := blocks[0].Time.Sub(blocks[len(blocks)-1].Time).Seconds()
+	if duration <= 0 {
+		return rewardPerBlock, 0
+	}
+	return rewardPerBlock, uint64(float64(secondsPerDay*(len(blocks)-1)) / duration)
+}
+
+func (s *Server) delegatorStakedAmount(ctx context.Context, validatorSMCAddress, delegatorAddress string) *big.Int {
+	staked := big.NewInt(0)
+	if delegatorAddress == "" {
+		return staked
+	}
+	delegators, err := s.dbClient.Delegators(ctx, db.DelegatorFilter{ValidatorSMCAddress: validatorSMCAddress})
+	if err != nil {
+		return staked
+	}
+	delegatorAddress = common.HexToAddress(delegatorAddress).Hex()
+	for _, d := range delegators {
+		if d.Address == delegatorAddress {
+			staked.SetString(d.StakedAmount, 10)
+			break
+		}
+	}
+	return staked
+}
@@ -0,0 +1,51 @@
+// Package server
+package server
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/cfg"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_simulateStaking(t *testing.T) {
+	validators := []*types.Validator{
+		{Address: "0x01", SmcAddress: "0x0000000000000000000000000000000000000001", StakedAmount: "300", Status: 2, Role: cfg.RoleProposer},
+		{Address: "0x02", SmcAddress: "0x0000000000000000000000000000000000000002", StakedAmount: "200", Status: 2, Role: cfg.RoleProposer},
+		{Address: "0x03", SmcAddress: "0x0000000000000000000000000000000000000003", StakedAmount: "100", Status: 2, Role: cfg.RoleValidator},
+	}
+	actions := []*types.SimulationAction{
+		{Type: types.SimulateDelegate, Validator: "0x0000000000000000000000000000000000000003", Amount: "250"},
+	}
+	delegations := make(map[string]*big.Int)
+	simulation, simulated, err := simulateStaking(validators, 2, actions, delegations)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0x03", "0x01"}, simulation.Proposers)
+	assert.Equal(t, "850", simulation.TotalStakedAmount)
+	assert.Equal(t, cfg.RoleValidator, simulation.Validators[2].Role)
+	assert.Equal(t, cfg.RoleProposer, simulation.Validators[2].PreviousRole)
+	assert.Equal(t, "250", delegations["0x0000000000000000000000000000000000000003"].String())
+
+	rewards := estimateValidatorRewards(simulated, big.NewInt(650))
+	assert.Equal(t, "350", rewards["0x0000000000000000000000000000000000000003"].String())
+	assert.Equal(t, "0", rewards["0x0000000000000000000000000000000000000002"].String())
+	// 10% commission, delegation is 250 of 350
+	assert.Equal(t, "225", estimateDelegatorReward(rewards["0x0000000000000000000000000000000000000003"], big.NewInt(350), big.NewInt(250), "10").String())
+
+	// validator has 100 staked, but delegator only 50 of it
+	undelegate := func(amount string) []*types.SimulationAction {
+		return []*types.SimulationAction{{Type: types.SimulateUndelegate, Validator: "0x0000000000000000000000000000000000000003", Amount: amount}}
+	}
+	_, _, err = simulateStaking(validators, 2, undelegate("60"), map[string]*big.Int{"0x0000000000000000000000000000000000000003": big.NewInt(50)})
+	assert.NotNil(t, err)
+	_, _, err = simulateStaking(validators, 2, undelegate("1"), map[string]*big.Int{})
+	assert.NotNil(t, err)
+	delegations = map[string]*big.Int{"0x0000000000000000000000000000000000000003": big.NewInt(50)}
+	simulation, _, err = simulateStaking(validators, 2, undelegate("50"), delegations)
+	assert.Nil(t, err)
+	assert.Equal(t, "550", simulation.TotalStakedAmount)
+	assert.Equal(t, "0", delegations["0x0000000000000000000000000000000000000003"].String())
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"math/big"
 	"sort"
+	"strconv"
+	"time"
 
 	"github.com/kardiachain/go-kardia/lib/common"
 	"github.com/labstack/echo"
@@ -16,6 +18,18 @@ import (
 	"github.com/kardiachain/kardia-explorer-backend/types"
 )
 
+const recentCommissionIncreaseWindow = 7 * 24 * time.Hour
+
+type historyResponse struct {
+	RecentCommissionIncrease bool                      `json:"recentCommissionIncrease"`
+	History                  []*types.ValidatorHistory `json:"history"`
+}
+
+type mobileResponse struct {
+	*types.StakingStats
+	Validators []*types.Validator `json:"validators"`
+}
+
 func (s *Server) StakingStats(c echo.Context) error {
 	lgr := s.logger.With(zap.String("method", "StakingStats"))
 	ctx := context.Background()
@@ -46,6 +60,7 @@ func (s *Server) Validators(c echo.Context) error {
 			resp = append(resp, v)
 		}
 	}
+	s.markCommissionIncreases(ctx, resp, recentCommissionIncreaseWindow)
 
 	return api.OK.SetData(resp).Build(c)
 }
@@ -99,6 +114,13 @@ func (s *Server) Validator(c echo.Context) error {
 		return api.Invalid.Build(c)
 	}
 	validator.Delegators = delegators
+	s.markCommissionIncreases(ctx, []*types.Validator{validator}, recentCommissionIncreaseWindow)
+	slashSummary, err := s.dbClient.SlashSummary(ctx, validator.Address)
+	if err != nil {
+		lgr.Warn("cannot load slash summary", zap.Error(err))
+	} else {
+		validator.SlashSummary = slashSummary
+	}
 	total, err := s.dbClient.CountDelegators(ctx, filter)
 	if err != nil {
 		lgr.Error("cannot count delegator", zap.Error(err))
@@ -113,6 +135,95 @@ func (s *Server) Validator(c echo.Context) error {
 	}).Build(c)
 }
 
+func (s *Server) ValidatorHistory(c echo.Context) error {
+	ctx := context.Background()
+	pagination, page, limit := getPagingOption(c)
+	// Convert to addr and get back string to avoid wrong checksum
+	validatorAddress := common.HexToAddress(c.Param("address")).String()
+	filter := &types.ValidatorHistoryFilter{
+		Pagination:       pagination,
+		ValidatorAddress: validatorAddress,
+		Field:            c.QueryParam("field"),
+	}
+	history, total, err := s.dbClient.ValidatorHistory(ctx, filter)
+	if err != nil {
+		s.logger.Warn("Cannot get validator history from db", zap.Error(err))
+		return api.Invalid.Build(c)
+	}
+
+	window := recentCommissionIncreaseWindow
+	if days, err := strconv.Atoi(c.QueryParam("days")); err == nil && days > 0 {
+		window = time.Duration(days) * 24 * time.Hour
+	}
+	validator := &types.Validator{Address: validatorAddress}
+	s.markCommissionIncreases(ctx, []*types.Validator{validator}, window)
+
+	return api.OK.SetData(PagingResponse{
+		Page:  page,
+		Limit: limit,
+		Total: total,
+		Data: historyResponse{
+			RecentCommissionIncrease: validator.RecentCommissionIncrease,
+			History:                  history,
+		},
+	}).Build(c)
+}
+
+// markCommissionIncreases flag validators which raised their commission rate within window
+func (s *Server) markCommissionIncreases(ctx context.Context, validators []*types.Validator, window time.Duration) {
+	increases, err := s.dbClient.CommissionIncreases(ctx, time.Now().Add(-window))
+	if err != nil {
+		s.logger.Warn("Cannot get commission increases from db", zap.Error(err))
+		return
+	}
+	increased := make(map[string]bool)
+	for _, h := range increases {
+		increased[h.ValidatorAddress] = true
+	}
+	for _, v := range validators {
+		v.RecentCommissionIncrease = increased[v.Address]
+	}
+}
+
+func (s *Server) ValidatorSlashEvents(c echo.Context) error {
+	ctx := context.Background()
+	pagination, page, limit := getPagingOption(c)
+	filter := &types.SlashEventsFilter{
+		Pagination:       pagination,
+		ValidatorAddress: common.HexToAddress(c.Param("address")).Hex(),
+	}
+	events, total, err := s.dbClient.SlashEvents(ctx, filter)
+	if err != nil {
+		s.logger.Warn("Cannot get slash events from db", zap.Error(err))
+		return api.Invalid.Build(c)
+	}
+	return api.OK.SetData(PagingResponse{
+		Page:  page,
+		Limit: limit,
+		Total: total,
+		Data:  events,
+	}).Build(c)
+}
+
+func (s *Server) SlashEvents(c echo.Context) error {
+	ctx := context.Background()
+	pagination, page, limit := getPagingOption(c)
+	filter := &types.SlashEventsFilter{
+		Pagination: pagination,
+	}
+	events, total, err := s.dbClient.SlashEvents(ctx, filter)
+	if err != nil {
+		s.logger.Warn("Cannot get slash events from db", zap.Error(err))
+		return api.Invalid.Build(c)
+	}
+	return api.OK.SetData(PagingResponse{
+		Page:  page,
+		Limit: limit,
+		Total: total,
+		Data:  events,
+	}).Build(c)
+}
+
 func (s *Server) MobileValidators(c echo.Context) error {
 	ctx := context.Background()
 
@@ -136,11 +247,6 @@ func (s *Server) MobileValidators(c echo.Context) error {
 	if err != nil {
 		stats = &types.StakingStats{}
 	}
-	type mobileResponse struct {
-		*types.StakingStats
-		Validators []*types.Validator `json:"validators"`
-	}
-
 	mobileResp := mobileResponse{stats, resp}
 	return api.OK.SetData(mobileResp).Build(c)
 }
@@ -156,11 +262,6 @@ func (s *Server) MobileCandidates(c echo.Context) error {
 	if err != nil {
 		stats = &types.StakingStats{}
 	}
-	type mobileResponse struct {
-		*types.StakingStats
-		Validators []*types.Validator `json:"validators"`
-	}
-
 	mobileResp := mobileResponse{stats, candidates}
 	return api.OK.SetData(mobileResp).Build(c)
 }
@@ -0,0 +1,516 @@
+// Package server
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/cfg"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const (
+	streamTopicBlocks         = "blocks"
+	streamTopicTxs            = "txs"
+	streamTopicAddress        = "address"
+	streamTopicTokenTransfers = "tokenTransfers"
+	streamTopicStaking        = "staking"
+
+	streamKindBlock         = "block"
+	streamKindTx            = "tx"
+	streamKindTokenTransfer = "tokenTransfer"
+	streamKindStaking       = "stakingEvent"
+
+	defaultStreamMaxSubscriptions = 20
+	// messages queued for a connection, slow consumers are disconnected once it's full and should resume by fromHeight
+	streamSendBuffer      = 256
+	streamMaxReplayBlocks = 100
+	streamWriteTimeout    = 10 * time.Second
+	streamPongTimeout     = 60 * time.Second
+	streamPingInterval    = streamPongTimeout * 9 / 10
+	streamMaxRequestSize  = 4096
+)
+
+var streamTopics = map[string]bool{
+	streamTopicBlocks:         true,
+	streamTopicTxs:            true,
+	streamTopicAddress:        true,
+	streamTopicTokenTransfers: true,
+	streamTopicStaking:        true,
+}
+
+func newStreamUpgrader(allowOrigins []string) *websocket.Upgrader {
+	return &websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin: func(r *http.Request) bool {
+			return streamOriginAllowed(r.Header.Get("Origin"), allowOrigins)
+		},
+	}
+}
+
+// streamOriginAllowed match browser origin against CORS allowlist, clients which aren't browsers don't send Origin
+func streamOriginAllowed(origin string, allowOrigins []string) bool {
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range allowOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// streamRequest is sent by clients, Address filters activity of an address, transfers of a token contract
+// or events of a validator depends on topic
+type streamRequest struct {
+	Op         string  `json:"op"`
+	Topic      string  `json:"topic"`
+	Address    string  `json:"address"`
+	FromHeight *uint64 `json:"fromHeight"`
+	ID         string  `json:"id"`
+}
+
+type streamMessage struct {
+	Type    string          `json:"type"`
+	ID      string          `json:"id,omitempty"`
+	Topic   string          `json:"topic,omitempty"`
+	Kind    string          `json:"kind,omitempty"`
+	Height  uint64          `json:"height,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	Message string          `json:"message,omitempty"`
+}
+
+// streamEvent is an event of a topic derived from imported block, keys are lowercase addresses it relates to
+type streamEvent struct {
+	topic  string
+	kind   string
+	height uint64
+	keys   []string
+	data   json.RawMessage
+}
+
+type streamSubscription struct {
+	id      string
+	topic   string
+	address string
+
+	// live events are held in pending while replaying blocks up to `after` from db
+	replaying bool
+	after     uint64
+	pending   []*streamEvent
+}
+
+func (sub *streamSubscription) match(e *streamEvent) bool {
+	if sub.topic != e.topic {
+		return false
+	}
+	if sub.address == "" {
+		return true
+	}
+	for _, key := range e.keys {
+		if key == sub.address {
+			return true
+		}
+	}
+	return false
+}
+
+type streamConn struct {
+	ws   *websocket.Conn
+	send chan []byte
+
+	mu   sync.Mutex
+	subs map[string]*streamSubscription
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+func newStreamConn(ws *websocket.Conn) *streamConn {
+	return &streamConn{
+		ws:   ws,
+		send: make(chan []byte, streamSendBuffer),
+		subs: make(map[string]*streamSubscription),
+		done: make(chan struct{}),
+	}
+}
+
+func (c *streamConn) close(code int, reason string) {
+	c.closeOnce.Do(func() {
+		_ = c.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(streamWriteTimeout))
+		close(c.done)
+		_ = c.ws.Close()
+	})
+}
+
+// trySend queue message without blocking, slow consumer is disconnected
+func (c *streamConn) trySend(msg *streamMessage) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	select {
+	case c.send <- data:
+	case <-c.done:
+	default:
+		// close in background, it may be called while hub is dispatching
+		go c.close(websocket.ClosePolicyViolation, "slow consumer, reconnect and resume with fromHeight")
+	}
+}
+
+// sendWait queue message and wait for space, used when replaying blocks
+func (c *streamConn) sendWait(msg *streamMessage) bool {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return false
+	}
+	select {
+	case c.send <- data:
+		return true
+	case <-c.done:
+		return false
+	}
+}
+
+// deliver live event to matched subscriptions of this connection
+func (c *streamConn) deliver(e *streamEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, sub := range c.subs {
+		if !sub.match(e) {
+			continue
+		}
+		if sub.replaying {
+			if e.height > sub.after {
+				if len(sub.pending) >= streamSendBuffer {
+					go c.close(websocket.ClosePolicyViolation, "slow consumer, reconnect and resume with fromHeight")
+					return
+				}
+				sub.pending = append(sub.pending, e)
+			}
+			continue
+		}
+		c.trySend(newStreamEventMessage(sub.id, e))
+	}
+}
+
+func (c *streamConn) writeLoop() {
+	ticker := time.NewTicker(streamPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case data := <-c.send:
+			_ = c.ws.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			if err := c.ws.WriteMessage(websocket.TextMessage, data); err != nil {
+				c.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-ticker.C:
+			if err := c.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteTimeout)); err != nil {
+				c.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		}
+	}
+}
+
+type streamHub struct {
+	latestHeight     uint64 // height of latest dispatched block, accessed atomically
+	maxSubscriptions int
+	upgrader         *websocket.Upgrader
+
+	startOnce sync.Once
+
+	mu    sync.RWMutex
+	conns map[*streamConn]struct{}
+}
+
+func newStreamHub(maxSubscriptions int, allowOrigins []string) *streamHub {
+	if maxSubscriptions <= 0 {
+		maxSubscriptions = defaultStreamMaxSubscriptions
+	}
+	return &streamHub{
+		maxSubscriptions: maxSubscriptions,
+		upgrader:         newStreamUpgrader(allowOrigins),
+		conns:            make(map[*streamConn]struct{}),
+	}
+}
+
+// PublishBlock publish an imported block to WebSocket subscribers of all API replicas. Blocks from backfill and
+// verifier may be older than latest, or published again after being re-imported
+func (s *infoServer) PublishBlock(ctx context.Context, block *types.Block) error {
+	header := *block
+	header.Txs = nil
+	header.Receipts = nil
+	return s.cacheClient.PublishBlock(ctx, &types.StreamBlock{Block: &header, Txs: block.Txs})
+}
+
+// Stream serve WebSocket subscriptions of blocks, txs, address activity, token transfers and staking events
+func (s *Server) Stream(c echo.Context) error {
+	s.stream.startOnce.Do(func() {
+		go s.runStreamHub(context.Background())
+	})
+	ws, err := s.stream.upgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		s.logger.Debug("Cannot upgrade websocket connection", zap.Error(err))
+		return nil
+	}
+	conn := newStreamConn(ws)
+	s.stream.mu.Lock()
+	s.stream.conns[conn] = struct{}{}
+	s.stream.mu.Unlock()
+	defer func() {
+		s.stream.mu.Lock()
+		delete(s.stream.conns, conn)
+		s.stream.mu.Unlock()
+		conn.close(websocket.CloseNormalClosure, "")
+	}()
+
+	go conn.writeLoop()
+	ws.SetReadLimit(streamMaxRequestSize)
+	_ = ws.SetReadDeadline(time.Now().Add(streamPongTimeout))
+	ws.SetPongHandler(func(string) error {
+		return ws.SetReadDeadline(time.Now().Add(streamPongTimeout))
+	})
+	for {
+		_, data, err := ws.ReadMessage()
+		if err != nil {
+			return nil
+		}
+		var req streamRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			conn.trySend(&streamMessage{Type: "error", Message: "invalid request"})
+			continue
+		}
+		switch req.Op {
+		case "subscribe":
+			s.subscribeStream(conn, &req)
+		case "unsubscribe":
+			conn.mu.Lock()
+			_, ok := conn.subs[req.ID]
+			delete(conn.subs, req.ID)
+			conn.mu.Unlock()
+			if !ok {
+				conn.trySend(&streamMessage{Type: "error", ID: req.ID, Message: "subscription not found"})
+				continue
+			}
+			conn.trySend(&streamMessage{Type: "unsubscribed", ID: req.ID})
+		default:
+			conn.trySend(&streamMessage{Type: "error", Message: "unknown op " + req.Op})
+		}
+	}
+}
+
+func newStreamSubscription(req *streamRequest) (*streamSubscription, error) {
+	if !streamTopics[req.Topic] {
+		return nil, fmt.Errorf("unknown topic %s", req.Topic)
+	}
+	address := strings.ToLower(strings.TrimSpace(req.Address))
+	if req.Topic == streamTopicAddress && address == "" {
+		return nil, errors.New("address is required")
+	}
+	if req.Topic == streamTopicBlocks || req.Topic == streamTopicTxs {
+		address = ""
+	}
+	id := req.Topic
+	if address != "" {
+		id += ":" + address
+	}
+	return &streamSubscription{id: id, topic: req.Topic, address: address}, nil
+}
+
+func (s *Server) subscribeStream(conn *streamConn, req *streamRequest) {
+	sub, err := newStreamSubscription(req)
+	if err != nil {
+		conn.trySend(&streamMessage{Type: "error", Message: err.Error()})
+		return
+	}
+	latest := atomic.LoadUint64(&s.stream.latestHeight)
+	if latest == 0 {
+		latest = s.cacheClient.LatestBlockHeight(context.Background())
+	}
+	if req.FromHeight != nil {
+		if *req.FromHeight+streamMaxReplayBlocks < latest {
+			conn.trySend(&streamMessage{Type: "error", ID: sub.id, Message: fmt.Sprintf("cannot resume more than %d blocks behind", streamMaxReplayBlocks)})
+			return
+		}
+		sub.replaying = *req.FromHeight < latest
+		sub.after = latest
+	}
+
+	conn.mu.Lock()
+	if _, ok := conn.subs[sub.id]; ok {
+		conn.mu.Unlock()
+		conn.trySend(&streamMessage{Type: "error", ID: sub.id, Message: "already subscribed"})
+		return
+	}
+	if len(conn.subs) >= s.stream.maxSubscriptions {
+		conn.mu.Unlock()
+		conn.trySend(&streamMessage{Type: "error", ID: sub.id, Message: fmt.Sprintf("too many subscriptions, limit is %d", s.stream.maxSubscriptions)})
+		return
+	}
+	conn.subs[sub.id] = sub
+	conn.mu.Unlock()
+	conn.trySend(&streamMessage{Type: "subscribed", ID: sub.id, Topic: sub.topic, Height: latest})
+	if !sub.replaying {
+		return
+	}
+
+	// replay missed blocks, then flush live events arrived meanwhile
+	if err := s.replayStream(conn, sub, *req.FromHeight+1, latest); err != nil {
+		s.logger.Warn("Cannot replay stream", zap.String("id", sub.id), zap.Error(err))
+		conn.trySend(&streamMessage{Type: "error", ID: sub.id, Message: "cannot replay missed blocks"})
+	}
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	for _, e := range sub.pending {
+		conn.trySend(newStreamEventMessage(sub.id, e))
+	}
+	sub.pending = nil
+	sub.replaying = false
+}
+
+func (s *Server) replayStream(conn *streamConn, sub *streamSubscription, from, to uint64) error {
+	ctx := context.Background()
+	heights := make([]uint64, 0, to-from+1)
+	for h := from; h <= to; h++ {
+		heights = append(heights, h)
+	}
+	blocks, err := s.dbClient.BlocksByHeights(ctx, heights)
+	if err != nil {
+		return err
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].Height < blocks[j].Height })
+	stakingContracts := s.streamStakingContracts(ctx)
+	for _, block := range blocks {
+		var txs []*types.Transaction
+		if sub.topic != streamTopicBlocks && block.NumTxs > 0 {
+			if txs, _, err = s.dbClient.TxsByBlockHeight(ctx, block.Height, nil); err != nil {
+				return err
+			}
+		}
+		for _, e := range streamEvents(&types.StreamBlock{Block: block, Txs: txs}, stakingContracts) {
+			if !sub.match(e) {
+				continue
+			}
+			if !conn.sendWait(newStreamEventMessage(sub.id, e)) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) runStreamHub(ctx context.Context) {
+	for block := range s.cacheClient.SubscribeBlocks(ctx) {
+		if block.Block == nil {
+			continue
+		}
+		events := streamEvents(block, s.streamStakingContracts(ctx))
+		// backfill and verifier publish older blocks too
+		for latest := atomic.LoadUint64(&s.stream.latestHeight); block.Block.Height > latest; latest = atomic.LoadUint64(&s.stream.latestHeight) {
+			if atomic.CompareAndSwapUint64(&s.stream.latestHeight, latest, block.Block.Height) {
+				break
+			}
+		}
+		s.stream.mu.RLock()
+		for conn := range s.stream.conns {
+			for _, e := range events {
+				conn.deliver(e)
+			}
+		}
+		s.stream.mu.RUnlock()
+	}
+	s.logger.Warn("Stream subscription is closed")
+}
+
+// streamStakingContracts return staking contract and validator contracts
+func (s *Server) streamStakingContracts(ctx context.Context) map[string]bool {
+	contracts := map[string]bool{strings.ToLower(cfg.StakingContractAddr): true}
+	for _, v := range s.cachedValidators(ctx) {
+		contracts[strings.ToLower(v.SmcAddress)] = true
+	}
+	return contracts
+}
+
+// streamEvents turn an imported block into events of all topics
+func streamEvents(block *types.StreamBlock, stakingContracts map[string]bool) []*streamEvent {
+	height := block.Block.Height
+	header := *block.Block
+	header.Txs = nil
+	header.Receipts = nil
+	events := []*streamEvent{newStreamEvent(streamTopicBlocks, streamKindBlock, height, nil, &header)}
+	for _, tx := range block.Txs {
+		keys := streamKeys(tx.From, tx.To, tx.ContractAddress)
+		events = append(events,
+			newStreamEvent(streamTopicTxs, streamKindTx, height, nil, tx),
+			newStreamEvent(streamTopicAddress, streamKindTx, height, keys, tx))
+		for i := range tx.Logs {
+			log := &tx.Logs[i]
+			contract := strings.ToLower(log.Address)
+			if len(log.Topics) > 0 && log.Topics[0] == cfg.KRCTransferTopic {
+				if transfer := streamTokenTransfer(log); transfer != nil {
+					events = append(events,
+						newStreamEvent(streamTopicTokenTransfers, streamKindTokenTransfer, height, []string{contract}, transfer),
+						newStreamEvent(streamTopicAddress, streamKindTokenTransfer, height, streamKeys(transfer.From, transfer.To), transfer))
+				}
+			}
+			if stakingContracts[contract] {
+				events = append(events, newStreamEvent(streamTopicStaking, streamKindStaking, height, []string{contract}, log))
+			}
+		}
+	}
+	return events
+}
+
+func streamTokenTransfer(log *types.Log) *types.TokenTransfer {
+	from, _ := log.Arguments["from"].(string)
+	to, _ := log.Arguments["to"].(string)
+	if from == "" || to == "" {
+		return nil
+	}
+	value, _ := log.Arguments["value"].(string)
+	return &types.TokenTransfer{
+		TransactionHash: log.TxHash,
+		Contract:        log.Address,
+		From:            from,
+		To:              to,
+		Value:           value,
+		Time:            log.Time,
+	}
+}
+
+func streamKeys(addresses ...string) []string {
+	var keys []string
+	for _, addr := range addresses {
+		if addr != "" {
+			keys = append(keys, strings.ToLower(addr))
+		}
+	}
+	return keys
+}
+
+func newStreamEvent(topic, kind string, height uint64, keys []string, data interface{}) *streamEvent {
+	raw, _ := json.Marshal(data)
+	return &streamEvent{topic: topic, kind: kind, height: height, keys: keys, data: raw}
+}
+
+func newStreamEventMessage(id string, e *streamEvent) *streamMessage {
+	return &streamMessage{Type: "event", ID: id, Topic: e.topic, Kind: e.kind, Height: e.height, Data: e.data}
+}
@@ -0,0 +1,78 @@
+// Package server
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/cfg"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_streamEvents(t *testing.T) {
+	validatorSMC := "0x1111111111111111111111111111111111111111"
+	block := &types.StreamBlock{
+		Block: &types.Block{Height: 10, Hash: "0xblock"},
+		Txs: []*types.Transaction{
+			{
+				Hash: "0xtx",
+				From: "0xAAAA",
+				To:   "0xToken",
+				Logs: []types.Log{
+					{
+						Address:   "0xToken",
+						Topics:    []string{cfg.KRCTransferTopic},
+						Arguments: map[string]interface{}{"from": "0xAAAA", "to": "0xBBBB", "value": "5"},
+					},
+					{Address: validatorSMC, MethodName: "Delegate"},
+				},
+			},
+		},
+	}
+	events := streamEvents(block, map[string]bool{validatorSMC: true})
+
+	count := func(sub *streamSubscription) int {
+		n := 0
+		for _, e := range events {
+			if sub.match(e) {
+				assert.Equal(t, uint64(10), e.height)
+				n++
+			}
+		}
+		return n
+	}
+	assert.Equal(t, 1, count(&streamSubscription{topic: streamTopicBlocks}))
+	assert.Equal(t, 1, count(&streamSubscription{topic: streamTopicTxs}))
+	// sender has both tx and token transfer, receiver only the transfer
+	assert.Equal(t, 2, count(&streamSubscription{topic: streamTopicAddress, address: "0xaaaa"}))
+	assert.Equal(t, 1, count(&streamSubscription{topic: streamTopicAddress, address: "0xbbbb"}))
+	assert.Equal(t, 0, count(&streamSubscription{topic: streamTopicAddress, address: "0xcccc"}))
+	assert.Equal(t, 1, count(&streamSubscription{topic: streamTopicTokenTransfers, address: "0xtoken"}))
+	assert.Equal(t, 1, count(&streamSubscription{topic: streamTopicStaking}))
+	assert.Equal(t, 1, count(&streamSubscription{topic: streamTopicStaking, address: validatorSMC}))
+}
+
+func Test_newStreamSubscription(t *testing.T) {
+	sub, err := newStreamSubscription(&streamRequest{Topic: streamTopicAddress, Address: " 0xAbC "})
+	assert.Nil(t, err)
+	assert.Equal(t, "address:0xabc", sub.id)
+
+	// blocks and txs don't have filter
+	sub, err = newStreamSubscription(&streamRequest{Topic: streamTopicBlocks, Address: "0xabc"})
+	assert.Nil(t, err)
+	assert.Equal(t, "blocks", sub.id)
+
+	_, err = newStreamSubscription(&streamRequest{Topic: streamTopicAddress})
+	assert.NotNil(t, err)
+	_, err = newStreamSubscription(&streamRequest{Topic: "unknown"})
+	assert.NotNil(t, err)
+}
+
+func Test_streamOriginAllowed(t *testing.T) {
+	allowed := []string{"https://explorer.kardiachain.io"}
+	assert.True(t, streamOriginAllowed("https://explorer.kardiachain.io", allowed))
+	assert.True(t, streamOriginAllowed("", allowed))
+	assert.False(t, streamOriginAllowed("https://evil.example", allowed))
+	assert.True(t, streamOriginAllowed("https://evil.example", []string{"*"}))
+}
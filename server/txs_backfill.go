@@ -0,0 +1,45 @@
+// Package server
+package server
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	txsBackfillBatch = 500
+
+	migrationTxsBackfill = "txsBackfill"
+)
+
+// BackfillTxs update txs which were imported before filter fields and type were set on insert, newest first.
+// Value, selector and type filters of txs listing only see those txs after it has finished. Finding txs to backfill
+// walks the whole time index, so it's recorded as done and skipped on later starts
+func (s *infoServer) BackfillTxs(ctx context.Context) error {
+	lgr := s.logger.With(zap.String("method", "BackfillTxs"))
+	done, err := s.dbClient.MigrationDone(ctx, migrationTxsBackfill)
+	if err != nil || done {
+		return err
+	}
+	before, total := time.Now(), 0
+	for {
+		txs, err := s.dbClient.TxsToBackfill(ctx, before, txsBackfillBatch)
+		if err != nil {
+			return err
+		}
+		if len(txs) == 0 {
+			lgr.Info("Finished backfilling txs", zap.Int("total", total))
+			return s.dbClient.MarkMigrationDone(ctx, migrationTxsBackfill)
+		}
+		s.classifyTxs(ctx, txs)
+		if err := s.dbClient.BackfillTxs(ctx, txs); err != nil {
+			return err
+		}
+		// updated txs don't match anymore, so txs at the same time which are left are found again
+		before = txs[len(txs)-1].Time
+		total += len(txs)
+		lgr.Debug("Backfilled txs", zap.Int("total", total), zap.Time("before", before))
+	}
+}
@@ -0,0 +1,140 @@
+// Package server
+package server
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/kardiachain/kardia-explorer-backend/cfg"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+// validatorMethodTypes are calls of validator contracts, users stake and claim there instead of staking contract
+var validatorMethodTypes = map[string]string{
+	"delegate":             types.TxTypeStake,
+	"undelegate":           types.TxTypeUnstake,
+	"undelegateWithAmount": types.TxTypeUnstake,
+	"withdraw":             types.TxTypeWithdraw,
+	"withdrawRewards":      types.TxTypeClaimReward,
+	"withdrawCommission":   types.TxTypeClaimReward,
+	"start":                types.TxTypeValidator,
+	"stop":                 types.TxTypeValidator,
+	"unjail":               types.TxTypeValidator,
+	"updateName":           types.TxTypeValidator,
+	"updateCommissionRate": types.TxTypeValidator,
+	"updateSigner":         types.TxTypeValidator,
+}
+
+var paramsMethodTypes = map[string]string{
+	"addProposal":     types.TxTypeGovernanceProposal,
+	"confirmProposal": types.TxTypeGovernanceProposal,
+	"addVote":         types.TxTypeGovernanceVote,
+}
+
+// classifyTx return type of a tx with merged receipt, isValidator is only asked for calls which look like staking
+func classifyTx(tx *types.Transaction, isValidator func(address string) bool) string {
+	if tx.Status == types.TxStatusFailed {
+		return types.TxTypeFailed
+	}
+	if tx.To == "" {
+		return types.TxTypeContractCreation
+	}
+	method := ""
+	if tx.DecodedInputData != nil {
+		method = tx.DecodedInputData.MethodName
+	}
+	switch {
+	case strings.EqualFold(tx.To, cfg.StakingContractAddr):
+		if method == "createValidator" {
+			return types.TxTypeValidator
+		}
+		return types.TxTypeContractCall
+	case strings.EqualFold(tx.To, cfg.ParamsContractAddr):
+		if t, ok := paramsMethodTypes[method]; ok {
+			return t
+		}
+		return types.TxTypeContractCall
+	}
+	if t, ok := validatorMethodTypes[method]; ok && isValidator(tx.To) {
+		return t
+	}
+
+	// a KRC721 Transfer has indexed token id, so it has one more topic than KRC20's
+	txType := ""
+	for _, l := range tx.Logs {
+		if len(l.Topics) == 0 || l.Topics[0] != cfg.KRCTransferTopic {
+			continue
+		}
+		if len(l.Topics) == 4 {
+			return types.TxTypeNFTTransfer
+		}
+		txType = types.TxTypeTokenTransfer
+	}
+	if txType != "" {
+		return txType
+	}
+	if tx.InputData == "" || tx.InputData == "0x" {
+		return types.TxTypeTransfer
+	}
+	return types.TxTypeContractCall
+}
+
+// classifyTxs set type of txs in a block, validator contracts are looked up once per block
+func (s *infoServer) classifyTxs(ctx context.Context, txs []*types.Transaction) {
+	validators := make(map[string]bool)
+	isValidator := func(address string) bool {
+		if v, ok := validators[address]; ok {
+			return v
+		}
+		smc, _, err := s.dbClient.Contract(ctx, address)
+		validators[address] = err == nil && smc != nil && smc.Type == cfg.SMCTypeValidator
+		return validators[address]
+	}
+	for _, tx := range txs {
+		tx.Type = classifyTx(tx, isValidator)
+	}
+}
+
+// txsPageOfType filter txs of a block by type then page them, a block is small enough to be filtered in memory
+func txsPageOfType(txs []*types.Transaction, txType string, pagination *types.Pagination) ([]*types.Transaction, uint64) {
+	var matched []*types.Transaction
+	for _, tx := range txs {
+		if tx.Type == txType {
+			matched = append(matched, tx)
+		}
+	}
+	total := uint64(len(matched))
+	if pagination == nil {
+		return matched, total
+	}
+	if pagination.Skip >= len(matched) {
+		return nil, total
+	}
+	end := pagination.Skip + pagination.Limit
+	if end > len(matched) {
+		end = len(matched)
+	}
+	return matched[pagination.Skip:end], total
+}
+
+func (s *Server) blockTxsOfType(ctx context.Context, block, txType string, pagination *types.Pagination) ([]*types.Transaction, uint64, error) {
+	var (
+		txs []*types.Transaction
+		err error
+	)
+	if strings.HasPrefix(block, "0x") {
+		txs, _, err = s.dbClient.TxsByBlockHash(ctx, block, nil)
+	} else {
+		height, parseErr := strconv.ParseUint(block, 10, 64)
+		if parseErr != nil {
+			return nil, 0, parseErr
+		}
+		txs, _, err = s.dbClient.TxsByBlockHeight(ctx, height, nil)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	page, total := txsPageOfType(txs, txType, pagination)
+	return page, total, nil
+}
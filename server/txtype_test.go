@@ -0,0 +1,70 @@
+// Package server
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/cfg"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_classifyTx(t *testing.T) {
+	validator := "0x7a1c7B5a2c7b0d2bB8bB4d0f4B7c1e6e1a0A0001"
+	token := "0x4D4FD1a0aA1e2E7A5F3e2e1a0a1D1e4C1D0B0002"
+	isValidator := func(address string) bool {
+		return address == validator
+	}
+	call := func(to, method string) *types.Transaction {
+		return &types.Transaction{To: to, Status: types.TxStatusSuccess, InputData: "0x12345678", DecodedInputData: &types.FunctionCall{MethodName: method}}
+	}
+	transferLog := func(topics int) types.Log {
+		l := types.Log{Address: token, Topics: []string{cfg.KRCTransferTopic}}
+		for i := 1; i < topics; i++ {
+			l.Topics = append(l.Topics, "0x01")
+		}
+		return l
+	}
+	tests := []struct {
+		name string
+		tx   *types.Transaction
+		want string
+	}{
+		{"plain transfer", &types.Transaction{Status: types.TxStatusSuccess, To: token, InputData: "0x", Value: "1"}, types.TxTypeTransfer},
+		{"contract creation", &types.Transaction{Status: types.TxStatusSuccess, InputData: "0x6080"}, types.TxTypeContractCreation},
+		{"create validator", call(cfg.StakingContractAddr, "createValidator"), types.TxTypeValidator},
+		{"vote", call(cfg.ParamsContractAddr, "addVote"), types.TxTypeGovernanceVote},
+		{"proposal", call(cfg.ParamsContractAddr, "addProposal"), types.TxTypeGovernanceProposal},
+		{"stake", call(validator, "delegate"), types.TxTypeStake},
+		{"unstake", call(validator, "undelegateWithAmount"), types.TxTypeUnstake},
+		{"claim reward", call(validator, "withdrawRewards"), types.TxTypeClaimReward},
+		{"delegate of other contract", call(token, "delegate"), types.TxTypeContractCall},
+		{"token transfer", &types.Transaction{Status: types.TxStatusSuccess, To: token, InputData: "0xa9059cbb", Logs: []types.Log{transferLog(3)}}, types.TxTypeTokenTransfer},
+		{"nft transfer", &types.Transaction{Status: types.TxStatusSuccess, To: token, InputData: "0x23b872dd", Logs: []types.Log{transferLog(3), transferLog(4)}}, types.TxTypeNFTTransfer},
+		{"contract call", call(token, "approve"), types.TxTypeContractCall},
+		{"failed stake", &types.Transaction{To: validator, InputData: "0x12345678", DecodedInputData: &types.FunctionCall{MethodName: "delegate"}}, types.TxTypeFailed},
+		{"failed contract creation", &types.Transaction{InputData: "0x6080"}, types.TxTypeFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, classifyTx(tt.tx, isValidator))
+		})
+	}
+}
+
+func Test_txsPageOfType(t *testing.T) {
+	txs := []*types.Transaction{
+		{Hash: "0x1", Type: types.TxTypeTransfer},
+		{Hash: "0x2", Type: types.TxTypeStake},
+		{Hash: "0x3", Type: types.TxTypeTransfer},
+		{Hash: "0x4", Type: types.TxTypeTransfer},
+	}
+	page, total := txsPageOfType(txs, types.TxTypeTransfer, &types.Pagination{Skip: 1, Limit: 1})
+	assert.Equal(t, uint64(3), total)
+	assert.Equal(t, []*types.Transaction{txs[2]}, page)
+
+	page, total = txsPageOfType(txs, types.TxTypeTransfer, &types.Pagination{Skip: 3, Limit: 10})
+	assert.Equal(t, uint64(3), total)
+	assert.Empty(t, page)
+}
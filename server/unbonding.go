@@ -0,0 +1,67 @@
+// Package server
+package server
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/api"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+// DelegatorUnbondingSchedule return unbonding entries of delegator across all validators
+func (s *Server) DelegatorUnbondingSchedule(c echo.Context) error {
+	ctx := context.Background()
+	delegatorAddress := common.HexToAddress(c.Param("address")).Hex()
+	entries, err := s.dbClient.UnbondingEntries(ctx, delegatorAddress)
+	if err != nil {
+		s.logger.Warn("Cannot get unbonding entries from db", zap.Error(err))
+		return api.Invalid.Build(c)
+	}
+	smcAddress := s.getValidatorsAddressAndRole(ctx)
+	for _, e := range entries {
+		if info, ok := smcAddress[e.ValidatorSMCAddress]; ok {
+			e.ValidatorName = info.Name
+		}
+	}
+	return api.OK.SetData(buildUnbondingSchedule(delegatorAddress, entries, time.Now().Unix())).Build(c)
+}
+
+func buildUnbondingSchedule(delegatorAddress string, entries []*types.UnbondingEntry, now int64) *types.UnbondingSchedule {
+	var (
+		totalUnbonding    = big.NewInt(0)
+		totalWithdrawable = big.NewInt(0)
+		schedule          = &types.UnbondingSchedule{
+			DelegatorAddress: delegatorAddress,
+			Entries:          entries,
+		}
+	)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CompletionTime < entries[j].CompletionTime
+	})
+	for _, e := range entries {
+		amount, ok := new(big.Int).SetString(e.Amount, 10)
+		if !ok {
+			continue
+		}
+		if e.CompletionTime <= now {
+			e.Withdrawable = true
+			totalWithdrawable.Add(totalWithdrawable, amount)
+			continue
+		}
+		e.RemainingTime = e.CompletionTime - now
+		totalUnbonding.Add(totalUnbonding, amount)
+		if schedule.NextCompletionTime == 0 {
+			schedule.NextCompletionTime = e.CompletionTime
+		}
+	}
+	schedule.TotalUnbonding = totalUnbonding.String()
+	schedule.TotalWithdrawable = totalWithdrawable.String()
+	return schedule
+}
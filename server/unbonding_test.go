@@ -0,0 +1,25 @@
+// Package server
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_buildUnbondingSchedule(t *testing.T) {
+	entries := []*types.UnbondingEntry{
+		{ValidatorSMCAddress: "0xB", Amount: "30", CompletionTime: 300},
+		{ValidatorSMCAddress: "0xA", Amount: "10", CompletionTime: 100},
+		{ValidatorSMCAddress: "0xA", Amount: "20", CompletionTime: 200},
+	}
+	schedule := buildUnbondingSchedule("0xD", entries, 150)
+	assert.Equal(t, "10", schedule.TotalWithdrawable)
+	assert.Equal(t, "50", schedule.TotalUnbonding)
+	assert.Equal(t, int64(200), schedule.NextCompletionTime)
+	assert.True(t, schedule.Entries[0].Withdrawable)
+	assert.Equal(t, int64(50), schedule.Entries[1].RemainingTime)
+	assert.Equal(t, int64(300), schedule.Entries[2].CompletionTime)
+}
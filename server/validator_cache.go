@@ -0,0 +1,42 @@
+// Package server
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/db"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+const validatorCacheRefresh = time.Minute
+
+// validatorCache keep the validator list in memory for lookups done on every request or block, e.g. search and
+// stream. Zero value is ready to use
+type validatorCache struct {
+	mu         sync.Mutex
+	validators []*types.Validator
+	updatedAt  time.Time
+}
+
+// cachedValidators return validators refreshed at most every validatorCacheRefresh, the last list is kept if db fails
+func (s *Server) cachedValidators(ctx context.Context) []*types.Validator {
+	s.validators.mu.Lock()
+	defer s.validators.mu.Unlock()
+	if s.validators.validators != nil && time.Since(s.validators.updatedAt) < validatorCacheRefresh {
+		return s.validators.validators
+	}
+	validators, err := s.dbClient.Validators(ctx, db.ValidatorsFilter{})
+	if err != nil {
+		s.logger.Warn("Cannot get validators", zap.Error(err))
+		return s.validators.validators
+	}
+	if validators == nil {
+		validators = []*types.Validator{}
+	}
+	s.validators.validators, s.validators.updatedAt = validators, time.Now()
+	return validators
+}
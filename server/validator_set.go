@@ -0,0 +1,186 @@
+// Package server
+package server
+
+import (
+	"context"
+	"math"
+	"strconv"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/api"
+	"github.com/kardiachain/kardia-explorer-backend/db"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+// recordValidatorSetChange store new validator set if block announce a different NextValidatorHash.
+// Blocks may be imported out of order by backfill, so after storing we re-diff the next known change too
+func (s *infoServer) recordValidatorSetChange(ctx context.Context, block *types.Block) error {
+	if err := s.recordInitialValidatorSet(ctx, block); err != nil {
+		return err
+	}
+	if block.NextValidatorHash == "" || block.NextValidatorHash == block.ValidatorHash {
+		return nil
+	}
+	members, err := s.validatorSetMembers(ctx, block.Height)
+	if err != nil {
+		return err
+	}
+	change := &types.ValidatorSetChange{
+		Height:        block.Height + 1,
+		BlockHeight:   block.Height,
+		ValidatorHash: block.NextValidatorHash,
+		Validators:    members,
+		Time:          block.Time,
+	}
+
+	prev, err := s.dbClient.ValidatorSetAt(ctx, block.Height)
+	if err != nil {
+		return err
+	}
+	if prev != nil {
+		change.Joined, change.Left, change.PowerChanges = diffValidatorSet(prev.Validators, change.Validators)
+	}
+	if err := s.dbClient.UpsertValidatorSetChange(ctx, change); err != nil {
+		return err
+	}
+
+	next, err := s.dbClient.NextValidatorSetChange(ctx, change.Height)
+	if err != nil || next == nil {
+		return err
+	}
+	next.Joined, next.Left, next.PowerChanges = diffValidatorSet(change.Validators, next.Validators)
+	return s.dbClient.UpsertValidatorSetChange(ctx, next)
+}
+
+// recordInitialValidatorSet store set which signed block when no set is stored yet, otherwise
+// there is no active set until the first change is imported
+func (s *infoServer) recordInitialValidatorSet(ctx context.Context, block *types.Block) error {
+	if block.Height == 0 {
+		return nil
+	}
+	latest, err := s.dbClient.ValidatorSetAt(ctx, math.MaxInt64)
+	if err != nil || latest != nil {
+		return err
+	}
+	members, err := s.validatorSetMembers(ctx, block.Height-1)
+	if err != nil {
+		return err
+	}
+	return s.dbClient.UpsertValidatorSetChange(ctx, &types.ValidatorSetChange{
+		Height:        block.Height,
+		BlockHeight:   block.Height - 1,
+		ValidatorHash: block.ValidatorHash,
+		Validators:    members,
+		Time:          block.Time,
+	})
+}
+
+// validatorSetMembers return validator set stored in staking contract at height, which signs the next block
+func (s *infoServer) validatorSetMembers(ctx context.Context, height uint64) ([]*types.ValidatorSetMember, error) {
+	addrs, powers, err := s.kaiClient.GetValidatorSetsAtHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]*types.ValidatorSetMember, 0, len(addrs))
+	for i, addr := range addrs {
+		power := "0"
+		if i < len(powers) && powers[i] != nil {
+			power = powers[i].String()
+		}
+		members = append(members, &types.ValidatorSetMember{Address: addr.Hex(), VotingPower: power})
+	}
+	return members, nil
+}
+
+func diffValidatorSet(prev, next []*types.ValidatorSetMember) (joined, left []*types.ValidatorSetMember, powerChanges []*types.ValidatorPowerChange) {
+	prevPowers := make(map[string]string, len(prev))
+	for _, m := range prev {
+		prevPowers[m.Address] = m.VotingPower
+	}
+	nextAddrs := make(map[string]bool, len(next))
+	for _, m := range next {
+		nextAddrs[m.Address] = true
+		power, ok := prevPowers[m.Address]
+		if !ok {
+			joined = append(joined, m)
+			continue
+		}
+		if power != m.VotingPower {
+			powerChanges = append(powerChanges, &types.ValidatorPowerChange{Address: m.Address, From: power, To: m.VotingPower})
+		}
+	}
+	for _, m := range prev {
+		if !nextAddrs[m.Address] {
+			left = append(left, m)
+		}
+	}
+	return joined, left, powerChanges
+}
+
+// ValidatorSetChanges return timeline of validator set changes, optionally only the ones involving `address`
+func (s *Server) ValidatorSetChanges(c echo.Context) error {
+	ctx := context.Background()
+	pagination, page, limit := getPagingOption(c)
+	filter := &types.ValidatorSetChangesFilter{Pagination: pagination}
+	if address := c.QueryParam("address"); address != "" {
+		filter.Address = common.HexToAddress(address).String()
+	}
+	changes, total, err := s.dbClient.ValidatorSetChanges(ctx, filter)
+	if err != nil {
+		s.logger.Warn("Cannot get validator set changes from db", zap.Error(err))
+		return api.Invalid.Build(c)
+	}
+	names := s.getValidatorNames(ctx)
+	for _, change := range changes {
+		setValidatorNames(change.Joined, names)
+		setValidatorNames(change.Left, names)
+	}
+	return api.OK.SetData(PagingResponse{
+		Page:  page,
+		Limit: limit,
+		Total: total,
+		Data:  changes,
+	}).Build(c)
+}
+
+// ActiveValidatorSet return validator set which signed block at `height`, latest set if height is omitted
+func (s *Server) ActiveValidatorSet(c echo.Context) error {
+	ctx := context.Background()
+	// mongo can't encode uint64 above MaxInt64
+	height := uint64(math.MaxInt64)
+	if c.QueryParam("height") != "" {
+		h, err := strconv.ParseUint(c.QueryParam("height"), 10, 64)
+		if err != nil {
+			return api.Invalid.Build(c)
+		}
+		height = h
+	}
+	set, err := s.dbClient.ValidatorSetAt(ctx, height)
+	if err != nil || set == nil {
+		s.logger.Warn("Cannot get validator set at height", zap.Uint64("height", height), zap.Error(err))
+		return api.Invalid.Build(c)
+	}
+	setValidatorNames(set.Validators, s.getValidatorNames(ctx))
+	return api.OK.SetData(set).Build(c)
+}
+
+func (s *Server) getValidatorNames(ctx context.Context) map[string]string {
+	names := make(map[string]string)
+	validators, err := s.dbClient.Validators(ctx, db.ValidatorsFilter{})
+	if err != nil {
+		return names
+	}
+	for _, v := range validators {
+		names[v.Address] = v.Name
+	}
+	return names
+}
+
+func setValidatorNames(members []*types.ValidatorSetMember, names map[string]string) {
+	for _, m := range members {
+		m.Name = names[m.Address]
+	}
+}
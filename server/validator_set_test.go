@@ -0,0 +1,25 @@
+// Package server
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_diffValidatorSet(t *testing.T) {
+	prev := []*types.ValidatorSetMember{
+		{Address: "0xA", VotingPower: "10"},
+		{Address: "0xB", VotingPower: "20"},
+	}
+	next := []*types.ValidatorSetMember{
+		{Address: "0xB", VotingPower: "25"},
+		{Address: "0xC", VotingPower: "5"},
+	}
+	joined, left, powerChanges := diffValidatorSet(prev, next)
+	assert.Equal(t, []*types.ValidatorSetMember{{Address: "0xC", VotingPower: "5"}}, joined)
+	assert.Equal(t, []*types.ValidatorSetMember{{Address: "0xA", VotingPower: "10"}}, left)
+	assert.Equal(t, []*types.ValidatorPowerChange{{Address: "0xB", From: "20", To: "25"}}, powerChanges)
+}
@@ -0,0 +1,352 @@
+// Package server
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kardiachain/go-kardia/lib/common"
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/kardiachain/kardia-explorer-backend/api"
+	"github.com/kardiachain/kardia-explorer-backend/cfg"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+	"github.com/kardiachain/kardia-explorer-backend/utils"
+)
+
+const (
+	maxWebhookWatches    = 100
+	webhookSecretLength  = 32
+	webhookRemovedSuffix = ":removed"
+	webhookLookupTimeout = 5 * time.Second
+)
+
+type webhookWatchRequest struct {
+	Type     string `json:"type"`
+	Address  string `json:"address"`
+	Contract string `json:"contract"`
+	Topic    string `json:"topic"`
+	URL      string `json:"url"`
+}
+
+// webhookOwner identify watch owner by ID of API key, so watches are kept when key is rotated
+func webhookOwner(c echo.Context) string {
+	key := api.RequestAPIKey(c)
+	if key == nil {
+		return ""
+	}
+	return key.ID.Hex()
+}
+
+// MigrateWebhookOwners move watches which are owned by hash of raw API key, as they were before owner became
+// key ID, to ID of the key. Watches of keys rotated before migration can't be matched and stay orphaned
+func (s *Server) MigrateWebhookOwners(ctx context.Context) error {
+	keys, _, err := s.dbClient.APIKeys(ctx, &types.APIKeysFilter{})
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		moved, err := s.dbClient.ReassignWebhookOwner(ctx, key.Hash, key.ID.Hex())
+		if err != nil {
+			return err
+		}
+		if moved > 0 {
+			s.logger.Info("Migrated webhook watches to API key ID", zap.String("keyId", key.ID.Hex()), zap.Int64("watches", moved))
+		}
+	}
+	return nil
+}
+
+// webhookLookupIP resolve webhook target host, replaced in tests
+var webhookLookupIP = net.DefaultResolver.LookupIPAddr
+
+// checkWebhookTarget reject hosts resolved to internal addresses, deliveries check it again when dialing
+// since DNS records may change later
+func checkWebhookTarget(ctx context.Context, host string) error {
+	ctx, cancel := context.WithTimeout(ctx, webhookLookupTimeout)
+	defer cancel()
+	addrs, err := webhookLookupIP(ctx, host)
+	if err != nil {
+		return fmt.Errorf("cannot resolve %s: %v", host, err)
+	}
+	for _, addr := range addrs {
+		if !utils.IsPublicIP(addr.IP) {
+			return fmt.Errorf("%s is resolved to non public address %s", host, addr.IP)
+		}
+	}
+	return nil
+}
+
+func newWebhookWatch(ctx context.Context, req *webhookWatchRequest) (*types.WebhookWatch, error) {
+	target, err := url.Parse(req.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Hostname() == "" {
+		return nil, fmt.Errorf("invalid url %s", req.URL)
+	}
+	if err := checkWebhookTarget(ctx, target.Hostname()); err != nil {
+		return nil, err
+	}
+	watch := &types.WebhookWatch{Type: req.Type, URL: req.URL, Topic: strings.ToLower(req.Topic)}
+	if req.Address != "" {
+		watch.Address = common.HexToAddress(req.Address).Hex()
+	}
+	if req.Contract != "" {
+		watch.Contract = common.HexToAddress(req.Contract).Hex()
+	}
+	switch req.Type {
+	case types.WebhookWatchIncoming, types.WebhookWatchOutgoing:
+		if watch.Address == "" {
+			return nil, fmt.Errorf("address is required for %s watch", req.Type)
+		}
+	case types.WebhookWatchTokenTransfer, types.WebhookWatchContractEvent:
+		if watch.Contract == "" {
+			return nil, fmt.Errorf("contract is required for %s watch", req.Type)
+		}
+	default:
+		return nil, fmt.Errorf("unknown watch type %s", req.Type)
+	}
+	secret := make([]byte, webhookSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	watch.Secret = hex.EncodeToString(secret)
+	return watch, nil
+}
+
+// CreateWebhookWatch register a watch for API key holder, the signing secret is only returned here
+func (s *Server) CreateWebhookWatch(c echo.Context) error {
+	ctx := context.Background()
+	owner := webhookOwner(c)
+	if owner == "" {
+		return api.Unauthorized.Build(c)
+	}
+	var req *webhookWatchRequest
+	if err := c.Bind(&req); err != nil || req == nil {
+		return api.Invalid.Build(c)
+	}
+	watch, err := newWebhookWatch(ctx, req)
+	if err != nil {
+		s.logger.Debug("Invalid webhook watch", zap.Error(err))
+		return api.Invalid.Build(c)
+	}
+	watches, err := s.dbClient.WebhookWatches(ctx, owner)
+	if err != nil {
+		s.logger.Warn("Cannot get webhook watches", zap.Error(err))
+		return api.InternalServer.Build(c)
+	}
+	if len(watches) >= maxWebhookWatches {
+		return api.Invalid.Build(c)
+	}
+	watch.Owner = owner
+	watch.CreatedAt = time.Now()
+	if err := s.dbClient.InsertWebhookWatch(ctx, watch); err != nil {
+		s.logger.Warn("Cannot insert webhook watch", zap.Error(err))
+		return api.InternalServer.Build(c)
+	}
+	return api.OK.SetData(watch).Build(c)
+}
+
+func (s *Server) WebhookWatches(c echo.Context) error {
+	ctx := context.Background()
+	owner := webhookOwner(c)
+	if owner == "" {
+		return api.Unauthorized.Build(c)
+	}
+	watches, err := s.dbClient.WebhookWatches(ctx, owner)
+	if err != nil {
+		s.logger.Warn("Cannot get webhook watches", zap.Error(err))
+		return api.InternalServer.Build(c)
+	}
+	for _, w := range watches {
+		w.Secret = ""
+	}
+	return api.OK.SetData(watches).Build(c)
+}
+
+func (s *Server) RemoveWebhookWatch(c echo.Context) error {
+	ctx := context.Background()
+	owner := webhookOwner(c)
+	if owner == "" {
+		return api.Unauthorized.Build(c)
+	}
+	removed, err := s.dbClient.RemoveWebhookWatch(ctx, owner, c.Param("id"))
+	if err != nil {
+		s.logger.Warn("Cannot remove webhook watch", zap.Error(err))
+		return api.InternalServer.Build(c)
+	}
+	if !removed {
+		return api.Invalid.Build(c)
+	}
+	return api.OK.Build(c)
+}
+
+// WebhookDeliveries is the delivery log of API key holder, filtered by watchId and status
+func (s *Server) WebhookDeliveries(c echo.Context) error {
+	ctx := context.Background()
+	owner := webhookOwner(c)
+	if owner == "" {
+		return api.Unauthorized.Build(c)
+	}
+	pagination, page, limit := getPagingOption(c)
+	if pagination == nil {
+		pagination = &types.Pagination{}
+		pagination.Sanitize()
+		page, limit = 1, pagination.Limit
+	}
+	deliveries, total, err := s.dbClient.WebhookDeliveries(ctx, &types.WebhookDeliveriesFilter{
+		Pagination: pagination,
+		Owner:      owner,
+		WatchID:    c.QueryParam("watchId"),
+		Status:     c.QueryParam("status"),
+	})
+	if err != nil {
+		s.logger.Warn("Cannot get webhook deliveries", zap.Error(err))
+		return api.InternalServer.Build(c)
+	}
+	return api.OK.SetData(PagingResponse{
+		Page:  page,
+		Limit: limit,
+		Total: total,
+		Data:  deliveries,
+	}).Build(c)
+}
+
+// RetryWebhookDelivery send a delivery again, mostly dead letters after target is fixed
+func (s *Server) RetryWebhookDelivery(c echo.Context) error {
+	ctx := context.Background()
+	owner := webhookOwner(c)
+	if owner == "" {
+		return api.Unauthorized.Build(c)
+	}
+	ok, err := s.dbClient.RetryWebhookDelivery(ctx, owner, c.Param("id"))
+	if err != nil {
+		s.logger.Warn("Cannot retry webhook delivery", zap.Error(err))
+		return api.InternalServer.Build(c)
+	}
+	if !ok {
+		return api.Invalid.Build(c)
+	}
+	return api.OK.Build(c)
+}
+
+// enqueueWebhooks match imported block against all watches and enqueue deliveries
+func (s *infoServer) enqueueWebhooks(ctx context.Context, block *types.Block) error {
+	watches, err := s.dbClient.WebhookWatches(ctx, "")
+	if err != nil || len(watches) == 0 {
+		return err
+	}
+	now := time.Now()
+	var deliveries []*types.WebhookDelivery
+	for _, watch := range watches {
+		for _, event := range webhookEvents(watch, block) {
+			deliveries = append(deliveries, newWebhookDelivery(watch.Owner, event, now))
+		}
+	}
+	return s.dbClient.InsertWebhookDeliveries(ctx, deliveries)
+}
+
+// revertWebhooks notify events of a replaced block again with removed flag, if they are not in new block
+func (s *infoServer) revertWebhooks(ctx context.Context, block *types.Block) error {
+	deliveries, err := s.dbClient.WebhookDeliveriesByHeight(ctx, block.Height)
+	if err != nil || len(deliveries) == 0 {
+		return err
+	}
+	txs := make(map[string]bool, len(block.Txs))
+	for _, tx := range block.Txs {
+		txs[tx.Hash] = true
+	}
+	now := time.Now()
+	var removed []*types.WebhookDelivery
+	for _, d := range deliveries {
+		if d.Event.BlockHash == block.Hash && txs[d.Event.TxHash] {
+			continue
+		}
+		event := *d.Event
+		event.ID += webhookRemovedSuffix
+		event.Removed = true
+		removed = append(removed, newWebhookDelivery(d.Owner, &event, now))
+	}
+	return s.dbClient.InsertWebhookDeliveries(ctx, removed)
+}
+
+func newWebhookDelivery(owner string, event *types.WebhookEvent, now time.Time) *types.WebhookDelivery {
+	return &types.WebhookDelivery{
+		Owner:         owner,
+		WatchID:       event.WatchID,
+		EventID:       event.ID,
+		Event:         event,
+		Status:        types.WebhookStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// webhookEvents return events of block matched by watch, event ID is unique per watch, block and tx or log
+func webhookEvents(watch *types.WebhookWatch, block *types.Block) []*types.WebhookEvent {
+	watchID := watch.ID.Hex()
+	newEvent := func(tx *types.Transaction, suffix string) *types.WebhookEvent {
+		return &types.WebhookEvent{
+			ID:          fmt.Sprintf("%s:%s:%s:%s", watchID, block.Hash, tx.Hash, suffix),
+			WatchID:     watchID,
+			Type:        watch.Type,
+			BlockHeight: block.Height,
+			BlockHash:   block.Hash,
+			TxHash:      tx.Hash,
+			Status:      tx.Status,
+			Time:        tx.Time,
+		}
+	}
+	var events []*types.WebhookEvent
+	for _, tx := range block.Txs {
+		switch watch.Type {
+		case types.WebhookWatchIncoming:
+			// only successful deposits
+			if strings.EqualFold(tx.To, watch.Address) && tx.Status == 1 && tx.Value != "" && tx.Value != "0" {
+				event := newEvent(tx, "tx")
+				event.From, event.To, event.Value = tx.From, tx.To, tx.Value
+				events = append(events, event)
+			}
+		case types.WebhookWatchOutgoing:
+			if strings.EqualFold(tx.From, watch.Address) {
+				event := newEvent(tx, "tx")
+				event.From, event.To, event.Value = tx.From, tx.To, tx.Value
+				events = append(events, event)
+			}
+		case types.WebhookWatchTokenTransfer, types.WebhookWatchContractEvent:
+			for i := range tx.Logs {
+				log := &tx.Logs[i]
+				if !strings.EqualFold(log.Address, watch.Contract) || len(log.Topics) == 0 {
+					continue
+				}
+				event := newEvent(tx, fmt.Sprintf("%d", log.Index))
+				event.Contract, event.LogIndex = log.Address, log.Index
+				if watch.Type == types.WebhookWatchContractEvent {
+					if watch.Topic != "" && !strings.EqualFold(log.Topics[0], watch.Topic) {
+						continue
+					}
+					event.MethodName, event.Topics, event.Data = log.MethodName, log.Topics, log.Data
+					events = append(events, event)
+					continue
+				}
+				if log.Topics[0] != cfg.KRCTransferTopic {
+					continue
+				}
+				event.From, _ = log.Arguments["from"].(string)
+				event.To, _ = log.Arguments["to"].(string)
+				event.Value, _ = log.Arguments["value"].(string)
+				if watch.Address != "" && !strings.EqualFold(event.From, watch.Address) && !strings.EqualFold(event.To, watch.Address) {
+					continue
+				}
+				events = append(events, event)
+			}
+		}
+	}
+	return events
+}
@@ -0,0 +1,87 @@
+// Package server
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kardiachain/kardia-explorer-backend/cfg"
+	"github.com/kardiachain/kardia-explorer-backend/types"
+)
+
+func Test_webhookEvents(t *testing.T) {
+	exchange := "0x1111111111111111111111111111111111111111"
+	token := "0x2222222222222222222222222222222222222222"
+	block := &types.Block{
+		Height: 100,
+		Hash:   "0xblock",
+		Txs: []*types.Transaction{
+			{Hash: "0xdeposit", From: "0xsender", To: exchange, Value: "10", Status: 1},
+			{Hash: "0xfailed", From: "0xsender", To: exchange, Value: "10", Status: 0},
+			{
+				Hash: "0xtoken", From: exchange, To: token, Value: "0", Status: 1,
+				Logs: []types.Log{
+					{Address: token, Index: 3, Topics: []string{cfg.KRCTransferTopic}, Arguments: map[string]interface{}{"from": exchange, "to": "0xother", "value": "7"}},
+					{Address: token, Index: 4, Topics: []string{"0xapproval"}},
+				},
+			},
+		},
+	}
+	watch := &types.WebhookWatch{ID: primitive.NewObjectID(), Type: types.WebhookWatchIncoming, Address: exchange}
+	events := webhookEvents(watch, block)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "0xdeposit", events[0].TxHash)
+	assert.Equal(t, "10", events[0].Value)
+	assert.Equal(t, watch.ID.Hex()+":0xblock:0xdeposit:tx", events[0].ID)
+
+	watch.Type = types.WebhookWatchOutgoing
+	assert.Len(t, webhookEvents(watch, block), 1)
+
+	watch = &types.WebhookWatch{ID: primitive.NewObjectID(), Type: types.WebhookWatchTokenTransfer, Contract: token, Address: exchange}
+	events = webhookEvents(watch, block)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "7", events[0].Value)
+	assert.Equal(t, uint(3), events[0].LogIndex)
+	watch.Address = "0x3333333333333333333333333333333333333333"
+	assert.Len(t, webhookEvents(watch, block), 0)
+
+	watch = &types.WebhookWatch{ID: primitive.NewObjectID(), Type: types.WebhookWatchContractEvent, Contract: token}
+	assert.Len(t, webhookEvents(watch, block), 2)
+	watch.Topic = "0xapproval"
+	assert.Len(t, webhookEvents(watch, block), 1)
+}
+
+func Test_newWebhookWatch(t *testing.T) {
+	webhookLookupIP = func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		if ip := net.ParseIP(host); ip != nil {
+			return []net.IPAddr{{IP: ip}}, nil
+		}
+		if host == "internal.example.com" {
+			return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}, {IP: net.ParseIP("10.0.0.1")}}, nil
+		}
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}
+	defer func() { webhookLookupIP = net.DefaultResolver.LookupIPAddr }()
+	ctx := context.Background()
+
+	watch, err := newWebhookWatch(ctx, &webhookWatchRequest{Type: types.WebhookWatchIncoming, Address: "0x1111111111111111111111111111111111111111", URL: "https://example.com/hook"})
+	assert.Nil(t, err)
+	assert.Len(t, watch.Secret, 2*webhookSecretLength)
+
+	_, err = newWebhookWatch(ctx, &webhookWatchRequest{Type: types.WebhookWatchIncoming, URL: "https://example.com/hook"})
+	assert.NotNil(t, err)
+	_, err = newWebhookWatch(ctx, &webhookWatchRequest{Type: types.WebhookWatchTokenTransfer, Contract: "0x1111111111111111111111111111111111111111", URL: "ftp://example.com"})
+	assert.NotNil(t, err)
+	_, err = newWebhookWatch(ctx, &webhookWatchRequest{Type: "unknown", URL: "https://example.com/hook"})
+	assert.NotNil(t, err)
+
+	// internal targets
+	for _, target := range []string{"http://127.0.0.1:8080/hook", "http://169.254.169.254/latest", "http://[::1]/hook", "http://0.0.0.0/hook", "https://internal.example.com/hook"} {
+		_, err = newWebhookWatch(ctx, &webhookWatchRequest{Type: types.WebhookWatchIncoming, Address: "0x1111111111111111111111111111111111111111", URL: target})
+		assert.NotNil(t, err, target)
+	}
+}
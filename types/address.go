@@ -27,6 +27,8 @@ type Address struct {
 	TokenTxCount    int `json:"tokenTxCount,omitempty" bson:"tokenTxCount"`
 
 	UpdatedAt int64 `json:"updatedAt" bson:"updatedAt"`
+
+	SearchNames []string `json:"-" bson:"searchNames"` // lower case names, set by db on write
 }
 
 type UpdateAddress struct {
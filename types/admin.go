@@ -0,0 +1,75 @@
+// Package types
+package types
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	AdminRoleLabels    = "labels"    // address names
+	AdminRoleContracts = "contracts" // contract verification and ABIs
+	AdminRoleOps       = "ops"       // nodes, supplies, reloads, API keys, audit log and rate limit metrics
+)
+
+var AdminRoles = []string{AdminRoleLabels, AdminRoleContracts, AdminRoleOps}
+
+// Operator is who an admin token is issued to, roles are fixed when token is signed
+type Operator struct {
+	ID        string   `json:"jti,omitempty"` // token ID, tokens are revoked by it
+	Name      string   `json:"sub"`
+	Roles     []string `json:"roles"`
+	IssuedAt  int64    `json:"iat"`
+	ExpiresAt int64    `json:"exp"`
+}
+
+// HasRole return true if operator has any of roles
+func (o *Operator) HasRole(roles ...string) bool {
+	for _, granted := range o.Roles {
+		for _, role := range roles {
+			if granted == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RevokedAdminToken is an admin token which is rejected before it expires
+type RevokedAdminToken struct {
+	ID        string    `json:"jti" bson:"_id"`
+	RevokedBy string    `json:"revokedBy" bson:"revokedBy"`
+	RevokedAt time.Time `json:"revokedAt" bson:"revokedAt"`
+}
+
+// AuditChange is a field of payload which differs from the record before the call
+type AuditChange struct {
+	Field string      `json:"field" bson:"field"`
+	From  interface{} `json:"from" bson:"from"`
+	To    interface{} `json:"to" bson:"to"`
+}
+
+// AuditLog is a mutating admin call, Diff is only filled by handlers which update an existing record
+type AuditLog struct {
+	ID       primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Operator string             `json:"operator" bson:"operator"`
+	Roles    []string           `json:"roles" bson:"roles"`
+	Method   string             `json:"method" bson:"method"`
+	Route    string             `json:"route" bson:"route"` // route path, e.g. /contracts/:address
+	Path     string             `json:"path" bson:"path"`
+	Payload  interface{}        `json:"payload,omitempty" bson:"payload,omitempty"`
+	Diff     []*AuditChange     `json:"diff,omitempty" bson:"diff,omitempty"`
+	Status   int                `json:"status" bson:"status"`
+	IP       string             `json:"ip" bson:"ip"`
+	Time     time.Time          `json:"time" bson:"time"`
+}
+
+type AuditLogsFilter struct {
+	Pagination *Pagination `bson:"-"`
+
+	Operator string    `bson:"operator,omitempty"`
+	Route    string    `bson:"route,omitempty"`
+	From     time.Time `bson:"-"`
+	To       time.Time `bson:"-"`
+}
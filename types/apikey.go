@@ -0,0 +1,71 @@
+// Package types
+package types
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	APIKeyTierFree     = "free"
+	APIKeyTierStandard = "standard"
+	APIKeyTierPartner  = "partner"
+	APIKeyTierInternal = "internal"
+)
+
+// APIKeyTier scale rate limit budgets of key holders and cap their requests per UTC day
+type APIKeyTier struct {
+	Name           string `json:"name"`
+	RateMultiplier int64  `json:"rateMultiplier"`
+	DailyQuota     int64  `json:"dailyQuota"` // zero is unlimited
+	Unlimited      bool   `json:"unlimited"`  // neither rate limited nor counted against quota
+}
+
+var APIKeyTiers = map[string]*APIKeyTier{
+	APIKeyTierFree:     {Name: APIKeyTierFree, RateMultiplier: 2, DailyQuota: 10000},
+	APIKeyTierStandard: {Name: APIKeyTierStandard, RateMultiplier: 10, DailyQuota: 100000},
+	APIKeyTierPartner:  {Name: APIKeyTierPartner, RateMultiplier: 50, DailyQuota: 1000000},
+	APIKeyTierInternal: {Name: APIKeyTierInternal, Unlimited: true},
+}
+
+// APIKeyTierByName fallback to free tier for unknown names
+func APIKeyTierByName(name string) *APIKeyTier {
+	if tier, ok := APIKeyTiers[name]; ok {
+		return tier
+	}
+	return APIKeyTiers[APIKeyTierFree]
+}
+
+// APIKey only keeps sha256 of the key, Key is filled on creation and rotation so it's shown to owner once
+type APIKey struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Hash        string             `json:"-" bson:"hash"`
+	Prefix      string             `json:"prefix" bson:"prefix"` // first characters of key, to tell keys apart
+	Key         string             `json:"key,omitempty" bson:"-"`
+	Owner       string             `json:"owner" bson:"owner"`
+	Email       string             `json:"email,omitempty" bson:"email,omitempty"`
+	Description string             `json:"description,omitempty" bson:"description,omitempty"`
+	Tier        string             `json:"tier" bson:"tier"`
+	Revoked     bool               `json:"revoked" bson:"revoked"`
+	CreatedAt   time.Time          `json:"createdAt" bson:"createdAt"`
+	RotatedAt   *time.Time         `json:"rotatedAt,omitempty" bson:"rotatedAt,omitempty"`
+	RevokedAt   *time.Time         `json:"revokedAt,omitempty" bson:"revokedAt,omitempty"`
+}
+
+type APIKeysFilter struct {
+	Pagination *Pagination `bson:"-"`
+
+	Owner   string `bson:"owner,omitempty"`
+	Tier    string `bson:"tier,omitempty"`
+	Revoked *bool  `bson:"revoked,omitempty"`
+}
+
+// APIKeyUsage is requests of a key in a UTC day, Routes is keyed by method and route path
+type APIKeyUsage struct {
+	KeyID    string           `json:"keyId" bson:"keyId"`
+	Day      string           `json:"day" bson:"day"` // 2006-01-02
+	Requests int64            `json:"requests" bson:"requests"`
+	Limited  int64            `json:"limited" bson:"limited"`
+	Routes   map[string]int64 `json:"routes" bson:"routes"`
+}
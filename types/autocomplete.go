@@ -0,0 +1,80 @@
+// Package types
+package types
+
+import (
+	"strings"
+)
+
+const (
+	AutocompleteToken     = "token"
+	AutocompleteContract  = "contract"
+	AutocompleteAddress   = "address"
+	AutocompleteValidator = "validator"
+)
+
+// AutocompleteEntry is a named address in typeahead index, Score is its popularity
+type AutocompleteEntry struct {
+	Address  string  `json:"address"`
+	Type     string  `json:"type"`
+	Name     string  `json:"name,omitempty"` // token, contract or validator name
+	Symbol   string  `json:"symbol,omitempty"`
+	Label    string  `json:"label,omitempty"` // address name set by admins
+	Logo     string  `json:"logo,omitempty"`
+	Verified bool    `json:"verified"`
+	Score    float64 `json:"score"`
+}
+
+// Terms are indexed names of entry
+func (e *AutocompleteEntry) Terms() []string {
+	var terms []string
+	for _, t := range []string{e.Name, e.Symbol, e.Label} {
+		if strings.TrimSpace(t) != "" {
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
+
+// NewAutocompleteEntry merge records of an address into an entry, any of them can be nil.
+// It returns nil if the address has no name to index
+func NewAutocompleteEntry(addr *Address, smc *Contract, val *Validator) *AutocompleteEntry {
+	e := &AutocompleteEntry{Type: AutocompleteAddress}
+	if addr != nil {
+		e.Address, e.Label, e.Name, e.Symbol, e.Logo = addr.Address, addr.Name, addr.TokenName, addr.TokenSymbol, addr.Logo
+		e.Score += float64(10*addr.HolderCount + addr.TxCount + addr.TokenTxCount)
+		switch {
+		case strings.HasPrefix(addr.KrcTypes, "KRC") || addr.TokenSymbol != "":
+			e.Type = AutocompleteToken
+		case addr.IsContract || addr.KrcTypes != "":
+			e.Type = AutocompleteContract
+		}
+	}
+	if smc != nil {
+		e.Address = smc.Address
+		if e.Name == "" {
+			e.Name = smc.Name
+		}
+		if e.Logo == "" {
+			e.Logo = smc.Logo
+		}
+		if e.Type == AutocompleteAddress {
+			e.Type = AutocompleteContract
+			if strings.HasPrefix(smc.Type, "KRC") {
+				e.Type = AutocompleteToken
+			}
+		}
+		e.Verified = smc.IsVerified
+	}
+	if val != nil {
+		e.Address, e.Type, e.Name = val.SmcAddress, AutocompleteValidator, val.Name
+		e.Score += float64(10 * val.TotalDelegators)
+	}
+	// verified contracts and validators are what people usually look for
+	if e.Verified || e.Type == AutocompleteValidator {
+		e.Score += 1000
+	}
+	if e.Address == "" || len(e.Terms()) == 0 {
+		return nil
+	}
+	return e
+}
@@ -66,6 +66,26 @@ type Block struct {
 
 	Txs      []*Transaction `json:"txs,omitempty" bson:"-"`
 	Receipts []*Receipt     `json:"receipts,omitempty" bson:"-"`
+
+	// StatsPending are stats which block is not counted in yet, e.g. import failed before counting it.
+	// Blocks imported before it existed don't have it, they are counted in all stats
+	StatsPending []string `json:"-" bson:"statsPending,omitempty"`
+}
+
+// Stats which blocks are counted in while importing
+const (
+	BlockStatsProduction = "production"
+	BlockStatsNetwork    = "network"
+)
+
+// IsCountedIn is true when block was added to stats, so it has to be removed from them before re-importing it
+func (b *Block) IsCountedIn(stats string) bool {
+	for _, pending := range b.StatsPending {
+		if pending == stats {
+			return false
+		}
+	}
+	return true
 }
 
 type VerifyBlockParam struct {
@@ -14,6 +14,8 @@ type Contract struct {
 	Info         string `json:"info" bson:"info"`
 	Logo         string `json:"logo" bson:"logo"`
 	IsVerified   bool   `json:"isVerified" bson:"isVerified"`
+
+	SearchNames []string `json:"-" bson:"searchNames"` // lower case name, set by db on write
 }
 
 type ContractABI struct {
@@ -0,0 +1,97 @@
+// Package types
+package types
+
+// Etherscan compatible models, all numbers are encoded as decimal strings like Etherscan does
+type EtherscanResponse struct {
+	Status  string      `json:"status"`
+	Message string      `json:"message"`
+	Result  interface{} `json:"result"`
+}
+
+type EtherscanBalance struct {
+	Account string `json:"account"`
+	Balance string `json:"balance"`
+}
+
+type EtherscanTx struct {
+	BlockNumber       string `json:"blockNumber"`
+	TimeStamp         string `json:"timeStamp"`
+	Hash              string `json:"hash"`
+	Nonce             string `json:"nonce"`
+	BlockHash         string `json:"blockHash"`
+	TransactionIndex  string `json:"transactionIndex"`
+	From              string `json:"from"`
+	To                string `json:"to"`
+	Value             string `json:"value"`
+	Gas               string `json:"gas"`
+	GasPrice          string `json:"gasPrice"`
+	IsError           string `json:"isError"`
+	TxReceiptStatus   string `json:"txreceipt_status"`
+	Input             string `json:"input"`
+	ContractAddress   string `json:"contractAddress"`
+	CumulativeGasUsed string `json:"cumulativeGasUsed"`
+	GasUsed           string `json:"gasUsed"`
+	Confirmations     string `json:"confirmations"`
+}
+
+// EtherscanTokenTransfer is used for both tokentx and tokennfttx, NFT transfers have TokenID instead of Value
+type EtherscanTokenTransfer struct {
+	BlockNumber      string `json:"blockNumber"`
+	TimeStamp        string `json:"timeStamp"`
+	Hash             string `json:"hash"`
+	Nonce            string `json:"nonce"`
+	BlockHash        string `json:"blockHash"`
+	From             string `json:"from"`
+	ContractAddress  string `json:"contractAddress"`
+	To               string `json:"to"`
+	Value            string `json:"value,omitempty"`
+	TokenID          string `json:"tokenID,omitempty"`
+	TokenName        string `json:"tokenName"`
+	TokenSymbol      string `json:"tokenSymbol"`
+	TokenDecimal     string `json:"tokenDecimal"`
+	TransactionIndex string `json:"transactionIndex"`
+	Gas              string `json:"gas"`
+	GasPrice         string `json:"gasPrice"`
+	GasUsed          string `json:"gasUsed"`
+	Input            string `json:"input"`
+	Confirmations    string `json:"confirmations"`
+}
+
+type EtherscanSourceCode struct {
+	SourceCode           string `json:"SourceCode"`
+	ABI                  string `json:"ABI"`
+	ContractName         string `json:"ContractName"`
+	CompilerVersion      string `json:"CompilerVersion"`
+	OptimizationUsed     string `json:"OptimizationUsed"`
+	Runs                 string `json:"Runs"`
+	ConstructorArguments string `json:"ConstructorArguments"`
+	EVMVersion           string `json:"EVMVersion"`
+	Library              string `json:"Library"`
+	LicenseType          string `json:"LicenseType"`
+	Proxy                string `json:"Proxy"`
+	Implementation       string `json:"Implementation"`
+	SwarmSource          string `json:"SwarmSource"`
+}
+
+type EtherscanTxStatus struct {
+	IsError        string `json:"isError"`
+	ErrDescription string `json:"errDescription"`
+}
+
+type EtherscanReceiptStatus struct {
+	Status string `json:"status"`
+}
+
+// EtherscanLog follows eth_getLogs, numbers are hex encoded
+type EtherscanLog struct {
+	Address          string   `json:"address"`
+	Topics           []string `json:"topics"`
+	Data             string   `json:"data"`
+	BlockNumber      string   `json:"blockNumber"`
+	TimeStamp        string   `json:"timeStamp"`
+	GasPrice         string   `json:"gasPrice"`
+	GasUsed          string   `json:"gasUsed"`
+	LogIndex         string   `json:"logIndex"`
+	TransactionHash  string   `json:"transactionHash"`
+	TransactionIndex string   `json:"transactionIndex"`
+}
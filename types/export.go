@@ -0,0 +1,14 @@
+// Package types
+package types
+
+import (
+	"time"
+)
+
+// ExportFilter select items of an address or a contract in [From, To], exports are always ascending by time
+type ExportFilter struct {
+	Address  string
+	Contract string
+	From     time.Time
+	To       time.Time
+}
@@ -12,6 +12,17 @@ const (
 type Pagination struct {
 	Skip  int
 	Limit int
+	// Cursor is used instead of Skip when set, to query by range on sort key
+	Cursor *Cursor
+}
+
+// Cursor is the sort key of last seen item of a list. Key break ties of items which have
+// same Height/Time, it's tx hash or document id. Prev cursor fetch items before it
+type Cursor struct {
+	Height uint64 `json:"h,omitempty"`
+	Time   int64  `json:"t,omitempty"` // unix milliseconds, as stored in mongo
+	Key    string `json:"k,omitempty"`
+	Prev   bool   `json:"p,omitempty"`
 }
 
 func (f *Pagination) Sanitize() {
@@ -57,24 +68,56 @@ type ContractsFilter struct {
 type InternalTxsFilter struct {
 	Pagination *Pagination `bson:"-"`
 
-	TransactionHash string `bson:"txHash,omitempty"`
-	Contract        string `bson:"contractAddress,omitempty"`
-	Address         string `bson:"address,omitempty"`
+	TransactionHash  string   `bson:"txHash,omitempty"`
+	Contract         string   `bson:"contractAddress,omitempty"`
+	Contracts        []string `bson:"-"` // transfers of any of these contracts
+	ExcludeContracts []string `bson:"-"` // skip transfers of these contracts
+	Address          string   `bson:"address,omitempty"`
+	Ascending        bool     `bson:"-"`
+}
+
+type TxsByAddressFilter struct {
+	Pagination *Pagination
+
+	Address   string
+	FromBlock uint64
+	ToBlock   uint64
+	Ascending bool
 }
 
+// TxsFilter of txs listing, block range is resolved to time range of its blocks. Zero values are not filtered
 type TxsFilter struct {
-	Pagination
+	Pagination *Pagination
 	TimeFilter
+
+	FromBlock uint64
+	ToBlock   uint64
+	Status    *uint
+	Type      string
+	To        string
+	Method    string  // method name, or 4 bytes selector as 0x prefixed hex
+	MinValue  float64 // in KAI
+	MaxValue  float64
+}
+
+// IsEmpty is true when only paging is set, so latest txs can be served from cache
+func (f *TxsFilter) IsEmpty() bool {
+	return f.FromTime.IsZero() && f.ToTime.IsZero() && f.FromBlock == 0 && f.ToBlock == 0 && f.Status == nil &&
+		f.Type == "" && f.To == "" && f.Method == "" && f.MinValue == 0 && f.MaxValue == 0
 }
 
+// BlocksFilter of blocks listing, time range is resolved to height range of blocks in it. Zero values are not filtered
 type BlocksFilter struct {
-	Pagination
+	Pagination *Pagination
 	TimeFilter
+
+	FromHeight uint64
+	ToHeight   uint64
+	Proposer   string
 }
 
-func (f *TxsFilter) Sanitize() {
-	f.Pagination.Sanitize()
-	f.TimeFilter.Sanitize()
+func (f *BlocksFilter) IsEmpty() bool {
+	return f.FromTime.IsZero() && f.ToTime.IsZero() && f.FromHeight == 0 && f.ToHeight == 0 && f.Proposer == ""
 }
 
 type HolderFilter struct {
@@ -90,4 +133,9 @@ type EventsFilter struct {
 	ContractAddress string `bson:"address,omitempty"`
 	MethodName      string `bson:"methodName,omitempty"`
 	TxHash          string `bson:"transactionHash,omitempty"`
+
+	FromBlock uint64   `bson:"-"`
+	ToBlock   uint64   `bson:"-"`
+	Topics    []string `bson:"-"` // match topic at same position, empty topic match any
+	Ascending bool     `bson:"-"`
 }
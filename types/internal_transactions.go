@@ -1,11 +1,16 @@
 package types
 
-import "time"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
 // TokenTransfer represents a Transfer event emitted from an ERC20 or ERC721.
 type TokenTransfer struct {
-	TransactionHash string `json:"txHash" bson:"txHash"`
-	Contract        string `json:"contractAddress" bson:"contractAddress"`
+	ID              primitive.ObjectID `json:"-" bson:"_id,omitempty"`
+	TransactionHash string             `json:"txHash" bson:"txHash"`
+	Contract        string             `json:"contractAddress" bson:"contractAddress"`
 
 	From  string    `json:"from" bson:"from"`
 	To    string    `json:"to" bson:"to"`
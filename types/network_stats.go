@@ -0,0 +1,105 @@
+// Package types
+package types
+
+import (
+	"time"
+)
+
+const (
+	NetworkBucketDay  = "day"
+	NetworkBucketHour = "hour"
+)
+
+const (
+	ChartMetricTxs             = "txs"
+	ChartMetricActiveAddresses = "active_addresses"
+	ChartMetricNewAddresses    = "new_addresses"
+	ChartMetricAvgGasUsed      = "avg_gas_used"
+	ChartMetricBlockTime       = "block_time"
+	ChartMetricKAITransferred  = "kai_transferred"
+)
+
+var ChartMetrics = []string{
+	ChartMetricTxs, ChartMetricActiveAddresses, ChartMetricNewAddresses,
+	ChartMetricAvgGasUsed, ChartMetricBlockTime, ChartMetricKAITransferred,
+}
+
+// IsChartMetric is true for metrics which NetworkStats.Metric knows
+func IsChartMetric(metric string) bool {
+	for _, m := range ChartMetrics {
+		if m == metric {
+			return true
+		}
+	}
+	return false
+}
+
+// NetworkBucketDuration return length of bucket type, zero for unknown types
+func NetworkBucketDuration(bucketType string) time.Duration {
+	switch bucketType {
+	case NetworkBucketDay:
+		return 24 * time.Hour
+	case NetworkBucketHour:
+		return time.Hour
+	}
+	return 0
+}
+
+// NetworkStats is network activity in a day or an hour, Bucket is unix time of its start.
+// Counters are incremented while importing blocks, block time comes from the first and last blocks in bucket
+type NetworkStats struct {
+	BucketType      string    `json:"bucketType" bson:"bucketType"`
+	Bucket          int64     `json:"bucket" bson:"bucket"`
+	Blocks          uint64    `json:"blocks" bson:"blocks"`
+	Txs             uint64    `json:"txs" bson:"txs"`
+	GasUsed         uint64    `json:"gasUsed" bson:"gasUsed"`
+	KAITransferred  float64   `json:"kaiTransferred" bson:"kaiTransferred"` // value of successful txs, in KAI
+	ActiveAddresses uint64    `json:"activeAddresses" bson:"activeAddresses"`
+	NewAddresses    uint64    `json:"newAddresses" bson:"newAddresses"`
+	FromHeight      uint64    `json:"fromHeight" bson:"fromHeight"`
+	ToHeight        uint64    `json:"toHeight" bson:"toHeight"`
+	FirstBlockTime  time.Time `json:"firstBlockTime" bson:"firstBlockTime"`
+	LastBlockTime   time.Time `json:"lastBlockTime" bson:"lastBlockTime"`
+}
+
+// Metric return value of chart metric in this bucket
+func (s *NetworkStats) Metric(metric string) float64 {
+	switch metric {
+	case ChartMetricTxs:
+		return float64(s.Txs)
+	case ChartMetricActiveAddresses:
+		return float64(s.ActiveAddresses)
+	case ChartMetricNewAddresses:
+		return float64(s.NewAddresses)
+	case ChartMetricAvgGasUsed:
+		if s.Blocks == 0 {
+			return 0
+		}
+		return float64(s.GasUsed) / float64(s.Blocks)
+	case ChartMetricBlockTime:
+		if s.ToHeight <= s.FromHeight {
+			return 0
+		}
+		return s.LastBlockTime.Sub(s.FirstBlockTime).Seconds() / float64(s.ToHeight-s.FromHeight)
+	case ChartMetricKAITransferred:
+		return s.KAITransferred
+	}
+	return 0
+}
+
+type NetworkStatsFilter struct {
+	BucketType string
+	FromBucket int64
+	ToBucket   int64
+}
+
+type ChartPoint struct {
+	Time  int64   `json:"time"` // unix time of bucket start
+	Value float64 `json:"value"`
+}
+
+type Chart struct {
+	Metric   string        `json:"metric"`
+	Interval string        `json:"interval"`
+	Points   []*ChartPoint `json:"points"`
+}
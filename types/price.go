@@ -0,0 +1,14 @@
+// Package types
+package types
+
+import (
+	"time"
+)
+
+// PricePoint is price of a token in fiat currency, recorded whenever market info is refreshed
+type PricePoint struct {
+	Symbol   string    `json:"symbol" bson:"symbol"`
+	Currency string    `json:"currency" bson:"currency"`
+	Price    float64   `json:"price" bson:"price"`
+	Time     time.Time `json:"time" bson:"time"`
+}
@@ -0,0 +1,42 @@
+// Package types
+package types
+
+const (
+	ProductionBucketDay    = "day"
+	ProductionBucketWindow = "window"
+)
+
+// ProductionStats is blocks produced by a proposer in a bucket, which is either a day
+// (Bucket is unix time of start of day) or a block window (Bucket is window index)
+type ProductionStats struct {
+	ProposerAddress string `json:"proposerAddress" bson:"proposerAddress"`
+	BucketType      string `json:"bucketType" bson:"bucketType"`
+	Bucket          int64  `json:"bucket" bson:"bucket"`
+	FromHeight      uint64 `json:"fromHeight,omitempty" bson:"fromHeight,omitempty"`
+	ToHeight        uint64 `json:"toHeight,omitempty" bson:"toHeight,omitempty"`
+	BlocksProposed  uint64 `json:"blocksProposed" bson:"blocksProposed"`
+	TxsIncluded     uint64 `json:"txsIncluded" bson:"txsIncluded"`
+	GasUsed         uint64 `json:"gasUsed" bson:"gasUsed"`
+	Rewards         string `json:"rewards" bson:"-"`
+}
+
+// ProposerPerformance compare blocks proposed with expected slots from voting power
+type ProposerPerformance struct {
+	ProposerAddress       string  `json:"proposerAddress"`
+	Name                  string  `json:"name"`
+	VotingPowerPercentage string  `json:"votingPowerPercentage"`
+	BlocksProposed        uint64  `json:"blocksProposed"`
+	TxsIncluded           uint64  `json:"txsIncluded"`
+	GasUsed               uint64  `json:"gasUsed"`
+	Rewards               string  `json:"rewards"`
+	ExpectedBlocks        float64 `json:"expectedBlocks"`
+	Performance           float64 `json:"performance"`
+	UnderPerforming       bool    `json:"underPerforming"`
+}
+
+type ProductionStatsFilter struct {
+	ProposerAddress string
+	BucketType      string
+	FromBucket      int64
+	ToBucket        int64
+}
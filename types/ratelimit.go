@@ -0,0 +1,18 @@
+// Package types
+package types
+
+import "time"
+
+// RateLimit is a token bucket of Burst tokens, which is refilled evenly over Period
+type RateLimit struct {
+	Name   string
+	Burst  int64
+	Period time.Duration
+}
+
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int64
+	RetryAfter time.Duration // until next token, zero if allowed
+	Reset      time.Duration // until bucket is full again
+}
@@ -0,0 +1,39 @@
+// Package types
+package types
+
+import (
+	"time"
+)
+
+// RewardSnapshot is state of a delegation at snapshot time. EarnedReward is reward earned since
+// previous snapshot, which is the change of claimable reward plus rewards withdrawn in between
+type RewardSnapshot struct {
+	ValidatorSMCAddress string    `json:"validatorSMCAddress" bson:"validatorSMCAddress"`
+	DelegatorAddress    string    `json:"delegatorAddress" bson:"delegatorAddress"`
+	StakedAmount        string    `json:"stakedAmount" bson:"stakedAmount"`
+	Reward              string    `json:"reward" bson:"reward"`
+	WithdrawnReward     string    `json:"withdrawnReward" bson:"withdrawnReward"`
+	EarnedReward        string    `json:"earnedReward" bson:"earnedReward"`
+	Time                time.Time `json:"time" bson:"time"`
+}
+
+// RewardHistory summarize snapshots of a delegation or a validator in a time range
+type RewardHistory struct {
+	ValidatorSMCAddress string            `json:"validatorSMCAddress"`
+	ValidatorName       string            `json:"validatorName,omitempty"`
+	DelegatorAddress    string            `json:"delegatorAddress,omitempty"`
+	FromTime            time.Time         `json:"fromTime"`
+	ToTime              time.Time         `json:"toTime"`
+	TotalEarned         string            `json:"totalEarned"`
+	AverageStaked       string            `json:"averageStaked"`
+	RealizedAPR         string            `json:"realizedAPR"`
+	Snapshots           []*RewardSnapshot `json:"snapshots,omitempty"`
+}
+
+type RewardSnapshotsFilter struct {
+	Pagination *Pagination `bson:"-"`
+	TimeFilter *TimeFilter `bson:"-"`
+
+	ValidatorSMCAddress string `bson:"validatorSMCAddress,omitempty"`
+	DelegatorAddress    string `bson:"delegatorAddress,omitempty"`
+}
@@ -0,0 +1,41 @@
+// Package types
+package types
+
+import (
+	"time"
+)
+
+// Tags of known entities in rich list
+const (
+	AddressTagValidator = "validator"
+	AddressTagStaking   = "staking"
+	AddressTagTreasury  = "treasury"
+	AddressTagExchange  = "exchange"
+)
+
+// RichListEntry is an address ranked by balance refreshed from RPC. Changes are against daily snapshots,
+// they are nil when the address was not in the list at that time
+type RichListEntry struct {
+	Rank             uint64    `json:"rank" bson:"rank"`
+	Address          string    `json:"address" bson:"address"`
+	Name             string    `json:"name,omitempty" bson:"name,omitempty"`
+	IsContract       bool      `json:"isContract" bson:"isContract"`
+	Tag              string    `json:"tag,omitempty" bson:"tag,omitempty"`
+	BalanceString    string    `json:"balance" bson:"balanceString"`
+	BalanceFloat     float64   `json:"-" bson:"balanceFloat"`
+	SupplyPercentage float64   `json:"supplyPercentage" bson:"supplyPercentage"` // of circulating supply
+	RankChange24h    *int64    `json:"rankChange24h" bson:"rankChange24h"`       // positive when moving up
+	RankChange7d     *int64    `json:"rankChange7d" bson:"rankChange7d"`
+	BalanceChange24h *float64  `json:"balanceChange24h" bson:"balanceChange24h"` // in KAI
+	BalanceChange7d  *float64  `json:"balanceChange7d" bson:"balanceChange7d"`
+	UpdatedAt        time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// RichListSnapshot is rank of an address at the last refresh of a day, Day is unix time of its start
+type RichListSnapshot struct {
+	Day          int64     `json:"day" bson:"day"`
+	Address      string    `json:"address" bson:"address"`
+	Rank         uint64    `json:"rank" bson:"rank"`
+	BalanceFloat float64   `json:"balance" bson:"balanceFloat"`
+	ExpireAt     time.Time `json:"-" bson:"expireAt"`
+}
@@ -0,0 +1,50 @@
+// Package types
+package types
+
+const (
+	SimulateDelegate   = "delegate"
+	SimulateUndelegate = "undelegate"
+)
+
+// StakingSimulationRequest is actions of Delegator, it can only undelegate what it has staked
+type StakingSimulationRequest struct {
+	Delegator string              `json:"delegator"`
+	Actions   []*SimulationAction `json:"actions"`
+}
+
+// SimulationAction is a hypothetical delegation or undelegation, Validator is the validator SMC address
+type SimulationAction struct {
+	Type      string `json:"type"`
+	Validator string `json:"validator"`
+	Amount    string `json:"amount"`
+}
+
+type SimulatedValidator struct {
+	Rank                  int    `json:"rank"`
+	Address               string `json:"address"`
+	SmcAddress            string `json:"smcAddress"`
+	Name                  string `json:"name"`
+	StakedAmount          string `json:"stakedAmount"`
+	VotingPowerPercentage string `json:"votingPowerPercentage"`
+	CommissionRate        string `json:"commissionRate"`
+	Role                  int    `json:"role"`
+	PreviousRole          int    `json:"previousRole"`
+	EstimatedReward       string `json:"estimatedReward"`
+}
+
+type SimulatedDelegation struct {
+	ValidatorSMCAddress string `json:"validatorSMCAddress"`
+	StakedAmount        string `json:"stakedAmount"`
+	EstimatedReward     string `json:"estimatedReward"`
+}
+
+// StakingSimulation is the network state after applying simulation actions, rewards are estimated per day
+type StakingSimulation struct {
+	MaxProposers      int64                  `json:"maxProposers"`
+	TotalStakedAmount string                 `json:"totalStakedAmount"`
+	RewardPerBlock    string                 `json:"rewardPerBlock"`
+	BlocksPerDay      uint64                 `json:"blocksPerDay"`
+	Validators        []*SimulatedValidator  `json:"validators"`
+	Proposers         []string               `json:"proposers"`
+	Delegations       []*SimulatedDelegation `json:"delegations,omitempty"`
+}
@@ -0,0 +1,34 @@
+// Package types
+package types
+
+// SlashEvent is a slash event of validator, with the amount each delegator lost
+// calculated from their staked amount at the time we see the event
+type SlashEvent struct {
+	ValidatorAddress    string              `json:"validatorAddress" bson:"validatorAddress"`
+	ValidatorSMCAddress string              `json:"validatorSMCAddress" bson:"validatorSMCAddress"`
+	ValidatorName       string              `json:"validatorName" bson:"validatorName"`
+	Period              string              `json:"period" bson:"period"`
+	Fraction            string              `json:"fraction" bson:"fraction"`
+	Height              uint64              `json:"height" bson:"height"`
+	TotalSlashedAmount  string              `json:"totalSlashedAmount" bson:"totalSlashedAmount"`
+	Delegators          []*SlashedDelegator `json:"delegators,omitempty" bson:"delegators"`
+	UpdateTime          int64               `json:"updateTime" bson:"updateTime"`
+}
+
+type SlashedDelegator struct {
+	Address      string `json:"address" bson:"address"`
+	StakedAmount string `json:"stakedAmount" bson:"stakedAmount"`
+	AmountLost   string `json:"amountLost" bson:"amountLost"`
+}
+
+type SlashSummary struct {
+	TotalEvents        uint64 `json:"totalEvents"`
+	TotalSlashedAmount string `json:"totalSlashedAmount"`
+	LatestHeight       uint64 `json:"latestHeight"`
+}
+
+type SlashEventsFilter struct {
+	Pagination *Pagination `bson:"-"`
+
+	ValidatorAddress string `bson:"validatorAddress,omitempty"`
+}
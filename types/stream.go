@@ -0,0 +1,8 @@
+// Package types
+package types
+
+// StreamBlock is published once a block is imported, API replicas fan it out to WebSocket subscribers
+type StreamBlock struct {
+	Block *Block         `json:"block"` // without txs and receipts
+	Txs   []*Transaction `json:"txs"`
+}
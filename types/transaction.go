@@ -7,6 +7,34 @@ import (
 	"github.com/kardiachain/go-kardia/types"
 )
 
+const (
+	TxStatusFailed  uint = 0
+	TxStatusSuccess uint = 1
+)
+
+// Tx types are classified on import from decoded call, receipt logs and system contracts
+const (
+	TxTypeTransfer           = "transfer"
+	TxTypeContractCreation   = "contract_creation"
+	TxTypeContractCall       = "contract_call"
+	TxTypeTokenTransfer      = "token_transfer"
+	TxTypeNFTTransfer        = "nft_transfer"
+	TxTypeStake              = "stake"
+	TxTypeUnstake            = "unstake"
+	TxTypeWithdraw           = "withdraw" // unbonded stake
+	TxTypeClaimReward        = "claim_reward"
+	TxTypeValidator          = "validator" // create, start, stop or update a validator
+	TxTypeGovernanceProposal = "governance_proposal"
+	TxTypeGovernanceVote     = "governance_vote"
+	TxTypeFailed             = "failed" // reverted, whatever it tried to do
+)
+
+var TxTypes = []string{
+	TxTypeTransfer, TxTypeContractCreation, TxTypeContractCall, TxTypeTokenTransfer, TxTypeNFTTransfer,
+	TxTypeStake, TxTypeUnstake, TxTypeWithdraw, TxTypeClaimReward, TxTypeValidator,
+	TxTypeGovernanceProposal, TxTypeGovernanceVote, TxTypeFailed,
+}
+
 type Transaction struct {
 	BlockHash   string `json:"blockHash" bson:"blockHash"`
 	BlockNumber uint64 `json:"blockNumber" bson:"blockNumber"`
@@ -29,6 +57,11 @@ type Transaction struct {
 	TransactionIndex uint          `json:"transactionIndex"`
 	LogsBloom        types.Bloom   `json:"logsBloom"`
 	Root             string        `json:"root"`
+	Type             string        `json:"type,omitempty" bson:"type,omitempty"`
+
+	// filter keys of txs listing, filled on insert
+	ValueFloat float64 `json:"-" bson:"valueFloat"` // low precise value in KAI
+	MethodID   string  `json:"-" bson:"methodID,omitempty"`
 }
 
 type FunctionCall struct {
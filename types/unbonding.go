@@ -0,0 +1,24 @@
+// Package types
+package types
+
+// UnbondingEntry is an undelegated amount which can be withdrawn after CompletionTime (unix seconds)
+type UnbondingEntry struct {
+	DelegatorAddress    string `json:"delegatorAddress" bson:"delegatorAddress"`
+	ValidatorSMCAddress string `json:"validatorSMCAddress" bson:"validatorSMCAddress"`
+	Amount              string `json:"amount" bson:"amount"`
+	CompletionTime      int64  `json:"completionTime" bson:"completionTime"`
+	UpdateTime          int64  `json:"updateTime" bson:"updateTime"`
+
+	ValidatorName string `json:"validatorName,omitempty" bson:"-"`
+	Withdrawable  bool   `json:"withdrawable" bson:"-"`
+	RemainingTime int64  `json:"remainingTime" bson:"-"`
+}
+
+// UnbondingSchedule merge unbonding entries of a delegator across all validators, sorted by completion time
+type UnbondingSchedule struct {
+	DelegatorAddress   string            `json:"delegatorAddress"`
+	TotalUnbonding     string            `json:"totalUnbonding"`
+	TotalWithdrawable  string            `json:"totalWithdrawable"`
+	NextCompletionTime int64             `json:"nextCompletionTime"`
+	Entries            []*UnbondingEntry `json:"entries"`
+}
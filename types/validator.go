@@ -55,6 +55,9 @@ type Validator struct {
 	MaxChangeRate         string       `json:"maxChangeRate" bson:"maxChangeRate"`
 	SigningInfo           *SigningInfo `json:"signingInfo" bson:"signingInfo"`
 	Delegators            []*Delegator `json:"delegators,omitempty" bson:"delegators"`
+
+	SlashSummary             *SlashSummary `json:"slashSummary,omitempty" bson:"-"`
+	RecentCommissionIncrease bool          `json:"recentCommissionIncrease" bson:"-"`
 }
 
 type RPCValidator struct {
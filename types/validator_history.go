@@ -0,0 +1,37 @@
+// Package types
+package types
+
+import (
+	"time"
+)
+
+// ValidatorHistory is a version of validator info, a new version is recorded
+// whenever one of the tracked fields changed
+type ValidatorHistory struct {
+	ValidatorAddress    string                  `json:"validatorAddress" bson:"validatorAddress"`
+	ValidatorSMCAddress string                  `json:"validatorSMCAddress" bson:"validatorSMCAddress"`
+	Version             uint64                  `json:"version" bson:"version"`
+	BlockHeight         uint64                  `json:"blockHeight" bson:"blockHeight"`
+	Name                string                  `json:"name" bson:"name"`
+	CommissionRate      string                  `json:"commissionRate" bson:"commissionRate"`
+	MaxRate             string                  `json:"maxRate" bson:"maxRate"`
+	MaxChangeRate       string                  `json:"maxChangeRate" bson:"maxChangeRate"`
+	Status              uint8                   `json:"status" bson:"status"`
+	Role                int                     `json:"role" bson:"role"`
+	Jailed              bool                    `json:"jailed" bson:"jailed"`
+	Changes             []*ValidatorFieldChange `json:"changes,omitempty" bson:"changes"`
+	Time                time.Time               `json:"time" bson:"time"`
+}
+
+type ValidatorFieldChange struct {
+	Field string `json:"field" bson:"field"`
+	From  string `json:"from" bson:"from"`
+	To    string `json:"to" bson:"to"`
+}
+
+type ValidatorHistoryFilter struct {
+	Pagination *Pagination `bson:"-"`
+
+	ValidatorAddress string `bson:"validatorAddress,omitempty"`
+	Field            string `bson:"changes.field,omitempty"`
+}
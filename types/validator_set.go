@@ -0,0 +1,34 @@
+// Package types
+package types
+
+import "time"
+
+type ValidatorSetMember struct {
+	Address     string `json:"address" bson:"address"`
+	Name        string `json:"name,omitempty" bson:"-"`
+	VotingPower string `json:"votingPower" bson:"votingPower"`
+}
+
+type ValidatorPowerChange struct {
+	Address string `json:"address" bson:"address"`
+	From    string `json:"from" bson:"from"`
+	To      string `json:"to" bson:"to"`
+}
+
+// ValidatorSetChange is recorded when a block announce a different NextValidatorHash,
+// Height is the first block which is signed by the new set
+type ValidatorSetChange struct {
+	Height        uint64                  `json:"height" bson:"height"`
+	BlockHeight   uint64                  `json:"blockHeight" bson:"blockHeight"`
+	ValidatorHash string                  `json:"validatorHash" bson:"validatorHash"`
+	Validators    []*ValidatorSetMember   `json:"validators" bson:"validators"`
+	Joined        []*ValidatorSetMember   `json:"joined" bson:"joined"`
+	Left          []*ValidatorSetMember   `json:"left" bson:"left"`
+	PowerChanges  []*ValidatorPowerChange `json:"powerChanges" bson:"powerChanges"`
+	Time          time.Time               `json:"time" bson:"time"`
+}
+
+type ValidatorSetChangesFilter struct {
+	Pagination *Pagination `json:"pagination" bson:"-"`
+	Address    string      `json:"address" bson:"-"`
+}
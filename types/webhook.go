@@ -0,0 +1,76 @@
+// Package types
+package types
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	WebhookWatchIncoming      = "incoming"      // KAI received by Address
+	WebhookWatchOutgoing      = "outgoing"      // KAI sent from Address
+	WebhookWatchTokenTransfer = "tokenTransfer" // KRC20 transfers of Contract, optionally involving Address
+	WebhookWatchContractEvent = "contractEvent" // events of Contract, optionally filtered by first topic
+
+	WebhookStatusPending   = "pending"
+	WebhookStatusDelivered = "delivered"
+	WebhookStatusDead      = "dead"
+)
+
+// WebhookWatch is registered by an API key holder, Secret is used to sign payloads and only returned on creation
+type WebhookWatch struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Owner     string             `json:"-" bson:"owner"` // ID of owner's API key
+	Type      string             `json:"type" bson:"type"`
+	Address   string             `json:"address,omitempty" bson:"address,omitempty"`
+	Contract  string             `json:"contract,omitempty" bson:"contract,omitempty"`
+	Topic     string             `json:"topic,omitempty" bson:"topic,omitempty"`
+	URL       string             `json:"url" bson:"url"`
+	Secret    string             `json:"secret,omitempty" bson:"secret"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+}
+
+// WebhookEvent is the payload posted to watch URL, events of reorged blocks are sent again with Removed
+type WebhookEvent struct {
+	ID          string    `json:"id" bson:"id"`
+	WatchID     string    `json:"watchId" bson:"watchId"`
+	Type        string    `json:"type" bson:"type"`
+	Removed     bool      `json:"removed" bson:"removed"`
+	BlockHeight uint64    `json:"blockHeight" bson:"blockHeight"`
+	BlockHash   string    `json:"blockHash" bson:"blockHash"`
+	TxHash      string    `json:"txHash" bson:"txHash"`
+	Status      uint      `json:"status" bson:"status"`
+	From        string    `json:"from,omitempty" bson:"from,omitempty"`
+	To          string    `json:"to,omitempty" bson:"to,omitempty"`
+	Value       string    `json:"value,omitempty" bson:"value,omitempty"`
+	Contract    string    `json:"contract,omitempty" bson:"contract,omitempty"`
+	LogIndex    uint      `json:"logIndex,omitempty" bson:"logIndex,omitempty"`
+	MethodName  string    `json:"methodName,omitempty" bson:"methodName,omitempty"`
+	Topics      []string  `json:"topics,omitempty" bson:"topics,omitempty"`
+	Data        string    `json:"data,omitempty" bson:"data,omitempty"`
+	Time        time.Time `json:"time" bson:"time"`
+}
+
+type WebhookDelivery struct {
+	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Owner          string             `json:"-" bson:"owner"`
+	WatchID        string             `json:"watchId" bson:"watchId"`
+	EventID        string             `json:"-" bson:"eventId"`
+	Event          *WebhookEvent      `json:"event" bson:"event"`
+	Status         string             `json:"status" bson:"status"`
+	Attempts       int                `json:"attempts" bson:"attempts"`
+	NextAttemptAt  time.Time          `json:"nextAttemptAt" bson:"nextAttemptAt"`
+	LastStatusCode int                `json:"lastStatusCode,omitempty" bson:"lastStatusCode"`
+	LastError      string             `json:"lastError,omitempty" bson:"lastError"`
+	CreatedAt      time.Time          `json:"createdAt" bson:"createdAt"`
+	UpdatedAt      time.Time          `json:"updatedAt" bson:"updatedAt"`
+}
+
+type WebhookDeliveriesFilter struct {
+	Pagination *Pagination `bson:"-"`
+
+	Owner   string `bson:"owner"`
+	WatchID string `bson:"watchId,omitempty"`
+	Status  string `bson:"status,omitempty"`
+}
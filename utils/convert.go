@@ -55,3 +55,17 @@ func CalculateVotingPower(raw string, total *big.Int) (string, error) {
 	}
 	return result, nil
 }
+
+// CalculateSlashedAmount returns amount lost from staked amount, slash fraction is scaled by 1e18
+func CalculateSlashedAmount(stakedAmount, fraction string) (string, error) {
+	staked, ok := new(big.Int).SetString(stakedAmount, 10)
+	if !ok {
+		return "", fmt.Errorf("cannot convert staked amount from string to *big.Int")
+	}
+	slashFraction, ok := new(big.Int).SetString(fraction, 10)
+	if !ok {
+		return "", fmt.Errorf("cannot convert slash fraction from string to *big.Int")
+	}
+	lost := new(big.Int).Mul(staked, slashFraction)
+	return lost.Div(lost, Hydro).String(), nil
+}
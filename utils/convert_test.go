@@ -0,0 +1,18 @@
+// Package utils
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateSlashedAmount(t *testing.T) {
+	// 5% of 1000 KAI
+	lost, err := CalculateSlashedAmount("1000000000000000000000", "50000000000000000")
+	assert.Nil(t, err)
+	assert.Equal(t, "50000000000000000000", lost)
+
+	_, err = CalculateSlashedAmount("invalid", "50000000000000000")
+	assert.NotNil(t, err)
+}